@@ -129,6 +129,7 @@ func main() {
 		log.Fatal(err)
 	}
 	defer transporter.Close(ctx)
+	transporter.SetFormat(*Format)
 
 	switch *LogFmt {
 	case "json":
@@ -188,7 +189,11 @@ func main() {
 			continue
 		}
 
-		err = transporter.Send(key, data)
+		// the flow is passed to the transport for its routing (eg: Kafka topic template and key)
+		err = transporter.SendBatch([]transport.Message{{Key: key, Data: data, Source: msg}})
+		if err == nil {
+			err = transporter.Flush()
+		}
 		if err != nil {
 			log.Error(err)
 			continue
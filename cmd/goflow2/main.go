@@ -13,7 +13,10 @@ import (
 
 	// import various formatters
 	"github.com/netsampler/goflow2/format"
+	_ "github.com/netsampler/goflow2/format/avro"
 	_ "github.com/netsampler/goflow2/format/json"
+	_ "github.com/netsampler/goflow2/format/parquet"
+	_ "github.com/netsampler/goflow2/format/pcap"
 	_ "github.com/netsampler/goflow2/format/protobuf"
 	_ "github.com/netsampler/goflow2/format/text"
 
@@ -21,6 +24,8 @@ import (
 	"github.com/netsampler/goflow2/transport"
 	_ "github.com/netsampler/goflow2/transport/file"
 	_ "github.com/netsampler/goflow2/transport/kafka"
+	_ "github.com/netsampler/goflow2/transport/parquet"
+	_ "github.com/netsampler/goflow2/transport/pcap"
 
 	// import various NetFlow/IPFIX templates
 	"github.com/netsampler/goflow2/decoders/netflow/templates"
@@ -56,6 +61,9 @@ var (
 
 	MappingFile = flag.String("mapping", "", "Configuration file for custom mappings")
 
+	QuarantineDir = flag.String("quarantine.dir", "", "Directory where the datagrams which could not be decoded are written (disabled when empty)")
+	QuarantineMax = flag.Int("quarantine.max", 1000, "Maximum amount of datagrams written to the quarantine directory (0 for no limit)")
+
 	Version = flag.Bool("v", false, "Print version")
 )
 
@@ -101,6 +109,7 @@ func main() {
 		log.Fatal(err)
 	}
 	defer transporter.Close(ctx)
+	transporter.SetFormat(*Format)
 
 	// the following is only useful when parsing NetFlowV9/IPFIX (template-based flow)
 	templateSystem, err := templates.FindTemplateSystem(ctx, *NetFlowTemplates)
@@ -114,6 +123,14 @@ func main() {
 		log.SetFormatter(&log.JSONFormatter{})
 	}
 
+	var quarantine *utils.Quarantine
+	if *QuarantineDir != "" {
+		quarantine, err = utils.NewQuarantine(*QuarantineDir, *QuarantineMax, log.StandardLogger())
+		if err != nil {
+			log.Fatal(err)
+		}
+	}
+
 	log.Info("Starting GoFlow2")
 
 	go httpServer()
@@ -141,6 +158,46 @@ func main() {
 				numSockets = 1
 			}
 
+			udpOptions := utils.UDPOptions{
+				Quarantine: quarantine,
+			}
+			if listenAddrUrl.Query().Get("timestamp") == "kernel" {
+				udpOptions.KernelTimestamps = true
+			}
+			if listenAddrUrl.Query().Get("drops") == "kernel" {
+				udpOptions.KernelDrops = true
+			}
+			if listenAddrUrl.Query().Has("batch") {
+				if batchSize, err := strconv.ParseUint(listenAddrUrl.Query().Get("batch"), 10, 64); err != nil {
+					log.Fatal(err)
+				} else {
+					udpOptions.BatchSize = int(batchSize)
+				}
+			}
+			if listenAddrUrl.Query().Has("queue") {
+				if queueSize, err := strconv.ParseUint(listenAddrUrl.Query().Get("queue"), 10, 64); err != nil {
+					log.Fatal(err)
+				} else {
+					udpOptions.QueueSize = int(queueSize)
+				}
+			}
+			switch policy := listenAddrUrl.Query().Get("policy"); policy {
+			case "", utils.QUEUE_POLICY_BLOCK, utils.QUEUE_POLICY_DROP:
+				udpOptions.QueuePolicy = policy
+			default:
+				log.Fatalf("queue policy %s does not exist", policy)
+			}
+			if udpOptions.QueuePolicy == utils.QUEUE_POLICY_DROP && udpOptions.QueueSize == 0 {
+				log.Fatalf("queue policy %s requires a queue (queue=)", udpOptions.QueuePolicy)
+			}
+			if listenAddrUrl.Query().Has("rcvbuf") {
+				if receiveBuffer, err := strconv.ParseUint(listenAddrUrl.Query().Get("rcvbuf"), 10, 64); err != nil {
+					log.Fatal(err)
+				} else {
+					udpOptions.ReceiveBuffer = int(receiveBuffer)
+				}
+			}
+
 			hostname := listenAddrUrl.Hostname()
 			port, err := strconv.ParseUint(listenAddrUrl.Port(), 10, 64)
 			if err != nil {
@@ -149,10 +206,16 @@ func main() {
 			}
 
 			logFields := log.Fields{
-				"scheme":   listenAddrUrl.Scheme,
-				"hostname": hostname,
-				"port":     port,
-				"count":    numSockets,
+				"scheme":           listenAddrUrl.Scheme,
+				"hostname":         hostname,
+				"port":             port,
+				"count":            numSockets,
+				"kerneltimestamps": udpOptions.KernelTimestamps,
+				"kerneldrops":      udpOptions.KernelDrops,
+				"batch":            udpOptions.BatchSize,
+				"queue":            udpOptions.QueueSize,
+				"policy":           udpOptions.QueuePolicy,
+				"rcvbuf":           udpOptions.ReceiveBuffer,
 			}
 
 			log.WithFields(logFields).Info("Starting collection")
@@ -164,6 +227,8 @@ func main() {
 						Transport: transporter,
 						Logger:    log.StandardLogger(),
 						Config:    config,
+
+						UDPOptions: udpOptions,
 					}
 					err = sSFlow.FlowRoutine(*Workers, hostname, int(port), *ReusePort)
 				} else if listenAddrUrl.Scheme == "netflow" {
@@ -173,12 +238,15 @@ func main() {
 					sNF.Logger = log.StandardLogger()
 					sNF.Config = config
 					sNF.TemplateSystem = templateSystem
+					sNF.UDPOptions = udpOptions
 					err = sNF.FlowRoutine(*Workers, hostname, int(port), *ReusePort)
 				} else if listenAddrUrl.Scheme == "nfl" {
 					sNFL := &utils.StateNFLegacy{
 						Format:    formatter,
 						Transport: transporter,
 						Logger:    log.StandardLogger(),
+
+						UDPOptions: udpOptions,
 					}
 					err = sNFL.FlowRoutine(*Workers, hostname, int(port), *ReusePort)
 				} else {
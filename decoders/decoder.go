@@ -1,6 +1,11 @@
 package decoder
 
 import (
+	"context"
+	"fmt"
+	"hash/fnv"
+	"sync"
+	"sync/atomic"
 	"time"
 )
 
@@ -11,105 +16,222 @@ type DecoderFunc func(Message interface{}) error
 type DoneCallback func(string, int, time.Time, time.Time)
 type ErrorCallback func(string, int, time.Time, time.Time, error)
 
+// Returns the key of a message: the messages with the same key are decoded by the same worker, in order
+type KeyFunc func(Message) []byte
+
+// Result of the decoding of a message
+type Result struct {
+	Name    string
+	Worker  int
+	Message Message
+	Start   time.Time
+	End     time.Time
+	Err     error
+}
+
+type ResultCallback func(Result)
+
+// Error returned when the decoder panicked (eg: malformed packet)
+type ErrorDecoderPanic struct {
+	Value interface{}
+}
+
+func (e *ErrorDecoderPanic) Error() string {
+	return fmt.Sprintf("Decoder panic: %v", e.Value)
+}
+
+// Statistics of a worker
+type WorkerStats struct {
+	Processed uint64 // decoded messages, including errors
+	Errors    uint64
+	Panics    uint64 // also counted as errors
+	Queued    int    // messages waiting to be decoded
+}
+
 // Worker structure
 type Worker struct {
 	Id            int
 	DecoderParams DecoderParams
-	WorkerPool    chan chan Message
 	Name          string
 	InMsg         chan Message
-	Quit          chan bool
+
+	processed uint64
+	errors    uint64
+	panics    uint64
 }
 
-// Create a worker and add it to the pool.
-func CreateWorker(workerPool chan chan Message, decoderParams DecoderParams, id int, name string) Worker {
-	return Worker{
+// Create a worker with a queue of queueSize messages.
+func CreateWorker(decoderParams DecoderParams, id int, name string, queueSize int) *Worker {
+	return &Worker{
 		Id:            id,
 		DecoderParams: decoderParams,
-		WorkerPool:    workerPool,
 		Name:          name,
-		InMsg:         make(chan Message),
-		Quit:          make(chan bool),
-	}
-}
-
-// Start the worker. Launches a goroutine to process NFv9 messages.
-// The worker will add its input channel of NFv9 messages to decode to the pool.
-func (w Worker) Start() {
-	go func() {
-		//log.Debugf("Worker %v started", w.Id)
-		for {
-			select {
-			case <-w.Quit:
-				break
-			case w.WorkerPool <- w.InMsg:
-				msg := <-w.InMsg
-				timeTrackStart := time.Now()
-				err := w.DecoderParams.DecoderFunc(msg)
-				timeTrackStop := time.Now()
-
-				if err != nil && w.DecoderParams.ErrorCallback != nil {
-					w.DecoderParams.ErrorCallback(w.Name, w.Id, timeTrackStart, timeTrackStop, err)
-				} else if err == nil && w.DecoderParams.DoneCallback != nil {
-					w.DecoderParams.DoneCallback(w.Name, w.Id, timeTrackStart, timeTrackStop)
-				}
-			}
+		InMsg:         make(chan Message, queueSize),
+	}
+}
+
+// Decodes a message, a panic of the decoder is returned as an error.
+func (w *Worker) decode(msg Message) (err error) {
+	defer func() {
+		if r := recover(); r != nil {
+			atomic.AddUint64(&w.panics, 1)
+			err = &ErrorDecoderPanic{Value: r}
 		}
-		//log.Debugf("Worker %v done", w.Id)
 	}()
+	return w.DecoderParams.DecoderFunc(msg)
 }
 
-// Stop the worker.
-func (w Worker) Stop() {
-	//log.Debugf("Stopping worker %v", w.Id)
-	w.Quit <- true
+func (w *Worker) process(msg Message) {
+	timeTrackStart := time.Now()
+	err := w.decode(msg)
+	timeTrackStop := time.Now()
+
+	atomic.AddUint64(&w.processed, 1)
+	if err != nil {
+		atomic.AddUint64(&w.errors, 1)
+	}
+
+	if err != nil && w.DecoderParams.ErrorCallback != nil {
+		w.DecoderParams.ErrorCallback(w.Name, w.Id, timeTrackStart, timeTrackStop, err)
+	} else if err == nil && w.DecoderParams.DoneCallback != nil {
+		w.DecoderParams.DoneCallback(w.Name, w.Id, timeTrackStart, timeTrackStop)
+	}
+	if w.DecoderParams.ResultCallback != nil {
+		w.DecoderParams.ResultCallback(Result{
+			Name:    w.Name,
+			Worker:  w.Id,
+			Message: msg,
+			Start:   timeTrackStart,
+			End:     timeTrackStop,
+			Err:     err,
+		})
+	}
+}
+
+// Runs the worker until the context is cancelled or its input channel is closed.
+func (w *Worker) Run(ctx context.Context) {
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case msg, ok := <-w.InMsg:
+			if !ok {
+				return
+			}
+			w.process(msg)
+		}
+	}
+}
+
+func (w *Worker) Stats() WorkerStats {
+	return WorkerStats{
+		Processed: atomic.LoadUint64(&w.processed),
+		Errors:    atomic.LoadUint64(&w.errors),
+		Panics:    atomic.LoadUint64(&w.panics),
+		Queued:    len(w.InMsg),
+	}
 }
 
 // Processor structure
 type Processor struct {
-	workerpool    chan chan Message
-	workerlist    []Worker
+	workerlist    []*Worker
 	DecoderParams DecoderParams
 	Name          string
+
+	ctx    context.Context
+	cancel context.CancelFunc
+	wg     *sync.WaitGroup
+	next   uint64
 }
 
 // Decoder structure. Define the function to call and the config specific to the type of packets.
 type DecoderParams struct {
-	DecoderFunc   DecoderFunc
-	DoneCallback  DoneCallback
-	ErrorCallback ErrorCallback
+	DecoderFunc    DecoderFunc
+	DoneCallback   DoneCallback
+	ErrorCallback  ErrorCallback
+	ResultCallback ResultCallback // called after every message, with the message and the error
+
+	KeyFunc   KeyFunc // messages are distributed in round-robin when not set
+	QueueSize int     // messages buffered per worker
 }
 
-// Create a message processor which is going to create all the workers and set-up the pool.
-func CreateProcessor(numWorkers int, decoderParams DecoderParams, name string) Processor {
-	processor := Processor{
-		workerpool:    make(chan chan Message),
-		workerlist:    make([]Worker, numWorkers),
+// Create a message processor which is going to create all the workers.
+func CreateProcessor(numWorkers int, decoderParams DecoderParams, name string) *Processor {
+	if numWorkers < 1 {
+		numWorkers = 1
+	}
+	processor := &Processor{
+		workerlist:    make([]*Worker, numWorkers),
 		DecoderParams: decoderParams,
 		Name:          name,
+		wg:            &sync.WaitGroup{},
 	}
 	for i := 0; i < numWorkers; i++ {
-		worker := CreateWorker(processor.workerpool, decoderParams, i, name)
-		processor.workerlist[i] = worker
+		processor.workerlist[i] = CreateWorker(decoderParams, i, name, decoderParams.QueueSize)
 	}
 	return processor
 }
 
 // Start message processor
-func (p Processor) Start() {
+func (p *Processor) Start() {
+	p.StartContext(context.Background())
+}
+
+// Start message processor, the workers stop when the context is cancelled
+func (p *Processor) StartContext(ctx context.Context) {
+	p.ctx, p.cancel = context.WithCancel(ctx)
 	for _, worker := range p.workerlist {
-		worker.Start()
+		p.wg.Add(1)
+		go func(worker *Worker) {
+			defer p.wg.Done()
+			worker.Run(p.ctx)
+		}(worker)
 	}
 }
 
-func (p Processor) Stop() {
-	for _, worker := range p.workerlist {
-		worker.Stop()
+// Stops the workers and waits for the messages being decoded. The queued messages are discarded.
+func (p *Processor) Stop() {
+	if p.cancel != nil {
+		p.cancel()
+	}
+	p.wg.Wait()
+}
+
+func (p *Processor) selectWorker(msg Message) *Worker {
+	if p.DecoderParams.KeyFunc != nil {
+		h := fnv.New32a()
+		h.Write(p.DecoderParams.KeyFunc(msg))
+		return p.workerlist[h.Sum32()%uint32(len(p.workerlist))]
+	}
+	next := atomic.AddUint64(&p.next, 1)
+	return p.workerlist[next%uint64(len(p.workerlist))]
+}
+
+// Send a message to be decoded to a worker.
+// Blocks until the worker accepts the message, returns false if the processor is stopped.
+func (p *Processor) ProcessMessage(msg Message) bool {
+	worker := p.selectWorker(msg)
+	if p.ctx == nil {
+		worker.InMsg <- msg
+		return true
+	}
+	// with a queue, the send could be selected after the cancellation: the message would never be decoded
+	if p.ctx.Err() != nil {
+		return false
+	}
+	select {
+	case worker.InMsg <- msg:
+		return true
+	case <-p.ctx.Done():
+		return false
 	}
 }
 
-// Send a message to be decoded to the pool.
-func (p Processor) ProcessMessage(msg Message) {
-	sendChannel := <-p.workerpool
-	sendChannel <- msg
+// Returns the statistics of the workers
+func (p *Processor) Stats() []WorkerStats {
+	stats := make([]WorkerStats, len(p.workerlist))
+	for i, worker := range p.workerlist {
+		stats[i] = worker.Stats()
+	}
+	return stats
 }
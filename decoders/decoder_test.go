@@ -0,0 +1,155 @@
+package decoder
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestProcessorPanic(t *testing.T) {
+	var results []Result
+	var lock sync.Mutex
+	done := make(chan struct{}, 3)
+	processor := CreateProcessor(2, DecoderParams{
+		DecoderFunc: func(msg interface{}) error {
+			switch msg.(string) {
+			case "panic":
+				var b []byte
+				_ = b[10] // malformed packet
+			case "error":
+				return errors.New("error")
+			}
+			return nil
+		},
+		ResultCallback: func(result Result) {
+			lock.Lock()
+			results = append(results, result)
+			lock.Unlock()
+			done <- struct{}{}
+		},
+	}, "test")
+	processor.Start()
+	defer processor.Stop()
+
+	for _, msg := range []string{"panic", "error", "ok"} {
+		require.True(t, processor.ProcessMessage(msg))
+	}
+	for i := 0; i < 3; i++ {
+		select {
+		case <-done:
+		case <-time.After(5 * time.Second):
+			require.Fail(t, "test timed out while waiting for results")
+		}
+	}
+
+	lock.Lock()
+	defer lock.Unlock()
+	errs := make(map[string]error)
+	for _, result := range results {
+		errs[result.Message.(string)] = result.Err
+	}
+	assert.IsType(t, &ErrorDecoderPanic{}, errs["panic"])
+	assert.EqualError(t, errs["error"], "error")
+	assert.Nil(t, errs["ok"])
+
+	var stats WorkerStats
+	for _, workerStats := range processor.Stats() {
+		stats.Processed += workerStats.Processed
+		stats.Errors += workerStats.Errors
+		stats.Panics += workerStats.Panics
+	}
+	assert.Equal(t, WorkerStats{Processed: 3, Errors: 2, Panics: 1}, stats)
+}
+
+func TestProcessorKey(t *testing.T) {
+	var lock sync.Mutex
+	workers := make(map[string]map[int]bool)
+	order := make(map[string][]int)
+	wg := &sync.WaitGroup{}
+	processor := CreateProcessor(4, DecoderParams{
+		DecoderFunc: func(msg interface{}) error { return nil },
+		ResultCallback: func(result Result) {
+			msg := result.Message.([2]interface{})
+			key := msg[0].(string)
+			lock.Lock()
+			if workers[key] == nil {
+				workers[key] = make(map[int]bool)
+			}
+			workers[key][result.Worker] = true
+			order[key] = append(order[key], msg[1].(int))
+			lock.Unlock()
+			wg.Done()
+		},
+		KeyFunc: func(msg Message) []byte {
+			return []byte(msg.([2]interface{})[0].(string))
+		},
+		QueueSize: 16,
+	}, "test")
+	processor.Start()
+	defer processor.Stop()
+
+	keys := []string{"10.0.0.1", "10.0.0.2", "10.0.0.3"}
+	for i := 0; i < 100; i++ {
+		for _, key := range keys {
+			wg.Add(1)
+			processor.ProcessMessage([2]interface{}{key, i})
+		}
+	}
+	wg.Wait()
+
+	for _, key := range keys {
+		assert.Len(t, workers[key], 1, "messages of %s decoded by several workers", key)
+		for i, n := range order[key] {
+			assert.Equal(t, i, n)
+		}
+	}
+}
+
+func TestProcessorStop(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	block := make(chan struct{})
+	processor := CreateProcessor(1, DecoderParams{
+		DecoderFunc: func(msg interface{}) error {
+			<-block
+			return nil
+		},
+	}, "test")
+	processor.StartContext(ctx)
+
+	require.True(t, processor.ProcessMessage("decoding"))
+	close(block)
+	cancel()
+
+	stopped := make(chan struct{})
+	go func() {
+		processor.Stop()
+		close(stopped)
+	}()
+	select {
+	case <-stopped:
+	case <-time.After(5 * time.Second):
+		require.Fail(t, "test timed out while waiting for the workers")
+	}
+	assert.False(t, processor.ProcessMessage("stopped"))
+}
+
+func TestProcessorStopQueue(t *testing.T) {
+	processor := CreateProcessor(1, DecoderParams{
+		DecoderFunc: func(msg interface{}) error {
+			return nil
+		},
+		QueueSize: 10,
+	}, "test")
+	processor.Start()
+	processor.Stop()
+
+	for i := 0; i < 20; i++ {
+		assert.False(t, processor.ProcessMessage(i))
+	}
+	assert.Equal(t, 0, processor.Stats()[0].Queued)
+}
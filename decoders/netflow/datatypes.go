@@ -0,0 +1,198 @@
+package netflow
+
+// Abstract data types of Information Elements (RFC 7012, section 3.1)
+type IPFIXDataType int
+
+const (
+	IPFIX_TYPE_UNSIGNED IPFIXDataType = iota // unsigned8 to unsigned64
+	IPFIX_TYPE_SIGNED                        // signed8 to signed64
+	IPFIX_TYPE_FLOAT                         // float32 and float64
+	IPFIX_TYPE_BOOLEAN
+	IPFIX_TYPE_MAC_ADDRESS
+	IPFIX_TYPE_OCTET_ARRAY
+	IPFIX_TYPE_STRING
+	IPFIX_TYPE_DATETIME_SECONDS
+	IPFIX_TYPE_DATETIME_MILLISECONDS
+	IPFIX_TYPE_DATETIME_MICROSECONDS
+	IPFIX_TYPE_DATETIME_NANOSECONDS
+	IPFIX_TYPE_IPV4_ADDRESS
+	IPFIX_TYPE_IPV6_ADDRESS
+	IPFIX_TYPE_BASIC_LIST
+	IPFIX_TYPE_SUBTEMPLATE_LIST
+	IPFIX_TYPE_SUBTEMPLATE_MULTILIST
+)
+
+var (
+	// Only Information Elements which are not unsigned integers are listed
+	ipfixDataTypes = map[uint16]IPFIXDataType{
+		IPFIX_FIELD_sourceIPv4Address:                IPFIX_TYPE_IPV4_ADDRESS,
+		IPFIX_FIELD_destinationIPv4Address:           IPFIX_TYPE_IPV4_ADDRESS,
+		IPFIX_FIELD_ipNextHopIPv4Address:             IPFIX_TYPE_IPV4_ADDRESS,
+		IPFIX_FIELD_bgpNextHopIPv4Address:            IPFIX_TYPE_IPV4_ADDRESS,
+		IPFIX_FIELD_ipv4RouterSc:                     IPFIX_TYPE_IPV4_ADDRESS,
+		IPFIX_FIELD_sourceIPv4Prefix:                 IPFIX_TYPE_IPV4_ADDRESS,
+		IPFIX_FIELD_destinationIPv4Prefix:            IPFIX_TYPE_IPV4_ADDRESS,
+		IPFIX_FIELD_mplsTopLabelIPv4Address:          IPFIX_TYPE_IPV4_ADDRESS,
+		IPFIX_FIELD_exporterIPv4Address:              IPFIX_TYPE_IPV4_ADDRESS,
+		IPFIX_FIELD_collectorIPv4Address:             IPFIX_TYPE_IPV4_ADDRESS,
+		IPFIX_FIELD_postNATSourceIPv4Address:         IPFIX_TYPE_IPV4_ADDRESS,
+		IPFIX_FIELD_postNATDestinationIPv4Address:    IPFIX_TYPE_IPV4_ADDRESS,
+		IPFIX_FIELD_staIPv4Address:                   IPFIX_TYPE_IPV4_ADDRESS,
+		IPFIX_FIELD_originalExporterIPv4Address:      IPFIX_TYPE_IPV4_ADDRESS,
+		IPFIX_FIELD_pseudoWireDestinationIPv4Address: IPFIX_TYPE_IPV4_ADDRESS,
+		IPFIX_FIELD_mibObjectValueIPAddress:          IPFIX_TYPE_IPV4_ADDRESS,
+
+		IPFIX_FIELD_sourceIPv6Address:             IPFIX_TYPE_IPV6_ADDRESS,
+		IPFIX_FIELD_destinationIPv6Address:        IPFIX_TYPE_IPV6_ADDRESS,
+		IPFIX_FIELD_ipNextHopIPv6Address:          IPFIX_TYPE_IPV6_ADDRESS,
+		IPFIX_FIELD_bgpNextHopIPv6Address:         IPFIX_TYPE_IPV6_ADDRESS,
+		IPFIX_FIELD_exporterIPv6Address:           IPFIX_TYPE_IPV6_ADDRESS,
+		IPFIX_FIELD_mplsTopLabelIPv6Address:       IPFIX_TYPE_IPV6_ADDRESS,
+		IPFIX_FIELD_destinationIPv6Prefix:         IPFIX_TYPE_IPV6_ADDRESS,
+		IPFIX_FIELD_sourceIPv6Prefix:              IPFIX_TYPE_IPV6_ADDRESS,
+		IPFIX_FIELD_collectorIPv6Address:          IPFIX_TYPE_IPV6_ADDRESS,
+		IPFIX_FIELD_postNATSourceIPv6Address:      IPFIX_TYPE_IPV6_ADDRESS,
+		IPFIX_FIELD_postNATDestinationIPv6Address: IPFIX_TYPE_IPV6_ADDRESS,
+		IPFIX_FIELD_originalExporterIPv6Address:   IPFIX_TYPE_IPV6_ADDRESS,
+
+		IPFIX_FIELD_sourceMacAddress:                   IPFIX_TYPE_MAC_ADDRESS,
+		IPFIX_FIELD_postDestinationMacAddress:          IPFIX_TYPE_MAC_ADDRESS,
+		IPFIX_FIELD_destinationMacAddress:              IPFIX_TYPE_MAC_ADDRESS,
+		IPFIX_FIELD_postSourceMacAddress:               IPFIX_TYPE_MAC_ADDRESS,
+		IPFIX_FIELD_staMacAddress:                      IPFIX_TYPE_MAC_ADDRESS,
+		IPFIX_FIELD_wtpMacAddress:                      IPFIX_TYPE_MAC_ADDRESS,
+		IPFIX_FIELD_dot1qCustomerSourceMacAddress:      IPFIX_TYPE_MAC_ADDRESS,
+		IPFIX_FIELD_dot1qCustomerDestinationMacAddress: IPFIX_TYPE_MAC_ADDRESS,
+
+		IPFIX_FIELD_interfaceName:                 IPFIX_TYPE_STRING,
+		IPFIX_FIELD_interfaceDescription:          IPFIX_TYPE_STRING,
+		IPFIX_FIELD_samplerName:                   IPFIX_TYPE_STRING,
+		IPFIX_FIELD_applicationDescription:        IPFIX_TYPE_STRING,
+		IPFIX_FIELD_applicationName:               IPFIX_TYPE_STRING,
+		IPFIX_FIELD_className:                     IPFIX_TYPE_STRING,
+		IPFIX_FIELD_wlanSSID:                      IPFIX_TYPE_STRING,
+		IPFIX_FIELD_VRFname:                       IPFIX_TYPE_STRING,
+		IPFIX_FIELD_metroEvcId:                    IPFIX_TYPE_STRING,
+		IPFIX_FIELD_natPoolName:                   IPFIX_TYPE_STRING,
+		IPFIX_FIELD_p2pTechnology:                 IPFIX_TYPE_STRING,
+		IPFIX_FIELD_tunnelTechnology:              IPFIX_TYPE_STRING,
+		IPFIX_FIELD_encryptedTechnology:           IPFIX_TYPE_STRING,
+		IPFIX_FIELD_observationDomainName:         IPFIX_TYPE_STRING,
+		IPFIX_FIELD_selectorName:                  IPFIX_TYPE_STRING,
+		IPFIX_FIELD_informationElementDescription: IPFIX_TYPE_STRING,
+		IPFIX_FIELD_informationElementName:        IPFIX_TYPE_STRING,
+		IPFIX_FIELD_virtualStationInterfaceName:   IPFIX_TYPE_STRING,
+		IPFIX_FIELD_virtualStationName:            IPFIX_TYPE_STRING,
+		IPFIX_FIELD_userName:                      IPFIX_TYPE_STRING,
+		IPFIX_FIELD_applicationCategoryName:       IPFIX_TYPE_STRING,
+		IPFIX_FIELD_applicationSubCategoryName:    IPFIX_TYPE_STRING,
+		IPFIX_FIELD_applicationGroupName:          IPFIX_TYPE_STRING,
+		IPFIX_FIELD_mibContextName:                IPFIX_TYPE_STRING,
+		IPFIX_FIELD_mibObjectName:                 IPFIX_TYPE_STRING,
+		IPFIX_FIELD_mibObjectDescription:          IPFIX_TYPE_STRING,
+		IPFIX_FIELD_mibObjectSyntax:               IPFIX_TYPE_STRING,
+		IPFIX_FIELD_mibModuleName:                 IPFIX_TYPE_STRING,
+		IPFIX_FIELD_mobileIMSI:                    IPFIX_TYPE_STRING,
+		IPFIX_FIELD_mobileMSISDN:                  IPFIX_TYPE_STRING,
+		IPFIX_FIELD_httpRequestMethod:             IPFIX_TYPE_STRING,
+		IPFIX_FIELD_httpRequestHost:               IPFIX_TYPE_STRING,
+		IPFIX_FIELD_httpRequestTarget:             IPFIX_TYPE_STRING,
+		IPFIX_FIELD_httpMessageVersion:            IPFIX_TYPE_STRING,
+
+		IPFIX_FIELD_mplsTopLabelStackSection:  IPFIX_TYPE_OCTET_ARRAY,
+		IPFIX_FIELD_mplsLabelStackSection2:    IPFIX_TYPE_OCTET_ARRAY,
+		IPFIX_FIELD_mplsLabelStackSection3:    IPFIX_TYPE_OCTET_ARRAY,
+		IPFIX_FIELD_mplsLabelStackSection4:    IPFIX_TYPE_OCTET_ARRAY,
+		IPFIX_FIELD_mplsLabelStackSection5:    IPFIX_TYPE_OCTET_ARRAY,
+		IPFIX_FIELD_mplsLabelStackSection6:    IPFIX_TYPE_OCTET_ARRAY,
+		IPFIX_FIELD_mplsLabelStackSection7:    IPFIX_TYPE_OCTET_ARRAY,
+		IPFIX_FIELD_mplsLabelStackSection8:    IPFIX_TYPE_OCTET_ARRAY,
+		IPFIX_FIELD_mplsLabelStackSection9:    IPFIX_TYPE_OCTET_ARRAY,
+		IPFIX_FIELD_mplsLabelStackSection10:   IPFIX_TYPE_OCTET_ARRAY,
+		IPFIX_FIELD_mplsVpnRouteDistinguisher: IPFIX_TYPE_OCTET_ARRAY,
+		IPFIX_FIELD_applicationId:             IPFIX_TYPE_OCTET_ARRAY,
+		IPFIX_FIELD_layer2packetSectionData:   IPFIX_TYPE_OCTET_ARRAY,
+		IPFIX_FIELD_paddingOctets:             IPFIX_TYPE_OCTET_ARRAY,
+		IPFIX_FIELD_messageMD5Checksum:        IPFIX_TYPE_OCTET_ARRAY,
+		IPFIX_FIELD_opaqueOctets:              IPFIX_TYPE_OCTET_ARRAY,
+		IPFIX_FIELD_collectorCertificate:      IPFIX_TYPE_OCTET_ARRAY,
+		IPFIX_FIELD_exporterCertificate:       IPFIX_TYPE_OCTET_ARRAY,
+		IPFIX_FIELD_ipHeaderPacketSection:     IPFIX_TYPE_OCTET_ARRAY,
+		IPFIX_FIELD_ipPayloadPacketSection:    IPFIX_TYPE_OCTET_ARRAY,
+		IPFIX_FIELD_dataLinkFrameSection:      IPFIX_TYPE_OCTET_ARRAY,
+		IPFIX_FIELD_mplsLabelStackSection:     IPFIX_TYPE_OCTET_ARRAY,
+		IPFIX_FIELD_mplsPayloadPacketSection:  IPFIX_TYPE_OCTET_ARRAY,
+		IPFIX_FIELD_virtualStationInterfaceId: IPFIX_TYPE_OCTET_ARRAY,
+		IPFIX_FIELD_virtualStationUUID:        IPFIX_TYPE_OCTET_ARRAY,
+		IPFIX_FIELD_dot1qServiceInstanceTag:   IPFIX_TYPE_OCTET_ARRAY,
+		IPFIX_FIELD_mibObjectValueOctetString: IPFIX_TYPE_OCTET_ARRAY,
+		IPFIX_FIELD_mibObjectValueOID:         IPFIX_TYPE_OCTET_ARRAY,
+		IPFIX_FIELD_mibObjectValueBits:        IPFIX_TYPE_OCTET_ARRAY,
+		IPFIX_FIELD_mibObjectIdentifier:       IPFIX_TYPE_OCTET_ARRAY,
+		IPFIX_FIELD_mibContextEngineID:        IPFIX_TYPE_OCTET_ARRAY,
+		IPFIX_FIELD_internalAddressRealm:      IPFIX_TYPE_OCTET_ARRAY,
+		IPFIX_FIELD_externalAddressRealm:      IPFIX_TYPE_OCTET_ARRAY,
+
+		IPFIX_FIELD_flowStartSeconds:       IPFIX_TYPE_DATETIME_SECONDS,
+		IPFIX_FIELD_flowEndSeconds:         IPFIX_TYPE_DATETIME_SECONDS,
+		IPFIX_FIELD_maxExportSeconds:       IPFIX_TYPE_DATETIME_SECONDS,
+		IPFIX_FIELD_maxFlowEndSeconds:      IPFIX_TYPE_DATETIME_SECONDS,
+		IPFIX_FIELD_minExportSeconds:       IPFIX_TYPE_DATETIME_SECONDS,
+		IPFIX_FIELD_minFlowStartSeconds:    IPFIX_TYPE_DATETIME_SECONDS,
+		IPFIX_FIELD_observationTimeSeconds: IPFIX_TYPE_DATETIME_SECONDS,
+
+		IPFIX_FIELD_flowStartMilliseconds:               IPFIX_TYPE_DATETIME_MILLISECONDS,
+		IPFIX_FIELD_flowEndMilliseconds:                 IPFIX_TYPE_DATETIME_MILLISECONDS,
+		IPFIX_FIELD_systemInitTimeMilliseconds:          IPFIX_TYPE_DATETIME_MILLISECONDS,
+		IPFIX_FIELD_collectionTimeMilliseconds:          IPFIX_TYPE_DATETIME_MILLISECONDS,
+		IPFIX_FIELD_maxFlowEndMilliseconds:              IPFIX_TYPE_DATETIME_MILLISECONDS,
+		IPFIX_FIELD_minFlowStartMilliseconds:            IPFIX_TYPE_DATETIME_MILLISECONDS,
+		IPFIX_FIELD_observationTimeMilliseconds:         IPFIX_TYPE_DATETIME_MILLISECONDS,
+		IPFIX_FIELD_monitoringIntervalStartMilliSeconds: IPFIX_TYPE_DATETIME_MILLISECONDS,
+		IPFIX_FIELD_monitoringIntervalEndMilliSeconds:   IPFIX_TYPE_DATETIME_MILLISECONDS,
+
+		IPFIX_FIELD_flowStartMicroseconds:       IPFIX_TYPE_DATETIME_MICROSECONDS,
+		IPFIX_FIELD_flowEndMicroseconds:         IPFIX_TYPE_DATETIME_MICROSECONDS,
+		IPFIX_FIELD_maxFlowEndMicroseconds:      IPFIX_TYPE_DATETIME_MICROSECONDS,
+		IPFIX_FIELD_minFlowStartMicroseconds:    IPFIX_TYPE_DATETIME_MICROSECONDS,
+		IPFIX_FIELD_observationTimeMicroseconds: IPFIX_TYPE_DATETIME_MICROSECONDS,
+
+		IPFIX_FIELD_flowStartNanoseconds:       IPFIX_TYPE_DATETIME_NANOSECONDS,
+		IPFIX_FIELD_flowEndNanoseconds:         IPFIX_TYPE_DATETIME_NANOSECONDS,
+		IPFIX_FIELD_maxFlowEndNanoseconds:      IPFIX_TYPE_DATETIME_NANOSECONDS,
+		IPFIX_FIELD_minFlowStartNanoseconds:    IPFIX_TYPE_DATETIME_NANOSECONDS,
+		IPFIX_FIELD_observationTimeNanoseconds: IPFIX_TYPE_DATETIME_NANOSECONDS,
+
+		IPFIX_FIELD_samplingProbability: IPFIX_TYPE_FLOAT,
+		IPFIX_FIELD_absoluteError:       IPFIX_TYPE_FLOAT,
+		IPFIX_FIELD_relativeError:       IPFIX_TYPE_FLOAT,
+		IPFIX_FIELD_upperCILimit:        IPFIX_TYPE_FLOAT,
+		IPFIX_FIELD_lowerCILimit:        IPFIX_TYPE_FLOAT,
+		IPFIX_FIELD_confidenceLevel:     IPFIX_TYPE_FLOAT,
+
+		IPFIX_FIELD_dataRecordsReliability: IPFIX_TYPE_BOOLEAN,
+		IPFIX_FIELD_hashDigestOutput:       IPFIX_TYPE_BOOLEAN,
+		IPFIX_FIELD_dot1qDEI:               IPFIX_TYPE_BOOLEAN,
+		IPFIX_FIELD_dot1qCustomerDEI:       IPFIX_TYPE_BOOLEAN,
+
+		IPFIX_FIELD_mibObjectValueInteger: IPFIX_TYPE_SIGNED,
+
+		IPFIX_FIELD_basicList:            IPFIX_TYPE_BASIC_LIST,
+		IPFIX_FIELD_subTemplateList:      IPFIX_TYPE_SUBTEMPLATE_LIST,
+		IPFIX_FIELD_mibObjectValueTable:  IPFIX_TYPE_SUBTEMPLATE_LIST,
+		IPFIX_FIELD_mibObjectValueRow:    IPFIX_TYPE_SUBTEMPLATE_LIST,
+		IPFIX_FIELD_subTemplateMultiList: IPFIX_TYPE_SUBTEMPLATE_MULTILIST,
+	}
+)
+
+// Returns the abstract data type of an IANA Information Element.
+// Unknown elements are considered unsigned integers when up to 8 bytes long.
+func IPFIXTypeToDataType(typeId uint16, length int) IPFIXDataType {
+	if dataType, ok := ipfixDataTypes[typeId]; ok {
+		return dataType
+	}
+	if length > 8 {
+		return IPFIX_TYPE_OCTET_ARRAY
+	}
+	return IPFIX_TYPE_UNSIGNED
+}
@@ -445,6 +445,9 @@ const (
 	IPFIX_FIELD_natThresholdEvent                     = 467
 )
 
+// Private Enterprise Number of the reverse Information Elements of bidirectional flows (RFC 5103)
+const IPFIX_PEN_REVERSE = 29305
+
 type IPFIXPacket struct {
 	Version             uint16
 	Length              uint16
@@ -467,9 +470,8 @@ type IPFIXOptionsTemplateRecord struct {
 	Scopes          []Field
 }
 
-func IPFIXTypeToString(typeId uint16) string {
-
-	nameList := map[uint16]string{
+var (
+	ipfixTypeNames = map[uint16]string{
 		0:   "Reserved",
 		1:   "octetDeltaCount",
 		2:   "packetDeltaCount",
@@ -916,13 +918,15 @@ func IPFIXTypeToString(typeId uint16) string {
 		466: "natQuotaExceededEvent",
 		467: "natThresholdEvent",
 	}
+)
 
+func IPFIXTypeToString(typeId uint16) string {
 	if typeId >= 105 && typeId <= 127 {
 		return "Assigned for NetFlow v9 compatibility"
 	} else if typeId >= 468 && typeId <= 32767 {
 		return "Unassigned"
 	} else {
-		return nameList[typeId]
+		return ipfixTypeNames[typeId]
 	}
 }
 
@@ -38,11 +38,11 @@ func (w *TemplateWrapper) getTemplateId(template interface{}) (templateId uint16
 }
 
 func (w TemplateWrapper) GetTemplate(version uint16, obsDomainId uint32, templateId uint16) (interface{}, error) {
-	return w.Inner.GetTemplate(w.Ctx, &templates.TemplateKey{w.Key, version, obsDomainId, templateId})
+	return w.Inner.GetTemplate(w.Ctx, &templates.TemplateKey{TemplateKey: w.Key, Version: version, ObsDomainId: obsDomainId, TemplateId: templateId})
 }
 
 func (w TemplateWrapper) AddTemplate(version uint16, obsDomainId uint32, template interface{}) {
-	w.Inner.AddTemplate(w.Ctx, &templates.TemplateKey{w.Key, version, obsDomainId, w.getTemplateId(template)}, template)
+	w.Inner.AddTemplate(w.Ctx, &templates.TemplateKey{TemplateKey: w.Key, Version: version, ObsDomainId: obsDomainId, TemplateId: w.getTemplateId(template)}, template)
 }
 
 func DecodeNFv9OptionsTemplateSet(payload *bytes.Buffer) ([]NFv9OptionsTemplateRecord, error) {
@@ -61,23 +61,23 @@ func DecodeNFv9OptionsTemplateSet(payload *bytes.Buffer) ([]NFv9OptionsTemplateR
 			return records, fmt.Errorf("Error decoding OptionsTemplateSet: negative length.")
 		}
 
-		fields := make([]Field, sizeScope)
+		fields := make([]Field, 0, sizeScope)
 		for i := 0; i < sizeScope; i++ {
 			field := Field{}
 			if err := DecodeField(payload, &field, false); err != nil {
 				return records, err
 			}
-			fields[i] = field
+			fields = appendField(fields, field)
 		}
 		optsTemplateRecord.Scopes = fields
 
-		fields = make([]Field, sizeOptions)
+		fields = make([]Field, 0, sizeOptions)
 		for i := 0; i < sizeOptions; i++ {
 			field := Field{}
 			if err := DecodeField(payload, &field, false); err != nil {
 				return records, err
 			}
-			fields[i] = field
+			fields = appendField(fields, field)
 		}
 		optsTemplateRecord.Options = fields
 
@@ -96,6 +96,15 @@ func DecodeField(payload *bytes.Buffer, field *Field, pen bool) error {
 	return err
 }
 
+// Adds a field to a template. Fields of zero length carry no value and are ignored:
+// each decoded field then consumes at least one byte of a data set.
+func appendField(fields []Field, field Field) []Field {
+	if field.Length == 0 {
+		return fields
+	}
+	return append(fields, field)
+}
+
 func DecodeIPFIXOptionsTemplateSet(payload *bytes.Buffer) ([]IPFIXOptionsTemplateRecord, error) {
 	var records []IPFIXOptionsTemplateRecord
 	var err error
@@ -106,13 +115,13 @@ func DecodeIPFIXOptionsTemplateSet(payload *bytes.Buffer) ([]IPFIXOptionsTemplat
 			return records, err
 		}
 
-		fields := make([]Field, int(optsTemplateRecord.ScopeFieldCount))
+		fields := make([]Field, 0, int(optsTemplateRecord.ScopeFieldCount))
 		for i := 0; i < int(optsTemplateRecord.ScopeFieldCount); i++ {
 			field := Field{}
 			if err := DecodeField(payload, &field, true); err != nil {
 				return records, err
 			}
-			fields[i] = field
+			fields = appendField(fields, field)
 		}
 		optsTemplateRecord.Scopes = fields
 
@@ -120,13 +129,13 @@ func DecodeIPFIXOptionsTemplateSet(payload *bytes.Buffer) ([]IPFIXOptionsTemplat
 		if optionsSize < 0 {
 			return records, fmt.Errorf("Error decoding OptionsTemplateSet: negative length.")
 		}
-		fields = make([]Field, optionsSize)
+		fields = make([]Field, 0, optionsSize)
 		for i := 0; i < optionsSize; i++ {
 			field := Field{}
 			if err := DecodeField(payload, &field, true); err != nil {
 				return records, err
 			}
-			fields[i] = field
+			fields = appendField(fields, field)
 		}
 		optsTemplateRecord.Options = fields
 
@@ -150,7 +159,7 @@ func DecodeTemplateSet(version uint16, payload *bytes.Buffer) ([]TemplateRecord,
 			return records, fmt.Errorf("Error decoding TemplateSet: zero count.")
 		}
 
-		fields := make([]Field, int(templateRecord.FieldCount))
+		fields := make([]Field, 0, int(templateRecord.FieldCount))
 		for i := 0; i < int(templateRecord.FieldCount); i++ {
 			field := Field{}
 			err := utils.BinaryDecoder(payload, &field.Type, &field.Length)
@@ -162,7 +171,7 @@ func DecodeTemplateSet(version uint16, payload *bytes.Buffer) ([]TemplateRecord,
 			if err != nil {
 				return records, err
 			}
-			fields[i] = field
+			fields = appendField(fields, field)
 		}
 		templateRecord.Fields = fields
 		records = append(records, templateRecord)
@@ -249,8 +258,12 @@ func DecodeOptionsDataSet(version uint16, payload *bytes.Buffer, listFieldsScope
 	listFieldsOptionSize := GetTemplateSize(version, listFieldsOption)
 
 	for payload.Len() >= listFieldsScopesSize+listFieldsOptionSize {
+		remaining := payload.Len()
 		scopeValues := DecodeDataSetUsingFields(version, payload, listFieldsScopes)
 		optionValues := DecodeDataSetUsingFields(version, payload, listFieldsOption)
+		if payload.Len() == remaining {
+			break // nothing decoded (empty template or truncated record)
+		}
 
 		record := OptionsDataRecord{
 			ScopesValues:  scopeValues,
@@ -267,7 +280,11 @@ func DecodeDataSet(version uint16, payload *bytes.Buffer, listFields []Field) ([
 
 	listFieldsSize := GetTemplateSize(version, listFields)
 	for payload.Len() >= listFieldsSize {
+		remaining := payload.Len()
 		values := DecodeDataSetUsingFields(version, payload, listFields)
+		if payload.Len() == remaining {
+			break // nothing decoded (empty template or truncated record)
+		}
 
 		record := DataRecord{
 			Values: values,
@@ -2,6 +2,7 @@ package netflow
 
 import (
 	"bytes"
+	"context"
 	"testing"
 
 	"github.com/stretchr/testify/assert"
@@ -414,3 +415,33 @@ func TestDecodeNetFlowV9(t *testing.T) {
 `,
 		decNfv9.String())
 }
+
+func FuzzDecodeMessageContext(f *testing.F) {
+	// NetFlow v9: template, options template and their data
+	f.Add([]byte{
+		0x00, 0x09, 0x00, 0x04, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x01,
+		0x00, 0x00, 0x00, 0x00,
+		0x00, 0x00, 0x00, 0x10, 0x01, 0x00, 0x00, 0x02, 0x00, 0x08, 0x00, 0x04, 0x00, 0x0c, 0x00, 0x04,
+		0x00, 0x01, 0x00, 0x12, 0x01, 0x01, 0x00, 0x04, 0x00, 0x04, 0x00, 0x02, 0x00, 0x04, 0x00, 0x22,
+		0x00, 0x04,
+		0x01, 0x00, 0x00, 0x0c, 0x0a, 0x00, 0x00, 0x01, 0x0a, 0x00, 0x00, 0x02,
+		0x01, 0x01, 0x00, 0x0c, 0x00, 0x00, 0x00, 0x05, 0x00, 0x00, 0x00, 0x64,
+	})
+	// IPFIX: template with an enterprise field, options template with a variable length field and their data
+	f.Add([]byte{
+		0x00, 0x0a, 0x00, 0x4e, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x01, 0x00, 0x00, 0x00, 0x00,
+		0x00, 0x02, 0x00, 0x14, 0x01, 0x00, 0x00, 0x02, 0x00, 0x08, 0x00, 0x04, 0x80, 0x01, 0x00, 0x04,
+		0x00, 0x00, 0x72, 0x79,
+		0x00, 0x03, 0x00, 0x12, 0x01, 0x01, 0x00, 0x02, 0x00, 0x01, 0x00, 0x0a, 0x00, 0x04, 0x00, 0x52,
+		0xff, 0xff,
+		0x01, 0x00, 0x00, 0x0c, 0x0a, 0x00, 0x00, 0x01, 0x00, 0x00, 0x00, 0x64,
+		0x01, 0x01, 0x00, 0x0c, 0x00, 0x00, 0x00, 0x02, 0x03, 0x65, 0x74, 0x68,
+	})
+	f.Fuzz(func(t *testing.T, data []byte) {
+		templates := CreateTemplateSystem()
+		// decoded twice: the data sets can use the templates of the first pass
+		for i := 0; i < 2; i++ {
+			_, _ = DecodeMessageContext(context.Background(), bytes.NewBuffer(data), "", templates)
+		}
+	})
+}
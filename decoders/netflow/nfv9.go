@@ -101,6 +101,22 @@ const (
 	NFV9_FIELD_layer2packetSectionData      = 104
 )
 
+// Cisco ASA NetFlow Secure Event Logging (NSEL) fields.
+// The other NSEL fields use the IPFIX numbers (firewallEvent, postNATSourceIPv4Address...)
+const (
+	NFV9_FIELD_NSEL_INGRESS_ACL_ID = 33000 // ACL ID, ACE ID and extended ACE ID
+	NFV9_FIELD_NSEL_EGRESS_ACL_ID  = 33001
+	NFV9_FIELD_NSEL_FW_EXT_EVENT   = 33002
+	NFV9_FIELD_NSEL_USERNAME       = 40000
+
+	// legacy IDs of the ASA releases before 8.4(2), instead of 225 to 228 and 233
+	NFV9_FIELD_NSEL_XLATE_SRC_ADDR_84 = 40001
+	NFV9_FIELD_NSEL_XLATE_DST_ADDR_84 = 40002
+	NFV9_FIELD_NSEL_XLATE_SRC_PORT_84 = 40003
+	NFV9_FIELD_NSEL_XLATE_DST_PORT_84 = 40004
+	NFV9_FIELD_NSEL_FW_EVENT_84       = 40005
+)
+
 type NFv9Packet struct {
 	Version        uint16
 	Count          uint16
@@ -124,9 +140,8 @@ type NFv9OptionsTemplateRecord struct {
 	Options      []Field
 }
 
-func NFv9TypeToString(typeId uint16) string {
-
-	nameList := map[uint16]string{
+var (
+	nfv9TypeNames = map[uint16]string{
 		1:   "IN_BYTES",
 		2:   "IN_PKTS",
 		3:   "FLOWS",
@@ -232,11 +247,13 @@ func NFv9TypeToString(typeId uint16) string {
 		234: "ingressVRFID",
 		235: "egressVRFID",
 	}
+)
 
+func NFv9TypeToString(typeId uint16) string {
 	if typeId > 104 || typeId == 0 {
 		return "Unassigned"
 	} else {
-		return nameList[typeId]
+		return nfv9TypeNames[typeId]
 	}
 }
 
@@ -42,7 +42,7 @@ func (k *TemplateKey) String() string {
 }
 
 func ParseTemplateKey(key string, k *TemplateKey) error {
-	if k != nil {
+	if k == nil {
 		return nil
 	}
 	var version uint16
@@ -60,12 +60,12 @@ func ParseTemplateKey(key string, k *TemplateKey) error {
 		version = uint16(val)
 	}
 	if val, err := strconv.ParseUint(keySplit[2], 10, 64); err != nil {
-		fmt.Errorf("template key observation domain I Dis invalid")
+		return fmt.Errorf("template key observation domain ID is invalid")
 	} else {
 		obsDomainId = uint32(val)
 	}
 	if val, err := strconv.ParseUint(keySplit[3], 10, 64); err != nil {
-		fmt.Errorf("template key template ID is invalid")
+		return fmt.Errorf("template key template ID is invalid")
 	} else {
 		templateId = uint16(val)
 	}
@@ -31,7 +31,7 @@ func DecodeMessage(payload *bytes.Buffer) (interface{}, error) {
 	if version == 5 {
 		packet.Version = version
 
-		utils.BinaryDecoder(payload,
+		err = utils.BinaryDecoder(payload,
 			&(packet.Count),
 			&(packet.SysUptime),
 			&(packet.UnixSecs),
@@ -41,9 +41,17 @@ func DecodeMessage(payload *bytes.Buffer) (interface{}, error) {
 			&(packet.EngineId),
 			&(packet.SamplingInterval),
 		)
+		if err != nil {
+			return nil, err
+		}
 
-		packet.Records = make([]RecordsNetFlowV5, int(packet.Count))
-		for i := 0; i < int(packet.Count) && payload.Len() >= 48; i++ {
+		// the count cannot exceed the records present in the packet
+		count := int(packet.Count)
+		if count > payload.Len()/48 {
+			count = payload.Len() / 48
+		}
+		packet.Records = make([]RecordsNetFlowV5, count)
+		for i := 0; i < count; i++ {
 			record := RecordsNetFlowV5{}
 			err := utils.BinaryDecoder(payload, &record)
 			if err != nil {
@@ -39,3 +39,16 @@ func TestDecodeNetFlowV5(t *testing.T) {
 	assert.Equal(t, uint16(5), decNfv5.Version)
 	assert.Equal(t, uint16(9), decNfv5.Records[0].Input)
 }
+
+func FuzzDecodeMessage(f *testing.F) {
+	f.Add([]byte{
+		0x00, 0x05, 0x00, 0x01, 0x00, 0x82, 0xc3, 0x48, 0x5b, 0xcd, 0xba, 0x1b, 0x05, 0x97, 0x6d, 0xc7,
+		0x00, 0x00, 0x64, 0x3d, 0x08, 0x08, 0x00, 0x00, 0x0a, 0x80, 0x02, 0x79, 0x0a, 0x80, 0x02, 0x01,
+		0x00, 0x00, 0x00, 0x00, 0x00, 0x09, 0x00, 0x02, 0x00, 0x00, 0x00, 0x05, 0x00, 0x00, 0x02, 0x4e,
+		0x00, 0x82, 0x9b, 0x8c, 0x00, 0x82, 0x9b, 0x90, 0x1f, 0x90, 0xb9, 0x18, 0x00, 0x1b, 0x06, 0x00,
+		0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00,
+	})
+	f.Fuzz(func(t *testing.T, data []byte) {
+		_, _ = DecodeMessage(bytes.NewBuffer(data))
+	})
+}
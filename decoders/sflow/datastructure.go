@@ -64,6 +64,103 @@ type ExtendedGateway struct {
 	LocalPref         uint32
 }
 
+type ExtendedUser struct {
+	SrcCharset uint32
+	SrcUser    string
+	DstCharset uint32
+	DstUser    string
+}
+
+type ExtendedURL struct {
+	Direction uint32 // 1: source, 2: destination
+	URL       string
+	Host      string
+}
+
+type ExtendedMPLS struct {
+	NextHopIPVersion uint32
+	NextHop          []byte
+	InLabels         []uint32
+	OutLabels        []uint32
+}
+
+type ExtendedNAT struct {
+	SrcIPVersion uint32
+	SrcIP        []byte
+	DstIPVersion uint32
+	DstIP        []byte
+}
+
+type ExtendedNATPort struct {
+	SrcPort uint32
+	DstPort uint32
+}
+
+type ExtendedMPLSTunnel struct {
+	Name      string
+	TunnelId  uint32
+	TunnelCos uint32
+}
+
+type ExtendedMPLSVC struct {
+	InstanceName string
+	VCId         uint32
+	VCLabelCos   uint32
+}
+
+type ExtendedMPLSFTN struct {
+	Description string
+	Mask        uint32
+}
+
+type Extended80211Rx struct {
+	SSID           string
+	BSSID          []byte
+	Version        uint32
+	Channel        uint32
+	Speed          uint64
+	RSNI           uint32
+	RCPI           uint32
+	PacketDuration uint32
+}
+
+type Extended80211Tx struct {
+	SSID            string
+	BSSID           []byte
+	Version         uint32
+	Transmissions   uint32
+	PacketDuration  uint32
+	RetransDuration uint32
+	Channel         uint32
+	Speed           uint64
+	Power           uint32
+}
+
+// Outer IPv4 header of a tunnel
+type ExtendedIPv4TunnelEgress struct {
+	Header SampledIPv4
+}
+
+type ExtendedIPv4TunnelIngress struct {
+	Header SampledIPv4
+}
+
+type ExtendedVNIEgress struct {
+	VNI uint32
+}
+
+type ExtendedVNIIngress struct {
+	VNI uint32
+}
+
+type ExtendedEgressQueue struct {
+	Queue uint32
+}
+
+type ExtendedLinuxDropReason struct {
+	Reason string
+}
+
 type IfCounters struct {
 	IfIndex            uint32
 	IfType             uint32
@@ -53,6 +53,18 @@ type ExpandedFlowSample struct {
 	Records          []FlowRecord
 }
 
+// Drop notification (discarded packet)
+type DropSample struct {
+	Header SampleHeader
+
+	Drops            uint32
+	Input            uint32
+	Output           uint32
+	Reason           uint32
+	FlowRecordsCount uint32
+	Records          []FlowRecord
+}
+
 type RecordHeader struct {
 	DataFormat uint32
 	Length     uint32
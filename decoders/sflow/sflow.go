@@ -9,13 +9,30 @@ import (
 )
 
 const (
-	FORMAT_EXT_SWITCH  = 1001
-	FORMAT_EXT_ROUTER  = 1002
-	FORMAT_EXT_GATEWAY = 1003
-	FORMAT_RAW_PKT     = 1
-	FORMAT_ETH         = 2
-	FORMAT_IPV4        = 3
-	FORMAT_IPV6        = 4
+	FORMAT_EXT_SWITCH              = 1001
+	FORMAT_EXT_ROUTER              = 1002
+	FORMAT_EXT_GATEWAY             = 1003
+	FORMAT_EXT_USER                = 1004
+	FORMAT_EXT_URL                 = 1005
+	FORMAT_EXT_MPLS                = 1006
+	FORMAT_EXT_NAT                 = 1007
+	FORMAT_EXT_MPLS_TUNNEL         = 1008
+	FORMAT_EXT_MPLS_VC             = 1009
+	FORMAT_EXT_MPLS_FTN            = 1010
+	FORMAT_EXT_80211_RX            = 1014
+	FORMAT_EXT_80211_TX            = 1015
+	FORMAT_EXT_NAT_PORT            = 1020
+	FORMAT_EXT_IPV4_TUNNEL_EGRESS  = 1023
+	FORMAT_EXT_IPV4_TUNNEL_INGRESS = 1024
+	FORMAT_EXT_VNI_EGRESS          = 1029
+	FORMAT_EXT_VNI_INGRESS         = 1030
+	FORMAT_EXT_EGRESS_QUEUE        = 1036
+	FORMAT_EXT_LINUX_DROP_REASON   = 1042
+	FORMAT_RAW_PKT                 = 1
+	FORMAT_ETH                     = 2
+	FORMAT_IPV4                    = 3
+	FORMAT_IPV6                    = 4
+	FORMAT_DISCARDED_PKT           = 5 // drop notification sample
 )
 
 type ErrorDecodingSFlow struct {
@@ -127,6 +144,70 @@ func DecodeIP(payload *bytes.Buffer) (uint32, []byte, error) {
 	return ipVersion, ip, nil
 }
 
+// Decodes a variable length XDR opaque (or string): length followed by data padded to 4 bytes
+func DecodeOpaque(payload *bytes.Buffer, maxLength int) ([]byte, error) {
+	var length uint32
+	err := utils.BinaryDecoder(payload, &length)
+	if err != nil {
+		return nil, err
+	}
+	if maxLength > 0 && int(length) > maxLength {
+		return nil, NewErrorDecodingSFlow(fmt.Sprintf("Opaque too long: %v, maximum %v.", length, maxLength))
+	}
+	padded := (int(length) + 3) &^ 3
+	if int(length) > payload.Len() {
+		return nil, NewErrorDecodingSFlow(fmt.Sprintf("Not enough data: %v, needs %v.", payload.Len(), length))
+	}
+	data := make([]byte, length)
+	copy(data, payload.Next(int(length)))
+	payload.Next(padded - int(length))
+	return data, nil
+}
+
+func DecodeLabelStack(payload *bytes.Buffer) ([]uint32, error) {
+	var count uint32
+	err := utils.BinaryDecoder(payload, &count)
+	if err != nil {
+		return nil, err
+	}
+	if int(count) > payload.Len()/4 {
+		return nil, NewErrorDecodingSFlow(fmt.Sprintf("Invalid label stack length: %v.", count))
+	}
+	labels := make([]uint32, count)
+	if len(labels) > 0 {
+		err = utils.BinaryDecoder(payload, labels)
+		if err != nil {
+			return nil, err
+		}
+	}
+	return labels, nil
+}
+
+func DecodeSampledIPv4(payload *bytes.Buffer) (SampledIPv4, error) {
+	sampledIPBase := SampledIP_Base{
+		SrcIP: make([]byte, 4),
+		DstIP: make([]byte, 4),
+	}
+	err := utils.BinaryDecoder(payload, &sampledIPBase)
+	if err != nil {
+		return SampledIPv4{}, err
+	}
+	sampledIPv4 := SampledIPv4{
+		Base: sampledIPBase,
+	}
+	err = utils.BinaryDecoder(payload, &(sampledIPv4.Tos))
+	return sampledIPv4, err
+}
+
+func decodeMac(payload *bytes.Buffer) ([]byte, error) {
+	mac := make([]byte, 8) // 6 bytes padded to 4
+	err := utils.BinaryDecoder(payload, mac)
+	if err != nil {
+		return nil, err
+	}
+	return mac[0:6], nil
+}
+
 func DecodeFlowRecord(header *RecordHeader, payload *bytes.Buffer) (FlowRecord, error) {
 	flowRecord := FlowRecord{
 		Header: *header,
@@ -148,18 +229,7 @@ func DecodeFlowRecord(header *RecordHeader, payload *bytes.Buffer) (FlowRecord,
 		sampledHeader.HeaderData = payload.Bytes()
 		flowRecord.Data = sampledHeader
 	case FORMAT_IPV4:
-		sampledIPBase := SampledIP_Base{
-			SrcIP: make([]byte, 4),
-			DstIP: make([]byte, 4),
-		}
-		err := utils.BinaryDecoder(payload, &sampledIPBase)
-		if err != nil {
-			return flowRecord, err
-		}
-		sampledIPv4 := SampledIPv4{
-			Base: sampledIPBase,
-		}
-		err = utils.BinaryDecoder(payload, &(sampledIPv4.Tos))
+		sampledIPv4, err := DecodeSampledIPv4(payload)
 		if err != nil {
 			return flowRecord, err
 		}
@@ -248,6 +318,198 @@ func DecodeFlowRecord(header *RecordHeader, payload *bytes.Buffer) (FlowRecord,
 		extendedGateway.Communities = communities
 
 		flowRecord.Data = extendedGateway
+	case FORMAT_EXT_USER:
+		extendedUser := ExtendedUser{}
+		err := utils.BinaryDecoder(payload, &(extendedUser.SrcCharset))
+		if err != nil {
+			return flowRecord, err
+		}
+		user, err := DecodeOpaque(payload, 0)
+		if err != nil {
+			return flowRecord, err
+		}
+		extendedUser.SrcUser = string(user)
+		err = utils.BinaryDecoder(payload, &(extendedUser.DstCharset))
+		if err != nil {
+			return flowRecord, err
+		}
+		user, err = DecodeOpaque(payload, 0)
+		if err != nil {
+			return flowRecord, err
+		}
+		extendedUser.DstUser = string(user)
+		flowRecord.Data = extendedUser
+	case FORMAT_EXT_URL:
+		extendedURL := ExtendedURL{}
+		err := utils.BinaryDecoder(payload, &(extendedURL.Direction))
+		if err != nil {
+			return flowRecord, err
+		}
+		url, err := DecodeOpaque(payload, 0)
+		if err != nil {
+			return flowRecord, err
+		}
+		extendedURL.URL = string(url)
+		host, err := DecodeOpaque(payload, 0)
+		if err != nil {
+			return flowRecord, err
+		}
+		extendedURL.Host = string(host)
+		flowRecord.Data = extendedURL
+	case FORMAT_EXT_MPLS:
+		extendedMPLS := ExtendedMPLS{}
+		ipVersion, ip, err := DecodeIP(payload)
+		if err != nil {
+			return flowRecord, err
+		}
+		extendedMPLS.NextHopIPVersion = ipVersion
+		extendedMPLS.NextHop = ip
+		extendedMPLS.InLabels, err = DecodeLabelStack(payload)
+		if err != nil {
+			return flowRecord, err
+		}
+		extendedMPLS.OutLabels, err = DecodeLabelStack(payload)
+		if err != nil {
+			return flowRecord, err
+		}
+		flowRecord.Data = extendedMPLS
+	case FORMAT_EXT_NAT:
+		extendedNAT := ExtendedNAT{}
+		ipVersion, ip, err := DecodeIP(payload)
+		if err != nil {
+			return flowRecord, err
+		}
+		extendedNAT.SrcIPVersion = ipVersion
+		extendedNAT.SrcIP = ip
+		ipVersion, ip, err = DecodeIP(payload)
+		if err != nil {
+			return flowRecord, err
+		}
+		extendedNAT.DstIPVersion = ipVersion
+		extendedNAT.DstIP = ip
+		flowRecord.Data = extendedNAT
+	case FORMAT_EXT_NAT_PORT:
+		extendedNATPort := ExtendedNATPort{}
+		err := utils.BinaryDecoder(payload, &extendedNATPort)
+		if err != nil {
+			return flowRecord, err
+		}
+		flowRecord.Data = extendedNATPort
+	case FORMAT_EXT_MPLS_TUNNEL:
+		extendedMPLSTunnel := ExtendedMPLSTunnel{}
+		name, err := DecodeOpaque(payload, 0)
+		if err != nil {
+			return flowRecord, err
+		}
+		extendedMPLSTunnel.Name = string(name)
+		err = utils.BinaryDecoder(payload, &(extendedMPLSTunnel.TunnelId), &(extendedMPLSTunnel.TunnelCos))
+		if err != nil {
+			return flowRecord, err
+		}
+		flowRecord.Data = extendedMPLSTunnel
+	case FORMAT_EXT_MPLS_VC:
+		extendedMPLSVC := ExtendedMPLSVC{}
+		name, err := DecodeOpaque(payload, 0)
+		if err != nil {
+			return flowRecord, err
+		}
+		extendedMPLSVC.InstanceName = string(name)
+		err = utils.BinaryDecoder(payload, &(extendedMPLSVC.VCId), &(extendedMPLSVC.VCLabelCos))
+		if err != nil {
+			return flowRecord, err
+		}
+		flowRecord.Data = extendedMPLSVC
+	case FORMAT_EXT_MPLS_FTN:
+		extendedMPLSFTN := ExtendedMPLSFTN{}
+		descr, err := DecodeOpaque(payload, 0)
+		if err != nil {
+			return flowRecord, err
+		}
+		extendedMPLSFTN.Description = string(descr)
+		err = utils.BinaryDecoder(payload, &(extendedMPLSFTN.Mask))
+		if err != nil {
+			return flowRecord, err
+		}
+		flowRecord.Data = extendedMPLSFTN
+	case FORMAT_EXT_80211_RX:
+		extended80211Rx := Extended80211Rx{}
+		ssid, err := DecodeOpaque(payload, 32)
+		if err != nil {
+			return flowRecord, err
+		}
+		extended80211Rx.SSID = string(ssid)
+		extended80211Rx.BSSID, err = decodeMac(payload)
+		if err != nil {
+			return flowRecord, err
+		}
+		err = utils.BinaryDecoder(payload, &(extended80211Rx.Version), &(extended80211Rx.Channel), &(extended80211Rx.Speed),
+			&(extended80211Rx.RSNI), &(extended80211Rx.RCPI), &(extended80211Rx.PacketDuration))
+		if err != nil {
+			return flowRecord, err
+		}
+		flowRecord.Data = extended80211Rx
+	case FORMAT_EXT_80211_TX:
+		extended80211Tx := Extended80211Tx{}
+		ssid, err := DecodeOpaque(payload, 32)
+		if err != nil {
+			return flowRecord, err
+		}
+		extended80211Tx.SSID = string(ssid)
+		extended80211Tx.BSSID, err = decodeMac(payload)
+		if err != nil {
+			return flowRecord, err
+		}
+		err = utils.BinaryDecoder(payload, &(extended80211Tx.Version), &(extended80211Tx.Transmissions), &(extended80211Tx.PacketDuration),
+			&(extended80211Tx.RetransDuration), &(extended80211Tx.Channel), &(extended80211Tx.Speed), &(extended80211Tx.Power))
+		if err != nil {
+			return flowRecord, err
+		}
+		flowRecord.Data = extended80211Tx
+	case FORMAT_EXT_IPV4_TUNNEL_EGRESS:
+		sampledIPv4, err := DecodeSampledIPv4(payload)
+		if err != nil {
+			return flowRecord, err
+		}
+		flowRecord.Data = ExtendedIPv4TunnelEgress{
+			Header: sampledIPv4,
+		}
+	case FORMAT_EXT_IPV4_TUNNEL_INGRESS:
+		sampledIPv4, err := DecodeSampledIPv4(payload)
+		if err != nil {
+			return flowRecord, err
+		}
+		flowRecord.Data = ExtendedIPv4TunnelIngress{
+			Header: sampledIPv4,
+		}
+	case FORMAT_EXT_VNI_EGRESS:
+		extendedVNI := ExtendedVNIEgress{}
+		err := utils.BinaryDecoder(payload, &(extendedVNI.VNI))
+		if err != nil {
+			return flowRecord, err
+		}
+		flowRecord.Data = extendedVNI
+	case FORMAT_EXT_VNI_INGRESS:
+		extendedVNI := ExtendedVNIIngress{}
+		err := utils.BinaryDecoder(payload, &(extendedVNI.VNI))
+		if err != nil {
+			return flowRecord, err
+		}
+		flowRecord.Data = extendedVNI
+	case FORMAT_EXT_EGRESS_QUEUE:
+		extendedEgressQueue := ExtendedEgressQueue{}
+		err := utils.BinaryDecoder(payload, &(extendedEgressQueue.Queue))
+		if err != nil {
+			return flowRecord, err
+		}
+		flowRecord.Data = extendedEgressQueue
+	case FORMAT_EXT_LINUX_DROP_REASON:
+		reason, err := DecodeOpaque(payload, 0)
+		if err != nil {
+			return flowRecord, err
+		}
+		flowRecord.Data = ExtendedLinuxDropReason{
+			Reason: string(reason),
+		}
 	default:
 		//return flowRecord, errors.New(fmt.Sprintf("Unknown data format %v.", (*header).DataFormat))
 		flowRecord.Data = &FlowRecordRaw{
@@ -274,7 +536,7 @@ func DecodeSample(header *SampleHeader, payload *bytes.Buffer) (interface{}, err
 
 		(*header).SourceIdType = sourceId >> 24
 		(*header).SourceIdValue = sourceId & 0x00ffffff
-	} else if format == FORMAT_IPV4 || format == FORMAT_IPV6 {
+	} else if format == FORMAT_IPV4 || format == FORMAT_IPV6 || format == FORMAT_DISCARDED_PKT {
 		err = utils.BinaryDecoder(payload, &((*header).SourceIdType), &((*header).SourceIdValue))
 		if err != nil {
 			return sample, err
@@ -287,6 +549,7 @@ func DecodeSample(header *SampleHeader, payload *bytes.Buffer) (interface{}, err
 	var flowSample FlowSample
 	var counterSample CounterSample
 	var expandedFlowSample ExpandedFlowSample
+	var dropSample DropSample
 	if format == FORMAT_RAW_PKT {
 		flowSample = FlowSample{
 			Header: *header,
@@ -297,6 +560,9 @@ func DecodeSample(header *SampleHeader, payload *bytes.Buffer) (interface{}, err
 			return sample, err
 		}
 		recordsCount = flowSample.FlowRecordsCount
+		if int(recordsCount) > payload.Len()/8 {
+			return sample, NewErrorDecodingSFlow(fmt.Sprintf("Invalid records count: %v.", recordsCount))
+		}
 		flowSample.Records = make([]FlowRecord, recordsCount)
 		sample = flowSample
 	} else if format == FORMAT_ETH || format == FORMAT_IPV6 {
@@ -304,6 +570,9 @@ func DecodeSample(header *SampleHeader, payload *bytes.Buffer) (interface{}, err
 		if err != nil {
 			return sample, err
 		}
+		if int(recordsCount) > payload.Len()/8 {
+			return sample, NewErrorDecodingSFlow(fmt.Sprintf("Invalid records count: %v.", recordsCount))
+		}
 		counterSample = CounterSample{
 			Header:              *header,
 			CounterRecordsCount: recordsCount,
@@ -321,8 +590,26 @@ func DecodeSample(header *SampleHeader, payload *bytes.Buffer) (interface{}, err
 			return sample, err
 		}
 		recordsCount = expandedFlowSample.FlowRecordsCount
+		if int(recordsCount) > payload.Len()/8 {
+			return sample, NewErrorDecodingSFlow(fmt.Sprintf("Invalid records count: %v.", recordsCount))
+		}
 		expandedFlowSample.Records = make([]FlowRecord, recordsCount)
 		sample = expandedFlowSample
+	} else if format == FORMAT_DISCARDED_PKT {
+		dropSample = DropSample{
+			Header: *header,
+		}
+		err = utils.BinaryDecoder(payload, &(dropSample.Drops), &(dropSample.Input), &(dropSample.Output),
+			&(dropSample.Reason), &(dropSample.FlowRecordsCount))
+		if err != nil {
+			return sample, err
+		}
+		recordsCount = dropSample.FlowRecordsCount
+		if int(recordsCount) > payload.Len()/8 {
+			return sample, NewErrorDecodingSFlow(fmt.Sprintf("Invalid records count: %v.", recordsCount))
+		}
+		dropSample.Records = make([]FlowRecord, recordsCount)
+		sample = dropSample
 	}
 	for i := 0; i < int(recordsCount) && payload.Len() >= 8; i++ {
 		recordHeader := RecordHeader{}
@@ -334,7 +621,7 @@ func DecodeSample(header *SampleHeader, payload *bytes.Buffer) (interface{}, err
 			break
 		}
 		recordReader := bytes.NewBuffer(payload.Next(int(recordHeader.Length)))
-		if format == FORMAT_RAW_PKT || format == FORMAT_IPV4 {
+		if format == FORMAT_RAW_PKT || format == FORMAT_IPV4 || format == FORMAT_DISCARDED_PKT {
 			record, err := DecodeFlowRecord(&recordHeader, recordReader)
 			if err != nil {
 				continue
@@ -343,6 +630,8 @@ func DecodeSample(header *SampleHeader, payload *bytes.Buffer) (interface{}, err
 				flowSample.Records[i] = record
 			} else if format == FORMAT_IPV4 {
 				expandedFlowSample.Records[i] = record
+			} else if format == FORMAT_DISCARDED_PKT {
+				dropSample.Records[i] = record
 			}
 		} else if format == FORMAT_ETH || format == FORMAT_IPV6 {
 			record, err := DecodeCounterRecord(&recordHeader, recordReader)
@@ -390,6 +679,9 @@ func DecodeMessage(payload *bytes.Buffer) (interface{}, error) {
 		if err != nil {
 			return packetV5, err
 		}
+		if int(packetV5.SamplesCount) > payload.Len()/8 {
+			return packetV5, NewErrorDecodingSFlow(fmt.Sprintf("Invalid samples count: %v.", packetV5.SamplesCount))
+		}
 		packetV5.Samples = make([]interface{}, int(packetV5.SamplesCount))
 		for i := 0; i < int(packetV5.SamplesCount) && payload.Len() >= 8; i++ {
 			header := SampleHeader{}
@@ -132,3 +132,113 @@ func getExpandedSFlowDecode() []byte {
 		0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00,
 	}
 }
+
+func TestDecodeExtendedFlowRecords(t *testing.T) {
+	// extended user: charset, "alice" (padded), charset, "bob" (padded)
+	data := []byte{
+		0x00, 0x00, 0x00, 0x6a, 0x00, 0x00, 0x00, 0x05, 0x61, 0x6c, 0x69, 0x63, 0x65, 0x00, 0x00, 0x00,
+		0x00, 0x00, 0x00, 0x6a, 0x00, 0x00, 0x00, 0x03, 0x62, 0x6f, 0x62, 0x00,
+	}
+	record, err := DecodeFlowRecord(&RecordHeader{DataFormat: FORMAT_EXT_USER, Length: uint32(len(data))}, bytes.NewBuffer(data))
+	assert.Nil(t, err)
+	assert.Equal(t, ExtendedUser{SrcCharset: 106, SrcUser: "alice", DstCharset: 106, DstUser: "bob"}, record.Data)
+
+	// extended MPLS: next-hop 10.0.0.1, in stack [16000], out stack [16001, 3]
+	data = []byte{
+		0x00, 0x00, 0x00, 0x01, 0x0a, 0x00, 0x00, 0x01,
+		0x00, 0x00, 0x00, 0x01, 0x03, 0xe8, 0x01, 0x40,
+		0x00, 0x00, 0x00, 0x02, 0x03, 0xe8, 0x10, 0x40, 0x00, 0x00, 0x31, 0x40,
+	}
+	record, err = DecodeFlowRecord(&RecordHeader{DataFormat: FORMAT_EXT_MPLS, Length: uint32(len(data))}, bytes.NewBuffer(data))
+	assert.Nil(t, err)
+	assert.Equal(t, ExtendedMPLS{
+		NextHopIPVersion: 1,
+		NextHop:          []byte{10, 0, 0, 1},
+		InLabels:         []uint32{0x03e80140},
+		OutLabels:        []uint32{0x03e81040, 0x3140},
+	}, record.Data)
+
+	// extended NAT: 192.0.2.1 -> 198.51.100.1
+	data = []byte{
+		0x00, 0x00, 0x00, 0x01, 0xc0, 0x00, 0x02, 0x01,
+		0x00, 0x00, 0x00, 0x01, 0xc6, 0x33, 0x64, 0x01,
+	}
+	record, err = DecodeFlowRecord(&RecordHeader{DataFormat: FORMAT_EXT_NAT, Length: uint32(len(data))}, bytes.NewBuffer(data))
+	assert.Nil(t, err)
+	assert.Equal(t, ExtendedNAT{SrcIPVersion: 1, SrcIP: []byte{192, 0, 2, 1}, DstIPVersion: 1, DstIP: []byte{198, 51, 100, 1}}, record.Data)
+
+	// extended 802.11 rx: "wifi", bssid, version, channel 6, speed, rsni, rcpi, duration
+	data = []byte{
+		0x00, 0x00, 0x00, 0x04, 0x77, 0x69, 0x66, 0x69,
+		0x01, 0x02, 0x03, 0x04, 0x05, 0x06, 0x00, 0x00,
+		0x00, 0x00, 0x00, 0x04, 0x00, 0x00, 0x00, 0x06,
+		0x00, 0x00, 0x00, 0x00, 0x05, 0xf5, 0xe1, 0x00,
+		0x00, 0x00, 0x00, 0x01, 0x00, 0x00, 0x00, 0x02, 0x00, 0x00, 0x00, 0x03,
+	}
+	record, err = DecodeFlowRecord(&RecordHeader{DataFormat: FORMAT_EXT_80211_RX, Length: uint32(len(data))}, bytes.NewBuffer(data))
+	assert.Nil(t, err)
+	assert.Equal(t, Extended80211Rx{
+		SSID:           "wifi",
+		BSSID:          []byte{1, 2, 3, 4, 5, 6},
+		Version:        4,
+		Channel:        6,
+		Speed:          100000000,
+		RSNI:           1,
+		RCPI:           2,
+		PacketDuration: 3,
+	}, record.Data)
+
+	data = []byte{0x00, 0x00, 0x00, 0x2a}
+	record, err = DecodeFlowRecord(&RecordHeader{DataFormat: FORMAT_EXT_VNI_INGRESS, Length: uint32(len(data))}, bytes.NewBuffer(data))
+	assert.Nil(t, err)
+	assert.Equal(t, ExtendedVNIIngress{VNI: 42}, record.Data)
+
+	// truncated URL
+	data = []byte{0x00, 0x00, 0x00, 0x01, 0x00, 0x00, 0x00, 0x20, 0x68, 0x74}
+	_, err = DecodeFlowRecord(&RecordHeader{DataFormat: FORMAT_EXT_URL, Length: uint32(len(data))}, bytes.NewBuffer(data))
+	assert.NotNil(t, err)
+}
+
+func TestDecodeDropSample(t *testing.T) {
+	data := []byte{
+		0x00, 0x00, 0x00, 0x01, // sequence number
+		0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x03, // source id
+		0x00, 0x00, 0x00, 0x00, // drops
+		0x00, 0x00, 0x00, 0x03, // input
+		0x3f, 0xff, 0xff, 0xff, // output
+		0x00, 0x00, 0x01, 0x02, // reason: acl
+		0x00, 0x00, 0x00, 0x01, // records count
+		0x00, 0x00, 0x04, 0x12, 0x00, 0x00, 0x00, 0x0c, // linux drop reason record
+		0x00, 0x00, 0x00, 0x08, 0x4e, 0x4f, 0x5f, 0x53, 0x4f, 0x43, 0x4b, 0x45,
+	}
+	header := SampleHeader{Format: FORMAT_DISCARDED_PKT, Length: uint32(len(data))}
+	sample, err := DecodeSample(&header, bytes.NewBuffer(data))
+	assert.Nil(t, err)
+	dropSample, ok := sample.(DropSample)
+	if assert.True(t, ok) {
+		assert.Equal(t, uint32(1), dropSample.Header.SampleSequenceNumber)
+		assert.Equal(t, uint32(3), dropSample.Header.SourceIdValue)
+		assert.Equal(t, uint32(3), dropSample.Input)
+		assert.Equal(t, uint32(258), dropSample.Reason)
+		assert.Equal(t, "acl", DropReasonToString(dropSample.Reason))
+		if assert.Len(t, dropSample.Records, 1) {
+			assert.Equal(t, ExtendedLinuxDropReason{Reason: "NO_SOCKE"}, dropSample.Records[0].Data)
+		}
+	}
+}
+
+func FuzzDecodeMessage(f *testing.F) {
+	f.Add(getExpandedSFlowDecode())
+	// flow sample with a raw packet header record
+	f.Add([]byte{
+		0x00, 0x00, 0x00, 0x05, 0x00, 0x00, 0x00, 0x01, 0xac, 0x10, 0x00, 0x11, 0x00, 0x00, 0x00, 0x01,
+		0x00, 0x00, 0x01, 0xaa, 0x67, 0xee, 0xaa, 0x01, 0x00, 0x00, 0x00, 0x01, 0x00, 0x00, 0x00, 0x01,
+		0x00, 0x00, 0x00, 0x3c, 0x00, 0x00, 0x00, 0x06, 0x00, 0x00, 0x04, 0x13, 0x00, 0x00, 0x08, 0x00,
+		0x00, 0x00, 0x30, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x04, 0xaa, 0x00, 0x00, 0x04, 0x13,
+		0x00, 0x00, 0x00, 0x01, 0x00, 0x00, 0x00, 0x01, 0x00, 0x00, 0x00, 0x14, 0x00, 0x00, 0x00, 0x01,
+		0x00, 0x00, 0x00, 0x52, 0x00, 0x00, 0x00, 0x04, 0x00, 0x00, 0x00, 0x4e,
+	})
+	f.Fuzz(func(t *testing.T, data []byte) {
+		_, _ = DecodeMessage(bytes.NewBuffer(data))
+	})
+}
@@ -0,0 +1,82 @@
+package avro
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/netsampler/goflow2/format"
+	"github.com/netsampler/goflow2/format/common"
+	flowmessage "github.com/netsampler/goflow2/pb"
+)
+
+type AvroDriver struct {
+	flowCodec    *common.AvroCodec
+	optionsCodec *common.AvroCodec
+
+	// prepended to the messages when using a schema registry
+	flowHeader    []byte
+	optionsHeader []byte
+}
+
+func (d *AvroDriver) Prepare() error {
+	common.HashFlag()
+	common.SelectorFlag()
+	common.RegistryFlag()
+	return nil
+}
+
+func (d *AvroDriver) Init(ctx context.Context) error {
+	err := common.ManualHashInit()
+	if err != nil {
+		return err
+	}
+	if err = common.ManualSelectorInit(); err != nil {
+		return err
+	}
+	// the selector only applies to the flows
+	if d.flowCodec, err = common.NewAvroCodecSelector(&flowmessage.FlowMessage{}); err != nil {
+		return err
+	}
+	if d.optionsCodec, err = common.NewAvroCodec(&flowmessage.OptionsMessage{}); err != nil {
+		return err
+	}
+
+	registry := common.ManualRegistryInit()
+	if registry == nil {
+		return nil
+	}
+	subject, subjectOptions := common.RegistrySubjects()
+	id, err := registry.Register(ctx, subject, common.SCHEMA_TYPE_AVRO, d.flowCodec.Schema())
+	if err != nil {
+		return err
+	}
+	d.flowHeader = common.RegistryHeader(id)
+	if id, err = registry.Register(ctx, subjectOptions, common.SCHEMA_TYPE_AVRO, d.optionsCodec.Schema()); err != nil {
+		return err
+	}
+	d.optionsHeader = common.RegistryHeader(id)
+	return nil
+}
+
+func (d *AvroDriver) Format(data interface{}) ([]byte, []byte, error) {
+	var codec *common.AvroCodec
+	var header []byte
+	switch data.(type) {
+	case *flowmessage.FlowMessage:
+		codec, header = d.flowCodec, d.flowHeader
+	case *flowmessage.OptionsMessage:
+		codec, header = d.optionsCodec, d.optionsHeader
+	default:
+		return nil, nil, fmt.Errorf("message is not a flow")
+	}
+	key := common.HashProtoLocal(data)
+
+	b := make([]byte, len(header), len(header)+256)
+	copy(b, header)
+	return []byte(key), codec.Append(b, data), nil
+}
+
+func init() {
+	d := &AvroDriver{}
+	format.RegisterFormatDriver("avro", d)
+}
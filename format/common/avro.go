@@ -0,0 +1,249 @@
+package common
+
+import (
+	"encoding/binary"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"sort"
+	"strings"
+
+	"google.golang.org/protobuf/reflect/protoreflect"
+)
+
+type avroField struct {
+	Name    string      `json:"name"`
+	Type    interface{} `json:"type"`
+	Default interface{} `json:"default"`
+}
+
+type avroRecord struct {
+	Type      string      `json:"type"`
+	Name      string      `json:"name"`
+	Namespace string      `json:"namespace"`
+	Fields    []avroField `json:"fields"`
+}
+
+type avroEnum struct {
+	Type    string   `json:"type"`
+	Name    string   `json:"name"`
+	Symbols []string `json:"symbols"`
+}
+
+type avroArray struct {
+	Type  string `json:"type"`
+	Items string `json:"items"`
+}
+
+type avroMap struct {
+	Type   string `json:"type"`
+	Values string `json:"values"`
+}
+
+// Avro schema and binary encoding of a protobuf message, derived from its fields:
+// integers are longs (uint64 above 2^63 are negative), enums are Avro enums,
+// repeated fields are arrays and maps are maps of strings.
+type AvroCodec struct {
+	t       *messageType
+	fields  []int // indexes of the encoded fields
+	schema  string
+	symbols []map[string]int // index of the symbols of the enums, by field
+}
+
+// Returns the protobuf name of a field
+func protoName(field messageField) string {
+	for _, option := range strings.Split(field.tag.Get("protobuf"), ",") {
+		if strings.HasPrefix(option, "name=") {
+			return strings.TrimPrefix(option, "name=")
+		}
+	}
+	return field.name
+}
+
+// Returns the codec of all the fields of a message
+func NewAvroCodec(msg interface{}) (*AvroCodec, error) {
+	t := generatedMessageType(msg)
+	if t == nil {
+		return nil, errors.New(fmt.Sprintf("Avro: message %T is not supported", msg))
+	}
+	fields := make([]int, len(t.fields))
+	for i := range t.fields {
+		fields[i] = i
+	}
+	return newAvroCodec(msg, t, fields)
+}
+
+// Returns the codec of the fields of a message selected with -format.selector,
+// in the order of the selector. Call ManualSelectorInit before.
+func NewAvroCodecSelector(msg interface{}) (*AvroCodec, error) {
+	t := generatedMessageType(msg)
+	if t == nil {
+		return nil, errors.New(fmt.Sprintf("Avro: message %T is not supported", msg))
+	}
+	var fields []int
+	selected := make(map[int]bool)
+	for _, step := range t.formatPlan(selector, selectorTag) {
+		if selected[step.index] {
+			continue
+		}
+		selected[step.index] = true
+		fields = append(fields, step.index)
+	}
+	if len(fields) == 0 {
+		return nil, errors.New("Avro: no field selected")
+	}
+	return newAvroCodec(msg, t, fields)
+}
+
+func newAvroCodec(msg interface{}, t *messageType, fields []int) (*AvroCodec, error) {
+	pmsg, ok := msg.(protoreflect.ProtoMessage)
+	if !ok {
+		return nil, errors.New(fmt.Sprintf("Avro: message %T is not supported", msg))
+	}
+	desc := pmsg.ProtoReflect().Descriptor()
+
+	c := &AvroCodec{
+		t:       t,
+		fields:  fields,
+		symbols: make([]map[string]int, len(t.fields)),
+	}
+	record := avroRecord{
+		Type:      "record",
+		Name:      string(desc.Name()),
+		Namespace: string(desc.ParentFile().Package()),
+		Fields:    make([]avroField, len(fields)),
+	}
+	for j, i := range fields {
+		field := t.fields[i]
+		name := protoName(field)
+		recordField := avroField{
+			Name: name,
+		}
+		switch field.kind {
+		case kindEnum:
+			fd := desc.Fields().ByName(protoreflect.Name(name))
+			if fd == nil || fd.Enum() == nil {
+				return nil, errors.New(fmt.Sprintf("Avro: enum of field %s not found", name))
+			}
+			values := fd.Enum().Values()
+			enum := avroEnum{
+				Type: "enum",
+				Name: string(fd.Enum().Name()),
+			}
+			c.symbols[i] = make(map[string]int, values.Len())
+			for k := 0; k < values.Len(); k++ {
+				symbol := string(values.Get(k).Name())
+				enum.Symbols = append(enum.Symbols, symbol)
+				c.symbols[i][symbol] = k
+			}
+			recordField.Type = enum
+			recordField.Default = enum.Symbols[0]
+		case kindUint:
+			recordField.Type = "long"
+			recordField.Default = 0
+		case kindBool:
+			recordField.Type = "boolean"
+			recordField.Default = false
+		case kindString:
+			recordField.Type = "string"
+			recordField.Default = ""
+		case kindBytes:
+			recordField.Type = "bytes"
+			recordField.Default = ""
+		case kindUint32List:
+			recordField.Type = avroArray{Type: "array", Items: "long"}
+			recordField.Default = []interface{}{}
+		case kindBytesList:
+			recordField.Type = avroArray{Type: "array", Items: "bytes"}
+			recordField.Default = []interface{}{}
+		case kindStringMap:
+			recordField.Type = avroMap{Type: "map", Values: "string"}
+			recordField.Default = map[string]interface{}{}
+		}
+		record.Fields[j] = recordField
+	}
+
+	schema, err := json.Marshal(record)
+	if err != nil {
+		return nil, err
+	}
+	c.schema = string(schema)
+	return c, nil
+}
+
+func (c *AvroCodec) Schema() string {
+	return c.schema
+}
+
+func appendAvroLong(b []byte, v int64) []byte {
+	var buf [binary.MaxVarintLen64]byte
+	return append(b, buf[:binary.PutVarint(buf[:], v)]...) // zigzag
+}
+
+func appendAvroBytes(b []byte, v []byte) []byte {
+	b = appendAvroLong(b, int64(len(v)))
+	return append(b, v...)
+}
+
+func appendAvroString(b []byte, v string) []byte {
+	b = appendAvroLong(b, int64(len(v)))
+	return append(b, v...)
+}
+
+// Appends the Avro binary encoding of a message, which must be of the type of the codec
+func (c *AvroCodec) Append(b []byte, msg interface{}) []byte {
+	var v fieldValue
+	for _, i := range c.fields {
+		field := c.t.fields[i]
+		v = fieldValue{}
+		c.t.value(msg, i, &v)
+		switch field.kind {
+		case kindEnum:
+			// values unknown to the schema are encoded as the default symbol
+			b = appendAvroLong(b, int64(c.symbols[i][v.str]))
+		case kindUint:
+			b = appendAvroLong(b, int64(v.num))
+		case kindBool:
+			if v.flag {
+				b = append(b, 1)
+			} else {
+				b = append(b, 0)
+			}
+		case kindString:
+			b = appendAvroString(b, v.str)
+		case kindBytes:
+			b = appendAvroBytes(b, v.bytes)
+		case kindUint32List:
+			if len(v.nums) > 0 {
+				b = appendAvroLong(b, int64(len(v.nums)))
+				for _, n := range v.nums {
+					b = appendAvroLong(b, int64(n))
+				}
+			}
+			b = append(b, 0)
+		case kindBytesList:
+			if len(v.list) > 0 {
+				b = appendAvroLong(b, int64(len(v.list)))
+				for _, item := range v.list {
+					b = appendAvroBytes(b, item)
+				}
+			}
+			b = append(b, 0)
+		case kindStringMap:
+			if len(v.dict) > 0 {
+				keys := make([]string, 0, len(v.dict))
+				for k := range v.dict {
+					keys = append(keys, k)
+				}
+				sort.Strings(keys)
+				b = appendAvroLong(b, int64(len(keys)))
+				for _, k := range keys {
+					b = appendAvroString(b, k)
+					b = appendAvroString(b, v.dict[k])
+				}
+			}
+			b = append(b, 0)
+		}
+	}
+	return b
+}
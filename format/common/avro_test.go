@@ -0,0 +1,126 @@
+package common
+
+import (
+	"testing"
+
+	"github.com/linkedin/goavro/v2"
+	flowmessage "github.com/netsampler/goflow2/pb"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestAvroCodec(t *testing.T) {
+	c, err := NewAvroCodec(&flowmessage.FlowMessage{})
+	require.NoError(t, err)
+	codec, err := goavro.NewCodec(c.Schema())
+	require.NoError(t, err)
+
+	flowMessage := &flowmessage.FlowMessage{}
+	populate(flowMessage)
+	flowMessage.Type = flowmessage.FlowMessage_IPFIX
+	flowMessage.TunnelType = flowmessage.FlowMessage_TunnelType(1000) // unknown to the schema
+	flowMessage.Bytes = 1 << 63
+
+	data := c.Append([]byte{0xff}, flowMessage)
+	assert.Equal(t, byte(0xff), data[0])
+	native, remaining, err := codec.NativeFromBinary(data[1:])
+	require.NoError(t, err)
+	assert.Empty(t, remaining)
+	record := native.(map[string]interface{})
+
+	assert.Equal(t, "IPFIX", record["type"])
+	assert.Equal(t, "TUNNEL_NONE", record["tunnel_type"])
+	assert.Equal(t, int64(-1<<63), record["bytes"])
+	assert.Equal(t, int64(flowMessage.InIf), record["in_if"])
+	assert.Equal(t, flowMessage.SrcAddr, record["src_addr"])
+	assert.Equal(t, true, record["has_mpls"])
+	assert.Equal(t, flowMessage.MplsTunnelName, record["mpls_tunnel_name"])
+	assert.Equal(t, []interface{}{int64(65000), int64(0), int64(flowMessage.AsPath[2])}, record["as_path"])
+	assert.Equal(t, []interface{}{flowMessage.Srv6Segments[0], flowMessage.Srv6Segments[1]}, record["srv6_segments"])
+	assert.Equal(t, map[string]interface{}{"a": "va\"lue", "b": "2", "c": ""}, record["all_fields"])
+	assert.Len(t, record, len(flowMessageType.fields))
+
+	// defaults
+	data = c.Append(nil, &flowmessage.FlowMessage{})
+	native, _, err = codec.NativeFromBinary(data)
+	require.NoError(t, err)
+	record = native.(map[string]interface{})
+	assert.Equal(t, "FLOWUNKNOWN", record["type"])
+	assert.Equal(t, []interface{}{}, record["as_path"])
+	assert.Equal(t, map[string]interface{}{}, record["all_fields"])
+}
+
+func TestAvroCodecOptions(t *testing.T) {
+	c, err := NewAvroCodec(&flowmessage.OptionsMessage{})
+	require.NoError(t, err)
+	codec, err := goavro.NewCodec(c.Schema())
+	require.NoError(t, err)
+
+	optionsMessage := &flowmessage.OptionsMessage{
+		Type:       flowmessage.FlowMessage_NETFLOW_V9,
+		TemplateId: 256,
+		Scopes:     map[string]string{"1": "10"},
+	}
+	native, _, err := codec.NativeFromBinary(c.Append(nil, optionsMessage))
+	require.NoError(t, err)
+	record := native.(map[string]interface{})
+	assert.Equal(t, "NETFLOW_V9", record["type"])
+	assert.Equal(t, int64(256), record["template_id"])
+	assert.Equal(t, map[string]interface{}{"1": "10"}, record["scopes"])
+
+	_, err = NewAvroCodec(&struct{}{})
+	assert.Error(t, err)
+}
+
+func TestAvroCodecSelector(t *testing.T) {
+	defer func(s []string, tag string) {
+		selector, selectorTag = s, tag
+	}(selector, selectorTag)
+
+	flowMessage := &flowmessage.FlowMessage{
+		Type:           flowmessage.FlowMessage_SFLOW_5,
+		SamplerAddress: []byte{192, 0, 2, 1},
+		Bytes:          1500,
+	}
+
+	selector, selectorTag = []string{"SamplerAddress", "Type", "Unknown", "SamplerAddress"}, ""
+	c, err := NewAvroCodecSelector(&flowmessage.FlowMessage{})
+	require.NoError(t, err)
+	assert.Equal(t, `{"type":"record","name":"FlowMessage","namespace":"flowpb","fields":[`+
+		`{"name":"sampler_address","type":"bytes","default":""},`+
+		`{"name":"type","type":{"type":"enum","name":"FlowType","symbols":["FLOWUNKNOWN","SFLOW_5","NETFLOW_V5","NETFLOW_V9","IPFIX"]},"default":"FLOWUNKNOWN"}]}`,
+		c.Schema())
+	codec, err := goavro.NewCodec(c.Schema())
+	require.NoError(t, err)
+	native, remaining, err := codec.NativeFromBinary(c.Append(nil, flowMessage))
+	require.NoError(t, err)
+	assert.Empty(t, remaining)
+	assert.Equal(t, map[string]interface{}{
+		"sampler_address": []byte{192, 0, 2, 1},
+		"type":            "SFLOW_5",
+	}, native)
+
+	selector, selectorTag = nil, ""
+	c, err = NewAvroCodecSelector(&flowmessage.FlowMessage{})
+	require.NoError(t, err)
+	all, err := NewAvroCodec(&flowmessage.FlowMessage{})
+	require.NoError(t, err)
+	assert.Equal(t, all.Schema(), c.Schema())
+
+	selector, selectorTag = []string{"Unknown"}, ""
+	_, err = NewAvroCodecSelector(&flowmessage.FlowMessage{})
+	assert.Error(t, err)
+	_, err = NewAvroCodecSelector(&struct{}{})
+	assert.Error(t, err)
+}
+
+func BenchmarkAvroCodec(b *testing.B) {
+	c, err := NewAvroCodec(&flowmessage.FlowMessage{})
+	require.NoError(b, err)
+	msg := benchmarkFlowMessage()
+	b.ReportAllocs()
+	buf := make([]byte, 0, 1024)
+	for i := 0; i < b.N; i++ {
+		buf = c.Append(buf[:0], msg)
+	}
+}
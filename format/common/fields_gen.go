@@ -0,0 +1,491 @@
+// Code generated by gen_fields.go; DO NOT EDIT.
+
+package common
+
+import flowmessage "github.com/netsampler/goflow2/pb"
+
+var flowMessageType = newMessageType(
+	[]messageField{
+		{name: "Type", tag: "protobuf:\"varint,1,opt,name=type,proto3,enum=flowpb.FlowMessage_FlowType\" json:\"type,omitempty\"", kind: kindEnum},
+		{name: "TimeReceived", tag: "protobuf:\"varint,2,opt,name=time_received,json=timeReceived,proto3\" json:\"time_received,omitempty\"", kind: kindUint},
+		{name: "TimeReceivedNs", tag: "protobuf:\"varint,158,opt,name=time_received_ns,json=timeReceivedNs,proto3\" json:\"time_received_ns,omitempty\"", kind: kindUint},
+		{name: "SequenceNum", tag: "protobuf:\"varint,4,opt,name=sequence_num,json=sequenceNum,proto3\" json:\"sequence_num,omitempty\"", kind: kindUint},
+		{name: "SamplingRate", tag: "protobuf:\"varint,3,opt,name=sampling_rate,json=samplingRate,proto3\" json:\"sampling_rate,omitempty\"", kind: kindUint},
+		{name: "FlowDirection", tag: "protobuf:\"varint,42,opt,name=flow_direction,json=flowDirection,proto3\" json:\"flow_direction,omitempty\"", kind: kindUint},
+		{name: "SamplerAddress", tag: "protobuf:\"bytes,11,opt,name=sampler_address,json=samplerAddress,proto3\" json:\"sampler_address,omitempty\"", kind: kindBytes},
+		{name: "TimeFlowStart", tag: "protobuf:\"varint,38,opt,name=time_flow_start,json=timeFlowStart,proto3\" json:\"time_flow_start,omitempty\"", kind: kindUint},
+		{name: "TimeFlowEnd", tag: "protobuf:\"varint,5,opt,name=time_flow_end,json=timeFlowEnd,proto3\" json:\"time_flow_end,omitempty\"", kind: kindUint},
+		{name: "TimeFlowStartMs", tag: "protobuf:\"varint,63,opt,name=time_flow_start_ms,json=timeFlowStartMs,proto3\" json:\"time_flow_start_ms,omitempty\"", kind: kindUint},
+		{name: "TimeFlowEndMs", tag: "protobuf:\"varint,64,opt,name=time_flow_end_ms,json=timeFlowEndMs,proto3\" json:\"time_flow_end_ms,omitempty\"", kind: kindUint},
+		{name: "Bytes", tag: "protobuf:\"varint,9,opt,name=bytes,proto3\" json:\"bytes,omitempty\"", kind: kindUint},
+		{name: "Packets", tag: "protobuf:\"varint,10,opt,name=packets,proto3\" json:\"packets,omitempty\"", kind: kindUint},
+		{name: "SrcAddr", tag: "protobuf:\"bytes,6,opt,name=src_addr,json=srcAddr,proto3\" json:\"src_addr,omitempty\"", kind: kindBytes},
+		{name: "DstAddr", tag: "protobuf:\"bytes,7,opt,name=dst_addr,json=dstAddr,proto3\" json:\"dst_addr,omitempty\"", kind: kindBytes},
+		{name: "Etype", tag: "protobuf:\"varint,30,opt,name=etype,proto3\" json:\"etype,omitempty\"", kind: kindUint},
+		{name: "Proto", tag: "protobuf:\"varint,20,opt,name=proto,proto3\" json:\"proto,omitempty\"", kind: kindUint},
+		{name: "SrcPort", tag: "protobuf:\"varint,21,opt,name=src_port,json=srcPort,proto3\" json:\"src_port,omitempty\"", kind: kindUint},
+		{name: "DstPort", tag: "protobuf:\"varint,22,opt,name=dst_port,json=dstPort,proto3\" json:\"dst_port,omitempty\"", kind: kindUint},
+		{name: "InIf", tag: "protobuf:\"varint,18,opt,name=in_if,json=inIf,proto3\" json:\"in_if,omitempty\"", kind: kindUint},
+		{name: "OutIf", tag: "protobuf:\"varint,19,opt,name=out_if,json=outIf,proto3\" json:\"out_if,omitempty\"", kind: kindUint},
+		{name: "SrcMac", tag: "protobuf:\"varint,27,opt,name=src_mac,json=srcMac,proto3\" json:\"src_mac,omitempty\"", kind: kindUint},
+		{name: "DstMac", tag: "protobuf:\"varint,28,opt,name=dst_mac,json=dstMac,proto3\" json:\"dst_mac,omitempty\"", kind: kindUint},
+		{name: "SrcVlan", tag: "protobuf:\"varint,33,opt,name=src_vlan,json=srcVlan,proto3\" json:\"src_vlan,omitempty\"", kind: kindUint},
+		{name: "DstVlan", tag: "protobuf:\"varint,34,opt,name=dst_vlan,json=dstVlan,proto3\" json:\"dst_vlan,omitempty\"", kind: kindUint},
+		{name: "VlanId", tag: "protobuf:\"varint,29,opt,name=vlan_id,json=vlanId,proto3\" json:\"vlan_id,omitempty\"", kind: kindUint},
+		{name: "IngressVrfId", tag: "protobuf:\"varint,39,opt,name=ingress_vrf_id,json=ingressVrfId,proto3\" json:\"ingress_vrf_id,omitempty\"", kind: kindUint},
+		{name: "EgressVrfId", tag: "protobuf:\"varint,40,opt,name=egress_vrf_id,json=egressVrfId,proto3\" json:\"egress_vrf_id,omitempty\"", kind: kindUint},
+		{name: "IpTos", tag: "protobuf:\"varint,23,opt,name=ip_tos,json=ipTos,proto3\" json:\"ip_tos,omitempty\"", kind: kindUint},
+		{name: "ForwardingStatus", tag: "protobuf:\"varint,24,opt,name=forwarding_status,json=forwardingStatus,proto3\" json:\"forwarding_status,omitempty\"", kind: kindUint},
+		{name: "IpTtl", tag: "protobuf:\"varint,25,opt,name=ip_ttl,json=ipTtl,proto3\" json:\"ip_ttl,omitempty\"", kind: kindUint},
+		{name: "TcpFlags", tag: "protobuf:\"varint,26,opt,name=tcp_flags,json=tcpFlags,proto3\" json:\"tcp_flags,omitempty\"", kind: kindUint},
+		{name: "IcmpType", tag: "protobuf:\"varint,31,opt,name=icmp_type,json=icmpType,proto3\" json:\"icmp_type,omitempty\"", kind: kindUint},
+		{name: "IcmpCode", tag: "protobuf:\"varint,32,opt,name=icmp_code,json=icmpCode,proto3\" json:\"icmp_code,omitempty\"", kind: kindUint},
+		{name: "Ipv6FlowLabel", tag: "protobuf:\"varint,37,opt,name=ipv6_flow_label,json=ipv6FlowLabel,proto3\" json:\"ipv6_flow_label,omitempty\"", kind: kindUint},
+		{name: "FragmentId", tag: "protobuf:\"varint,35,opt,name=fragment_id,json=fragmentId,proto3\" json:\"fragment_id,omitempty\"", kind: kindUint},
+		{name: "FragmentOffset", tag: "protobuf:\"varint,36,opt,name=fragment_offset,json=fragmentOffset,proto3\" json:\"fragment_offset,omitempty\"", kind: kindUint},
+		{name: "BiFlowDirection", tag: "protobuf:\"varint,41,opt,name=bi_flow_direction,json=biFlowDirection,proto3\" json:\"bi_flow_direction,omitempty\"", kind: kindUint},
+		{name: "SrcAs", tag: "protobuf:\"varint,14,opt,name=src_as,json=srcAs,proto3\" json:\"src_as,omitempty\"", kind: kindUint},
+		{name: "DstAs", tag: "protobuf:\"varint,15,opt,name=dst_as,json=dstAs,proto3\" json:\"dst_as,omitempty\"", kind: kindUint},
+		{name: "NextHop", tag: "protobuf:\"bytes,12,opt,name=next_hop,json=nextHop,proto3\" json:\"next_hop,omitempty\"", kind: kindBytes},
+		{name: "NextHopAs", tag: "protobuf:\"varint,13,opt,name=next_hop_as,json=nextHopAs,proto3\" json:\"next_hop_as,omitempty\"", kind: kindUint},
+		{name: "SrcNet", tag: "protobuf:\"varint,16,opt,name=src_net,json=srcNet,proto3\" json:\"src_net,omitempty\"", kind: kindUint},
+		{name: "DstNet", tag: "protobuf:\"varint,17,opt,name=dst_net,json=dstNet,proto3\" json:\"dst_net,omitempty\"", kind: kindUint},
+		{name: "BgpNextHop", tag: "protobuf:\"bytes,100,opt,name=bgp_next_hop,json=bgpNextHop,proto3\" json:\"bgp_next_hop,omitempty\"", kind: kindBytes},
+		{name: "BgpCommunities", tag: "protobuf:\"varint,101,rep,packed,name=bgp_communities,json=bgpCommunities,proto3\" json:\"bgp_communities,omitempty\"", kind: kindUint32List},
+		{name: "AsPath", tag: "protobuf:\"varint,102,rep,packed,name=as_path,json=asPath,proto3\" json:\"as_path,omitempty\"", kind: kindUint32List},
+		{name: "HasMpls", tag: "protobuf:\"varint,53,opt,name=has_mpls,json=hasMpls,proto3\" json:\"has_mpls,omitempty\"", kind: kindBool},
+		{name: "MplsCount", tag: "protobuf:\"varint,54,opt,name=mpls_count,json=mplsCount,proto3\" json:\"mpls_count,omitempty\"", kind: kindUint},
+		{name: "Mpls_1Ttl", tag: "protobuf:\"varint,55,opt,name=mpls_1_ttl,json=mpls1Ttl,proto3\" json:\"mpls_1_ttl,omitempty\"", kind: kindUint},
+		{name: "Mpls_1Label", tag: "protobuf:\"varint,56,opt,name=mpls_1_label,json=mpls1Label,proto3\" json:\"mpls_1_label,omitempty\"", kind: kindUint},
+		{name: "Mpls_2Ttl", tag: "protobuf:\"varint,57,opt,name=mpls_2_ttl,json=mpls2Ttl,proto3\" json:\"mpls_2_ttl,omitempty\"", kind: kindUint},
+		{name: "Mpls_2Label", tag: "protobuf:\"varint,58,opt,name=mpls_2_label,json=mpls2Label,proto3\" json:\"mpls_2_label,omitempty\"", kind: kindUint},
+		{name: "Mpls_3Ttl", tag: "protobuf:\"varint,59,opt,name=mpls_3_ttl,json=mpls3Ttl,proto3\" json:\"mpls_3_ttl,omitempty\"", kind: kindUint},
+		{name: "Mpls_3Label", tag: "protobuf:\"varint,60,opt,name=mpls_3_label,json=mpls3Label,proto3\" json:\"mpls_3_label,omitempty\"", kind: kindUint},
+		{name: "MplsLastTtl", tag: "protobuf:\"varint,61,opt,name=mpls_last_ttl,json=mplsLastTtl,proto3\" json:\"mpls_last_ttl,omitempty\"", kind: kindUint},
+		{name: "MplsLastLabel", tag: "protobuf:\"varint,62,opt,name=mpls_last_label,json=mplsLastLabel,proto3\" json:\"mpls_last_label,omitempty\"", kind: kindUint},
+		{name: "MplsLabelIp", tag: "protobuf:\"bytes,65,opt,name=mpls_label_ip,json=mplsLabelIp,proto3\" json:\"mpls_label_ip,omitempty\"", kind: kindBytes},
+		{name: "ObservationDomainId", tag: "protobuf:\"varint,70,opt,name=observation_domain_id,json=observationDomainId,proto3\" json:\"observation_domain_id,omitempty\"", kind: kindUint},
+		{name: "ObservationPointId", tag: "protobuf:\"varint,71,opt,name=observation_point_id,json=observationPointId,proto3\" json:\"observation_point_id,omitempty\"", kind: kindUint},
+		{name: "AllFields", tag: "protobuf:\"bytes,110,rep,name=all_fields,json=allFields,proto3\" json:\"all_fields,omitempty\" protobuf_key:\"bytes,1,opt,name=key,proto3\" protobuf_val:\"bytes,2,opt,name=value,proto3\"", kind: kindStringMap},
+		{name: "PostNatSrcAddr", tag: "protobuf:\"bytes,111,opt,name=post_nat_src_addr,json=postNatSrcAddr,proto3\" json:\"post_nat_src_addr,omitempty\"", kind: kindBytes},
+		{name: "PostNatDstAddr", tag: "protobuf:\"bytes,112,opt,name=post_nat_dst_addr,json=postNatDstAddr,proto3\" json:\"post_nat_dst_addr,omitempty\"", kind: kindBytes},
+		{name: "PostNatSrcPort", tag: "protobuf:\"varint,113,opt,name=post_nat_src_port,json=postNatSrcPort,proto3\" json:\"post_nat_src_port,omitempty\"", kind: kindUint},
+		{name: "PostNatDstPort", tag: "protobuf:\"varint,114,opt,name=post_nat_dst_port,json=postNatDstPort,proto3\" json:\"post_nat_dst_port,omitempty\"", kind: kindUint},
+		{name: "MplsLabelsIn", tag: "protobuf:\"varint,115,rep,packed,name=mpls_labels_in,json=mplsLabelsIn,proto3\" json:\"mpls_labels_in,omitempty\"", kind: kindUint32List},
+		{name: "MplsLabelsOut", tag: "protobuf:\"varint,116,rep,packed,name=mpls_labels_out,json=mplsLabelsOut,proto3\" json:\"mpls_labels_out,omitempty\"", kind: kindUint32List},
+		{name: "MplsTunnelName", tag: "protobuf:\"bytes,117,opt,name=mpls_tunnel_name,json=mplsTunnelName,proto3\" json:\"mpls_tunnel_name,omitempty\"", kind: kindString},
+		{name: "MplsTunnelId", tag: "protobuf:\"varint,118,opt,name=mpls_tunnel_id,json=mplsTunnelId,proto3\" json:\"mpls_tunnel_id,omitempty\"", kind: kindUint},
+		{name: "MplsVcName", tag: "protobuf:\"bytes,119,opt,name=mpls_vc_name,json=mplsVcName,proto3\" json:\"mpls_vc_name,omitempty\"", kind: kindString},
+		{name: "MplsVcId", tag: "protobuf:\"varint,120,opt,name=mpls_vc_id,json=mplsVcId,proto3\" json:\"mpls_vc_id,omitempty\"", kind: kindUint},
+		{name: "MplsFtnDescr", tag: "protobuf:\"bytes,121,opt,name=mpls_ftn_descr,json=mplsFtnDescr,proto3\" json:\"mpls_ftn_descr,omitempty\"", kind: kindString},
+		{name: "SrcUser", tag: "protobuf:\"bytes,122,opt,name=src_user,json=srcUser,proto3\" json:\"src_user,omitempty\"", kind: kindString},
+		{name: "DstUser", tag: "protobuf:\"bytes,123,opt,name=dst_user,json=dstUser,proto3\" json:\"dst_user,omitempty\"", kind: kindString},
+		{name: "HttpUrl", tag: "protobuf:\"bytes,124,opt,name=http_url,json=httpUrl,proto3\" json:\"http_url,omitempty\"", kind: kindString},
+		{name: "HttpHost", tag: "protobuf:\"bytes,125,opt,name=http_host,json=httpHost,proto3\" json:\"http_host,omitempty\"", kind: kindString},
+		{name: "VniIngress", tag: "protobuf:\"varint,126,opt,name=vni_ingress,json=vniIngress,proto3\" json:\"vni_ingress,omitempty\"", kind: kindUint},
+		{name: "VniEgress", tag: "protobuf:\"varint,127,opt,name=vni_egress,json=vniEgress,proto3\" json:\"vni_egress,omitempty\"", kind: kindUint},
+		{name: "WlanSsid", tag: "protobuf:\"bytes,128,opt,name=wlan_ssid,json=wlanSsid,proto3\" json:\"wlan_ssid,omitempty\"", kind: kindString},
+		{name: "WlanBssid", tag: "protobuf:\"varint,129,opt,name=wlan_bssid,json=wlanBssid,proto3\" json:\"wlan_bssid,omitempty\"", kind: kindUint},
+		{name: "WlanChannel", tag: "protobuf:\"varint,130,opt,name=wlan_channel,json=wlanChannel,proto3\" json:\"wlan_channel,omitempty\"", kind: kindUint},
+		{name: "Dropped", tag: "protobuf:\"varint,131,opt,name=dropped,proto3\" json:\"dropped,omitempty\"", kind: kindBool},
+		{name: "DropReason", tag: "protobuf:\"varint,132,opt,name=drop_reason,json=dropReason,proto3\" json:\"drop_reason,omitempty\"", kind: kindUint},
+		{name: "DropReasonName", tag: "protobuf:\"bytes,133,opt,name=drop_reason_name,json=dropReasonName,proto3\" json:\"drop_reason_name,omitempty\"", kind: kindString},
+		{name: "DropEgressQueue", tag: "protobuf:\"varint,134,opt,name=drop_egress_queue,json=dropEgressQueue,proto3\" json:\"drop_egress_queue,omitempty\"", kind: kindUint},
+		{name: "TunnelType", tag: "protobuf:\"varint,135,opt,name=tunnel_type,json=tunnelType,proto3,enum=flowpb.FlowMessage_TunnelType\" json:\"tunnel_type,omitempty\"", kind: kindEnum},
+		{name: "TunnelSrcAddr", tag: "protobuf:\"bytes,136,opt,name=tunnel_src_addr,json=tunnelSrcAddr,proto3\" json:\"tunnel_src_addr,omitempty\"", kind: kindBytes},
+		{name: "TunnelDstAddr", tag: "protobuf:\"bytes,137,opt,name=tunnel_dst_addr,json=tunnelDstAddr,proto3\" json:\"tunnel_dst_addr,omitempty\"", kind: kindBytes},
+		{name: "TunnelProto", tag: "protobuf:\"varint,138,opt,name=tunnel_proto,json=tunnelProto,proto3\" json:\"tunnel_proto,omitempty\"", kind: kindUint},
+		{name: "TunnelSrcPort", tag: "protobuf:\"varint,139,opt,name=tunnel_src_port,json=tunnelSrcPort,proto3\" json:\"tunnel_src_port,omitempty\"", kind: kindUint},
+		{name: "TunnelDstPort", tag: "protobuf:\"varint,140,opt,name=tunnel_dst_port,json=tunnelDstPort,proto3\" json:\"tunnel_dst_port,omitempty\"", kind: kindUint},
+		{name: "TunnelVni", tag: "protobuf:\"varint,141,opt,name=tunnel_vni,json=tunnelVni,proto3\" json:\"tunnel_vni,omitempty\"", kind: kindUint},
+		{name: "TunnelGreKey", tag: "protobuf:\"varint,142,opt,name=tunnel_gre_key,json=tunnelGreKey,proto3\" json:\"tunnel_gre_key,omitempty\"", kind: kindUint},
+		{name: "TunnelDepth", tag: "protobuf:\"varint,143,opt,name=tunnel_depth,json=tunnelDepth,proto3\" json:\"tunnel_depth,omitempty\"", kind: kindUint},
+		{name: "OuterVlanId", tag: "protobuf:\"varint,144,opt,name=outer_vlan_id,json=outerVlanId,proto3\" json:\"outer_vlan_id,omitempty\"", kind: kindUint},
+		{name: "OuterVlanPcp", tag: "protobuf:\"varint,145,opt,name=outer_vlan_pcp,json=outerVlanPcp,proto3\" json:\"outer_vlan_pcp,omitempty\"", kind: kindUint},
+		{name: "InnerVlanId", tag: "protobuf:\"varint,146,opt,name=inner_vlan_id,json=innerVlanId,proto3\" json:\"inner_vlan_id,omitempty\"", kind: kindUint},
+		{name: "InnerVlanPcp", tag: "protobuf:\"varint,147,opt,name=inner_vlan_pcp,json=innerVlanPcp,proto3\" json:\"inner_vlan_pcp,omitempty\"", kind: kindUint},
+		{name: "Srv6Segments", tag: "protobuf:\"bytes,148,rep,name=srv6_segments,json=srv6Segments,proto3\" json:\"srv6_segments,omitempty\"", kind: kindBytesList},
+		{name: "Srv6SegmentsLeft", tag: "protobuf:\"varint,149,opt,name=srv6_segments_left,json=srv6SegmentsLeft,proto3\" json:\"srv6_segments_left,omitempty\"", kind: kindUint},
+		{name: "DnsQname", tag: "protobuf:\"bytes,150,opt,name=dns_qname,json=dnsQname,proto3\" json:\"dns_qname,omitempty\"", kind: kindString},
+		{name: "TlsSni", tag: "protobuf:\"bytes,151,opt,name=tls_sni,json=tlsSni,proto3\" json:\"tls_sni,omitempty\"", kind: kindString},
+		{name: "HttpMethod", tag: "protobuf:\"bytes,152,opt,name=http_method,json=httpMethod,proto3\" json:\"http_method,omitempty\"", kind: kindString},
+		{name: "QuicVersion", tag: "protobuf:\"varint,153,opt,name=quic_version,json=quicVersion,proto3\" json:\"quic_version,omitempty\"", kind: kindUint},
+		{name: "RawHeader", tag: "protobuf:\"bytes,154,opt,name=raw_header,json=rawHeader,proto3\" json:\"raw_header,omitempty\"", kind: kindBytes},
+		{name: "RawHeaderFrameLength", tag: "protobuf:\"varint,155,opt,name=raw_header_frame_length,json=rawHeaderFrameLength,proto3\" json:\"raw_header_frame_length,omitempty\"", kind: kindUint},
+		{name: "RawHeaderStripped", tag: "protobuf:\"varint,156,opt,name=raw_header_stripped,json=rawHeaderStripped,proto3\" json:\"raw_header_stripped,omitempty\"", kind: kindUint},
+		{name: "RawHeaderProtocol", tag: "protobuf:\"varint,157,opt,name=raw_header_protocol,json=rawHeaderProtocol,proto3\" json:\"raw_header_protocol,omitempty\"", kind: kindUint},
+		{name: "ReverseBytes", tag: "protobuf:\"varint,159,opt,name=reverse_bytes,json=reverseBytes,proto3\" json:\"reverse_bytes,omitempty\"", kind: kindUint},
+		{name: "ReversePackets", tag: "protobuf:\"varint,160,opt,name=reverse_packets,json=reversePackets,proto3\" json:\"reverse_packets,omitempty\"", kind: kindUint},
+		{name: "ReverseTcpFlags", tag: "protobuf:\"varint,161,opt,name=reverse_tcp_flags,json=reverseTcpFlags,proto3\" json:\"reverse_tcp_flags,omitempty\"", kind: kindUint},
+		{name: "ReverseIpTos", tag: "protobuf:\"varint,179,opt,name=reverse_ip_tos,json=reverseIpTos,proto3\" json:\"reverse_ip_tos,omitempty\"", kind: kindUint},
+		{name: "ReverseIpTtl", tag: "protobuf:\"varint,180,opt,name=reverse_ip_ttl,json=reverseIpTtl,proto3\" json:\"reverse_ip_ttl,omitempty\"", kind: kindUint},
+		{name: "ReverseTimeFlowStartMs", tag: "protobuf:\"varint,181,opt,name=reverse_time_flow_start_ms,json=reverseTimeFlowStartMs,proto3\" json:\"reverse_time_flow_start_ms,omitempty\"", kind: kindUint},
+		{name: "ReverseTimeFlowEndMs", tag: "protobuf:\"varint,182,opt,name=reverse_time_flow_end_ms,json=reverseTimeFlowEndMs,proto3\" json:\"reverse_time_flow_end_ms,omitempty\"", kind: kindUint},
+		{name: "NatEvent", tag: "protobuf:\"varint,162,opt,name=nat_event,json=natEvent,proto3\" json:\"nat_event,omitempty\"", kind: kindUint},
+		{name: "NatPoolId", tag: "protobuf:\"varint,163,opt,name=nat_pool_id,json=natPoolId,proto3\" json:\"nat_pool_id,omitempty\"", kind: kindUint},
+		{name: "NatPoolName", tag: "protobuf:\"bytes,164,opt,name=nat_pool_name,json=natPoolName,proto3\" json:\"nat_pool_name,omitempty\"", kind: kindString},
+		{name: "FirewallEvent", tag: "protobuf:\"varint,165,opt,name=firewall_event,json=firewallEvent,proto3\" json:\"firewall_event,omitempty\"", kind: kindUint},
+		{name: "FirewallExtEvent", tag: "protobuf:\"varint,166,opt,name=firewall_ext_event,json=firewallExtEvent,proto3\" json:\"firewall_ext_event,omitempty\"", kind: kindUint},
+		{name: "ConnId", tag: "protobuf:\"varint,167,opt,name=conn_id,json=connId,proto3\" json:\"conn_id,omitempty\"", kind: kindUint},
+		{name: "IngressAclId", tag: "protobuf:\"varint,168,opt,name=ingress_acl_id,json=ingressAclId,proto3\" json:\"ingress_acl_id,omitempty\"", kind: kindUint},
+		{name: "IngressAceId", tag: "protobuf:\"varint,169,opt,name=ingress_ace_id,json=ingressAceId,proto3\" json:\"ingress_ace_id,omitempty\"", kind: kindUint},
+		{name: "EgressAclId", tag: "protobuf:\"varint,170,opt,name=egress_acl_id,json=egressAclId,proto3\" json:\"egress_acl_id,omitempty\"", kind: kindUint},
+		{name: "EgressAceId", tag: "protobuf:\"varint,171,opt,name=egress_ace_id,json=egressAceId,proto3\" json:\"egress_ace_id,omitempty\"", kind: kindUint},
+		{name: "UserName", tag: "protobuf:\"bytes,172,opt,name=user_name,json=userName,proto3\" json:\"user_name,omitempty\"", kind: kindString},
+		{name: "InIfName", tag: "protobuf:\"bytes,173,opt,name=in_if_name,json=inIfName,proto3\" json:\"in_if_name,omitempty\"", kind: kindString},
+		{name: "OutIfName", tag: "protobuf:\"bytes,174,opt,name=out_if_name,json=outIfName,proto3\" json:\"out_if_name,omitempty\"", kind: kindString},
+		{name: "ApplicationId", tag: "protobuf:\"bytes,175,opt,name=application_id,json=applicationId,proto3\" json:\"application_id,omitempty\"", kind: kindBytes},
+		{name: "ApplicationName", tag: "protobuf:\"bytes,176,opt,name=application_name,json=applicationName,proto3\" json:\"application_name,omitempty\"", kind: kindString},
+		{name: "IngressVrfName", tag: "protobuf:\"bytes,177,opt,name=ingress_vrf_name,json=ingressVrfName,proto3\" json:\"ingress_vrf_name,omitempty\"", kind: kindString},
+		{name: "EgressVrfName", tag: "protobuf:\"bytes,178,opt,name=egress_vrf_name,json=egressVrfName,proto3\" json:\"egress_vrf_name,omitempty\"", kind: kindString},
+		{name: "CustomInteger_1", tag: "protobuf:\"varint,1001,opt,name=custom_integer_1,json=customInteger1,proto3\" json:\"custom_integer_1,omitempty\"", kind: kindUint},
+		{name: "CustomInteger_2", tag: "protobuf:\"varint,1002,opt,name=custom_integer_2,json=customInteger2,proto3\" json:\"custom_integer_2,omitempty\"", kind: kindUint},
+		{name: "CustomInteger_3", tag: "protobuf:\"varint,1003,opt,name=custom_integer_3,json=customInteger3,proto3\" json:\"custom_integer_3,omitempty\"", kind: kindUint},
+		{name: "CustomInteger_4", tag: "protobuf:\"varint,1004,opt,name=custom_integer_4,json=customInteger4,proto3\" json:\"custom_integer_4,omitempty\"", kind: kindUint},
+		{name: "CustomInteger_5", tag: "protobuf:\"varint,1005,opt,name=custom_integer_5,json=customInteger5,proto3\" json:\"custom_integer_5,omitempty\"", kind: kindUint},
+		{name: "CustomBytes_1", tag: "protobuf:\"bytes,1011,opt,name=custom_bytes_1,json=customBytes1,proto3\" json:\"custom_bytes_1,omitempty\"", kind: kindBytes},
+		{name: "CustomBytes_2", tag: "protobuf:\"bytes,1012,opt,name=custom_bytes_2,json=customBytes2,proto3\" json:\"custom_bytes_2,omitempty\"", kind: kindBytes},
+		{name: "CustomBytes_3", tag: "protobuf:\"bytes,1013,opt,name=custom_bytes_3,json=customBytes3,proto3\" json:\"custom_bytes_3,omitempty\"", kind: kindBytes},
+		{name: "CustomBytes_4", tag: "protobuf:\"bytes,1014,opt,name=custom_bytes_4,json=customBytes4,proto3\" json:\"custom_bytes_4,omitempty\"", kind: kindBytes},
+		{name: "CustomBytes_5", tag: "protobuf:\"bytes,1015,opt,name=custom_bytes_5,json=customBytes5,proto3\" json:\"custom_bytes_5,omitempty\"", kind: kindBytes},
+		{name: "CustomList_1", tag: "protobuf:\"varint,1021,rep,packed,name=custom_list_1,json=customList1,proto3\" json:\"custom_list_1,omitempty\"", kind: kindUint32List},
+	},
+	func(msg interface{}, index int, v *fieldValue) {
+		m := msg.(*flowmessage.FlowMessage)
+		switch index {
+		case 0:
+			v.str = m.Type.String()
+		case 1:
+			v.num = uint64(m.TimeReceived)
+		case 2:
+			v.num = uint64(m.TimeReceivedNs)
+		case 3:
+			v.num = uint64(m.SequenceNum)
+		case 4:
+			v.num = uint64(m.SamplingRate)
+		case 5:
+			v.num = uint64(m.FlowDirection)
+		case 6:
+			v.bytes = m.SamplerAddress
+		case 7:
+			v.num = uint64(m.TimeFlowStart)
+		case 8:
+			v.num = uint64(m.TimeFlowEnd)
+		case 9:
+			v.num = uint64(m.TimeFlowStartMs)
+		case 10:
+			v.num = uint64(m.TimeFlowEndMs)
+		case 11:
+			v.num = uint64(m.Bytes)
+		case 12:
+			v.num = uint64(m.Packets)
+		case 13:
+			v.bytes = m.SrcAddr
+		case 14:
+			v.bytes = m.DstAddr
+		case 15:
+			v.num = uint64(m.Etype)
+		case 16:
+			v.num = uint64(m.Proto)
+		case 17:
+			v.num = uint64(m.SrcPort)
+		case 18:
+			v.num = uint64(m.DstPort)
+		case 19:
+			v.num = uint64(m.InIf)
+		case 20:
+			v.num = uint64(m.OutIf)
+		case 21:
+			v.num = uint64(m.SrcMac)
+		case 22:
+			v.num = uint64(m.DstMac)
+		case 23:
+			v.num = uint64(m.SrcVlan)
+		case 24:
+			v.num = uint64(m.DstVlan)
+		case 25:
+			v.num = uint64(m.VlanId)
+		case 26:
+			v.num = uint64(m.IngressVrfId)
+		case 27:
+			v.num = uint64(m.EgressVrfId)
+		case 28:
+			v.num = uint64(m.IpTos)
+		case 29:
+			v.num = uint64(m.ForwardingStatus)
+		case 30:
+			v.num = uint64(m.IpTtl)
+		case 31:
+			v.num = uint64(m.TcpFlags)
+		case 32:
+			v.num = uint64(m.IcmpType)
+		case 33:
+			v.num = uint64(m.IcmpCode)
+		case 34:
+			v.num = uint64(m.Ipv6FlowLabel)
+		case 35:
+			v.num = uint64(m.FragmentId)
+		case 36:
+			v.num = uint64(m.FragmentOffset)
+		case 37:
+			v.num = uint64(m.BiFlowDirection)
+		case 38:
+			v.num = uint64(m.SrcAs)
+		case 39:
+			v.num = uint64(m.DstAs)
+		case 40:
+			v.bytes = m.NextHop
+		case 41:
+			v.num = uint64(m.NextHopAs)
+		case 42:
+			v.num = uint64(m.SrcNet)
+		case 43:
+			v.num = uint64(m.DstNet)
+		case 44:
+			v.bytes = m.BgpNextHop
+		case 45:
+			v.nums = m.BgpCommunities
+		case 46:
+			v.nums = m.AsPath
+		case 47:
+			v.flag = m.HasMpls
+		case 48:
+			v.num = uint64(m.MplsCount)
+		case 49:
+			v.num = uint64(m.Mpls_1Ttl)
+		case 50:
+			v.num = uint64(m.Mpls_1Label)
+		case 51:
+			v.num = uint64(m.Mpls_2Ttl)
+		case 52:
+			v.num = uint64(m.Mpls_2Label)
+		case 53:
+			v.num = uint64(m.Mpls_3Ttl)
+		case 54:
+			v.num = uint64(m.Mpls_3Label)
+		case 55:
+			v.num = uint64(m.MplsLastTtl)
+		case 56:
+			v.num = uint64(m.MplsLastLabel)
+		case 57:
+			v.bytes = m.MplsLabelIp
+		case 58:
+			v.num = uint64(m.ObservationDomainId)
+		case 59:
+			v.num = uint64(m.ObservationPointId)
+		case 60:
+			v.dict = m.AllFields
+		case 61:
+			v.bytes = m.PostNatSrcAddr
+		case 62:
+			v.bytes = m.PostNatDstAddr
+		case 63:
+			v.num = uint64(m.PostNatSrcPort)
+		case 64:
+			v.num = uint64(m.PostNatDstPort)
+		case 65:
+			v.nums = m.MplsLabelsIn
+		case 66:
+			v.nums = m.MplsLabelsOut
+		case 67:
+			v.str = m.MplsTunnelName
+		case 68:
+			v.num = uint64(m.MplsTunnelId)
+		case 69:
+			v.str = m.MplsVcName
+		case 70:
+			v.num = uint64(m.MplsVcId)
+		case 71:
+			v.str = m.MplsFtnDescr
+		case 72:
+			v.str = m.SrcUser
+		case 73:
+			v.str = m.DstUser
+		case 74:
+			v.str = m.HttpUrl
+		case 75:
+			v.str = m.HttpHost
+		case 76:
+			v.num = uint64(m.VniIngress)
+		case 77:
+			v.num = uint64(m.VniEgress)
+		case 78:
+			v.str = m.WlanSsid
+		case 79:
+			v.num = uint64(m.WlanBssid)
+		case 80:
+			v.num = uint64(m.WlanChannel)
+		case 81:
+			v.flag = m.Dropped
+		case 82:
+			v.num = uint64(m.DropReason)
+		case 83:
+			v.str = m.DropReasonName
+		case 84:
+			v.num = uint64(m.DropEgressQueue)
+		case 85:
+			v.str = m.TunnelType.String()
+		case 86:
+			v.bytes = m.TunnelSrcAddr
+		case 87:
+			v.bytes = m.TunnelDstAddr
+		case 88:
+			v.num = uint64(m.TunnelProto)
+		case 89:
+			v.num = uint64(m.TunnelSrcPort)
+		case 90:
+			v.num = uint64(m.TunnelDstPort)
+		case 91:
+			v.num = uint64(m.TunnelVni)
+		case 92:
+			v.num = uint64(m.TunnelGreKey)
+		case 93:
+			v.num = uint64(m.TunnelDepth)
+		case 94:
+			v.num = uint64(m.OuterVlanId)
+		case 95:
+			v.num = uint64(m.OuterVlanPcp)
+		case 96:
+			v.num = uint64(m.InnerVlanId)
+		case 97:
+			v.num = uint64(m.InnerVlanPcp)
+		case 98:
+			v.list = m.Srv6Segments
+		case 99:
+			v.num = uint64(m.Srv6SegmentsLeft)
+		case 100:
+			v.str = m.DnsQname
+		case 101:
+			v.str = m.TlsSni
+		case 102:
+			v.str = m.HttpMethod
+		case 103:
+			v.num = uint64(m.QuicVersion)
+		case 104:
+			v.bytes = m.RawHeader
+		case 105:
+			v.num = uint64(m.RawHeaderFrameLength)
+		case 106:
+			v.num = uint64(m.RawHeaderStripped)
+		case 107:
+			v.num = uint64(m.RawHeaderProtocol)
+		case 108:
+			v.num = uint64(m.ReverseBytes)
+		case 109:
+			v.num = uint64(m.ReversePackets)
+		case 110:
+			v.num = uint64(m.ReverseTcpFlags)
+		case 111:
+			v.num = uint64(m.ReverseIpTos)
+		case 112:
+			v.num = uint64(m.ReverseIpTtl)
+		case 113:
+			v.num = uint64(m.ReverseTimeFlowStartMs)
+		case 114:
+			v.num = uint64(m.ReverseTimeFlowEndMs)
+		case 115:
+			v.num = uint64(m.NatEvent)
+		case 116:
+			v.num = uint64(m.NatPoolId)
+		case 117:
+			v.str = m.NatPoolName
+		case 118:
+			v.num = uint64(m.FirewallEvent)
+		case 119:
+			v.num = uint64(m.FirewallExtEvent)
+		case 120:
+			v.num = uint64(m.ConnId)
+		case 121:
+			v.num = uint64(m.IngressAclId)
+		case 122:
+			v.num = uint64(m.IngressAceId)
+		case 123:
+			v.num = uint64(m.EgressAclId)
+		case 124:
+			v.num = uint64(m.EgressAceId)
+		case 125:
+			v.str = m.UserName
+		case 126:
+			v.str = m.InIfName
+		case 127:
+			v.str = m.OutIfName
+		case 128:
+			v.bytes = m.ApplicationId
+		case 129:
+			v.str = m.ApplicationName
+		case 130:
+			v.str = m.IngressVrfName
+		case 131:
+			v.str = m.EgressVrfName
+		case 132:
+			v.num = uint64(m.CustomInteger_1)
+		case 133:
+			v.num = uint64(m.CustomInteger_2)
+		case 134:
+			v.num = uint64(m.CustomInteger_3)
+		case 135:
+			v.num = uint64(m.CustomInteger_4)
+		case 136:
+			v.num = uint64(m.CustomInteger_5)
+		case 137:
+			v.bytes = m.CustomBytes_1
+		case 138:
+			v.bytes = m.CustomBytes_2
+		case 139:
+			v.bytes = m.CustomBytes_3
+		case 140:
+			v.bytes = m.CustomBytes_4
+		case 141:
+			v.bytes = m.CustomBytes_5
+		case 142:
+			v.nums = m.CustomList_1
+		}
+	},
+)
+
+var optionsMessageType = newMessageType(
+	[]messageField{
+		{name: "Type", tag: "protobuf:\"varint,1,opt,name=type,proto3,enum=flowpb.FlowMessage_FlowType\" json:\"type,omitempty\"", kind: kindEnum},
+		{name: "TimeReceived", tag: "protobuf:\"varint,2,opt,name=time_received,json=timeReceived,proto3\" json:\"time_received,omitempty\"", kind: kindUint},
+		{name: "TimeReceivedNs", tag: "protobuf:\"varint,9,opt,name=time_received_ns,json=timeReceivedNs,proto3\" json:\"time_received_ns,omitempty\"", kind: kindUint},
+		{name: "SequenceNum", tag: "protobuf:\"varint,3,opt,name=sequence_num,json=sequenceNum,proto3\" json:\"sequence_num,omitempty\"", kind: kindUint},
+		{name: "SamplerAddress", tag: "protobuf:\"bytes,4,opt,name=sampler_address,json=samplerAddress,proto3\" json:\"sampler_address,omitempty\"", kind: kindBytes},
+		{name: "ObservationDomainId", tag: "protobuf:\"varint,5,opt,name=observation_domain_id,json=observationDomainId,proto3\" json:\"observation_domain_id,omitempty\"", kind: kindUint},
+		{name: "TemplateId", tag: "protobuf:\"varint,6,opt,name=template_id,json=templateId,proto3\" json:\"template_id,omitempty\"", kind: kindUint},
+		{name: "Scopes", tag: "protobuf:\"bytes,7,rep,name=scopes,proto3\" json:\"scopes,omitempty\" protobuf_key:\"bytes,1,opt,name=key,proto3\" protobuf_val:\"bytes,2,opt,name=value,proto3\"", kind: kindStringMap},
+		{name: "Options", tag: "protobuf:\"bytes,8,rep,name=options,proto3\" json:\"options,omitempty\" protobuf_key:\"bytes,1,opt,name=key,proto3\" protobuf_val:\"bytes,2,opt,name=value,proto3\"", kind: kindStringMap},
+	},
+	func(msg interface{}, index int, v *fieldValue) {
+		m := msg.(*flowmessage.OptionsMessage)
+		switch index {
+		case 0:
+			v.str = m.Type.String()
+		case 1:
+			v.num = uint64(m.TimeReceived)
+		case 2:
+			v.num = uint64(m.TimeReceivedNs)
+		case 3:
+			v.num = uint64(m.SequenceNum)
+		case 4:
+			v.bytes = m.SamplerAddress
+		case 5:
+			v.num = uint64(m.ObservationDomainId)
+		case 6:
+			v.num = uint64(m.TemplateId)
+		case 7:
+			v.dict = m.Scopes
+		case 8:
+			v.dict = m.Options
+		}
+	},
+)
+
+func generatedMessageType(msg interface{}) *messageType {
+	switch msg.(type) {
+	case *flowmessage.FlowMessage:
+		return flowMessageType
+	case *flowmessage.OptionsMessage:
+		return optionsMessageType
+	}
+	return nil
+}
@@ -0,0 +1,95 @@
+//go:build ignore
+
+// Generates the field accessors of the protobuf messages (fields_gen.go),
+// used to format and hash the messages without reflection.
+package main
+
+import (
+	"bytes"
+	"fmt"
+	"go/format"
+	"log"
+	"os"
+	"reflect"
+	"strconv"
+
+	flowmessage "github.com/netsampler/goflow2/pb"
+)
+
+type message struct {
+	name  string // variable name
+	value interface{}
+}
+
+var messages = []message{
+	{"flowMessageType", flowmessage.FlowMessage{}},
+	{"optionsMessageType", flowmessage.OptionsMessage{}},
+}
+
+var stringerType = reflect.TypeOf((*fmt.Stringer)(nil)).Elem()
+
+// Returns the kind of the field and the statement setting its value
+func fieldKind(field reflect.StructField) (string, string) {
+	t := field.Type
+	switch {
+	case t.Kind() == reflect.Int32 && t.Implements(stringerType):
+		return "kindEnum", fmt.Sprintf("v.str = m.%s.String()", field.Name)
+	case t.Kind() == reflect.Uint32 || t.Kind() == reflect.Uint64:
+		return "kindUint", fmt.Sprintf("v.num = uint64(m.%s)", field.Name)
+	case t.Kind() == reflect.Bool:
+		return "kindBool", fmt.Sprintf("v.flag = m.%s", field.Name)
+	case t.Kind() == reflect.String:
+		return "kindString", fmt.Sprintf("v.str = m.%s", field.Name)
+	case t == reflect.TypeOf([]byte{}):
+		return "kindBytes", fmt.Sprintf("v.bytes = m.%s", field.Name)
+	case t == reflect.TypeOf([]uint32{}):
+		return "kindUint32List", fmt.Sprintf("v.nums = m.%s", field.Name)
+	case t == reflect.TypeOf([][]byte{}):
+		return "kindBytesList", fmt.Sprintf("v.list = m.%s", field.Name)
+	case t == reflect.TypeOf(map[string]string{}):
+		return "kindStringMap", fmt.Sprintf("v.dict = m.%s", field.Name)
+	}
+	log.Fatalf("field %s: type %v is not supported", field.Name, t)
+	return "", ""
+}
+
+func main() {
+	b := &bytes.Buffer{}
+	fmt.Fprintf(b, "// Code generated by gen_fields.go; DO NOT EDIT.\n\n")
+	fmt.Fprintf(b, "package common\n\n")
+	fmt.Fprintf(b, "import flowmessage \"github.com/netsampler/goflow2/pb\"\n\n")
+
+	for _, msg := range messages {
+		t := reflect.TypeOf(msg.value)
+
+		var fields, cases bytes.Buffer
+		var index int
+		for i := 0; i < t.NumField(); i++ {
+			field := t.Field(i)
+			if !field.IsExported() {
+				continue
+			}
+			kind, statement := fieldKind(field)
+			fmt.Fprintf(&fields, "{name: %q, tag: %s, kind: %s},\n", field.Name, strconv.Quote(string(field.Tag)), kind)
+			fmt.Fprintf(&cases, "case %d:\n%s\n", index, statement)
+			index++
+		}
+
+		fmt.Fprintf(b, "var %s = newMessageType(\n[]messageField{\n%s},\n", msg.name, fields.String())
+		fmt.Fprintf(b, "func(msg interface{}, index int, v *fieldValue) {\nm := msg.(*flowmessage.%s)\nswitch index {\n%s}\n},\n)\n\n", t.Name(), cases.String())
+	}
+
+	fmt.Fprintf(b, "func generatedMessageType(msg interface{}) *messageType {\nswitch msg.(type) {\n")
+	for _, msg := range messages {
+		fmt.Fprintf(b, "case *flowmessage.%s:\nreturn %s\n", reflect.TypeOf(msg.value).Name(), msg.name)
+	}
+	fmt.Fprintf(b, "}\nreturn nil\n}\n")
+
+	src, err := format.Source(b.Bytes())
+	if err != nil {
+		log.Fatal(err)
+	}
+	if err := os.WriteFile("fields_gen.go", src, 0644); err != nil {
+		log.Fatal(err)
+	}
+}
@@ -38,6 +38,13 @@ func HashProtoLocal(msg interface{}) string {
 }
 
 func HashProto(fields []string, msg interface{}) string {
+	if t := generatedMessageType(msg); t != nil {
+		return t.hash(fields, msg)
+	}
+	return hashProtoReflect(fields, msg)
+}
+
+func hashProtoReflect(fields []string, msg interface{}) string {
 	var keyStr string
 
 	if msg != nil {
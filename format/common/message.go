@@ -0,0 +1,454 @@
+package common
+
+import (
+	"encoding/binary"
+	"encoding/hex"
+	"net"
+	"reflect"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"unicode/utf8"
+)
+
+//go:generate go run gen_fields.go
+
+// Kinds of the fields of the generated accessors
+const (
+	kindEnum = iota
+	kindUint
+	kindBool
+	kindString
+	kindBytes
+	kindUint32List
+	kindBytesList
+	kindStringMap
+)
+
+// Value of a field, set by the generated accessor depending on its kind
+type fieldValue struct {
+	num   uint64
+	str   string // also the name of enums
+	flag  bool
+	bytes []byte
+	nums  []uint32
+	list  [][]byte
+	dict  map[string]string
+}
+
+type messageField struct {
+	name string
+	tag  reflect.StructTag
+	kind int
+}
+
+// Fields of a protobuf message with their generated accessor (fields_gen.go),
+// to format and hash the message without reflection
+type messageType struct {
+	fields []messageField
+	index  map[string]int // by field name
+	value  func(msg interface{}, index int, v *fieldValue)
+
+	formatPlans sync.Map // selector and tag to []formatStep
+	hashPlans   sync.Map // hashing fields to []int
+}
+
+func newMessageType(fields []messageField, value func(interface{}, int, *fieldValue)) *messageType {
+	t := &messageType{
+		fields: fields,
+		index:  make(map[string]int, len(fields)),
+		value:  value,
+	}
+	for i, field := range fields {
+		t.index[field.name] = i
+	}
+	return t
+}
+
+const (
+	renderDefault = iota // by kind of the field
+	renderStringFunc
+	renderString
+	renderInteger
+	renderIP
+	renderMac
+	renderBytes
+	renderIPList
+	renderEmpty   // unknown format type
+	renderExtra   // RenderExtras function
+	renderReflect // format type not matching the kind of the field
+)
+
+type formatStep struct {
+	key       string // name in the output
+	fieldName string
+	index     int
+	render    int
+	extra     RenderExtraFunction
+}
+
+// Returns how to render a field, with the same precedence as the reflection
+func renderOf(field messageField) (int, RenderExtraFunction) {
+	if fieldType, ok := TextFields[field.name]; ok {
+		switch fieldType {
+		case FORMAT_TYPE_STRING_FUNC:
+			if field.kind == kindEnum {
+				return renderStringFunc, nil
+			}
+		case FORMAT_TYPE_STRING:
+			if field.kind == kindString {
+				return renderString, nil
+			}
+		case FORMAT_TYPE_INTEGER:
+			if field.kind == kindUint {
+				return renderInteger, nil
+			}
+		case FORMAT_TYPE_IP:
+			if field.kind == kindBytes {
+				return renderIP, nil
+			}
+		case FORMAT_TYPE_MAC:
+			if field.kind == kindUint {
+				return renderMac, nil
+			}
+		case FORMAT_TYPE_BYTES:
+			if field.kind == kindBytes {
+				return renderBytes, nil
+			}
+		case FORMAT_TYPE_IP_LIST:
+			if field.kind == kindBytesList {
+				return renderIPList, nil
+			}
+		default:
+			return renderEmpty, nil
+		}
+		return renderReflect, nil
+	} else if renderer, ok := RenderExtras[field.name]; ok {
+		return renderExtra, renderer
+	}
+	return renderDefault, nil
+}
+
+// Builds the fields to render for a selector, as done by formatMessageReflectCustom.
+// The plans are cached: TextFields and RenderExtras must be set before formatting.
+func (t *messageType) formatPlan(customSelector []string, tag string) []formatStep {
+	planKey := tag + "\x00" + strings.Join(customSelector, ",")
+	if plan, ok := t.formatPlans.Load(planKey); ok {
+		return plan.([]formatStep)
+	}
+
+	reMap := make(map[string]string)
+	if len(customSelector) == 0 || tag != "" {
+		customSelectorTmp := make([]string, len(t.fields))
+		for i, field := range t.fields {
+			fieldName := field.name
+			if tag != "" {
+				fieldName = ExtractTag(tag, field.name, field.tag)
+				reMap[fieldName] = field.name
+			}
+			customSelectorTmp[i] = fieldName
+		}
+		if len(customSelector) == 0 {
+			customSelector = customSelectorTmp
+		}
+	}
+
+	plan := make([]formatStep, 0, len(customSelector))
+	for _, s := range customSelector {
+		fieldName := s
+		if fieldNameMap, ok := reMap[fieldName]; ok {
+			fieldName = fieldNameMap
+		}
+		index, ok := t.index[fieldName]
+		if !ok {
+			continue
+		}
+		render, extra := renderOf(t.fields[index])
+		plan = append(plan, formatStep{
+			key:       s,
+			fieldName: fieldName,
+			index:     index,
+			render:    render,
+			extra:     extra,
+		})
+	}
+	t.formatPlans.Store(planKey, plan)
+	return plan
+}
+
+func appendKey(b []byte, key, quotes, sign string) []byte {
+	b = append(b, quotes...)
+	b = append(b, key...)
+	b = append(b, quotes...)
+	return append(b, sign...)
+}
+
+const hexDigits = "0123456789abcdef"
+
+// Appends a string quoted as a JSON string: unlike strconv.Quote, control characters
+// are escaped as \u00XX and invalid UTF-8 is replaced by U+FFFD instead of \x escapes.
+func appendJSONString(b []byte, s string) []byte {
+	b = append(b, '"')
+	for i := 0; i < len(s); {
+		c := s[i]
+		if c < utf8.RuneSelf {
+			switch {
+			case c == '"' || c == '\\':
+				b = append(b, '\\', c)
+			case c == '\n':
+				b = append(b, '\\', 'n')
+			case c == '\r':
+				b = append(b, '\\', 'r')
+			case c == '\t':
+				b = append(b, '\\', 't')
+			case c < 0x20:
+				b = append(b, '\\', 'u', '0', '0', hexDigits[c>>4], hexDigits[c&0xf])
+			default:
+				b = append(b, c)
+			}
+			i++
+			continue
+		}
+		r, size := utf8.DecodeRuneInString(s[i:])
+		if r == utf8.RuneError && size == 1 {
+			b = append(b, "\\ufffd"...)
+		} else {
+			b = append(b, s[i:i+size]...)
+		}
+		i += size
+	}
+	return append(b, '"')
+}
+
+func appendMac(b []byte, value uint64) []byte {
+	mac := make([]byte, 8)
+	binary.BigEndian.PutUint64(mac, value)
+	return strconv.AppendQuote(b, net.HardwareAddr(mac[2:]).String())
+}
+
+// Appends a value as printed by %v
+func appendBytesValue(b []byte, value []byte) []byte {
+	b = append(b, '[')
+	for i, v := range value {
+		if i > 0 {
+			b = append(b, ' ')
+		}
+		b = strconv.AppendUint(b, uint64(v), 10)
+	}
+	return append(b, ']')
+}
+
+// Appends the value of a field rendered from its kind.
+// Returns false when the field is not rendered (empty maps).
+func appendDefault(b []byte, kind int, v *fieldValue, quotes, sign string) ([]byte, bool) {
+	switch kind {
+	case kindString:
+		b = strconv.AppendQuote(b, v.str)
+	case kindBytes:
+		b = append(b, '[')
+		for i, n := range v.bytes {
+			if i > 0 {
+				b = append(b, ',')
+			}
+			b = strconv.AppendUint(b, uint64(n), 10)
+		}
+		b = append(b, ']')
+	case kindUint32List:
+		b = append(b, '[')
+		for i, n := range v.nums {
+			if i > 0 {
+				b = append(b, ',')
+			}
+			b = strconv.AppendUint(b, uint64(n), 10)
+		}
+		b = append(b, ']')
+	case kindBytesList:
+		b = append(b, '[')
+		for i, item := range v.list {
+			if i > 0 {
+				b = append(b, ',')
+			}
+			b = appendBytesValue(b, item)
+		}
+		b = append(b, ']')
+	case kindStringMap:
+		if len(v.dict) == 0 {
+			return b, false
+		}
+		keys := make([]string, 0, len(v.dict))
+		for k := range v.dict {
+			keys = append(keys, k)
+		}
+		sort.Strings(keys)
+		b = append(b, '{')
+		for i, k := range keys {
+			if i > 0 {
+				b = append(b, ',')
+			}
+			b = appendKey(b, k, quotes, sign)
+			b = appendJSONString(b, v.dict[k])
+		}
+		b = append(b, '}')
+	case kindEnum:
+		b = append(b, v.str...)
+	case kindUint:
+		b = strconv.AppendUint(b, v.num, 10)
+	case kindBool:
+		b = strconv.AppendBool(b, v.flag)
+	}
+	return b, true
+}
+
+// Formats a message without reflection, with the same output as formatMessageReflectCustom
+func (t *messageType) format(msg interface{}, quotes, sep, sign string, null bool) string {
+	plan := t.formatPlan(selector, selectorTag)
+
+	b := make([]byte, 0, 64*len(plan))
+	var v fieldValue
+	var vfm reflect.Value // only for the fields rendered with reflection
+	first := true
+	for _, step := range plan {
+		start := len(b)
+		if !first {
+			b = append(b, sep...)
+		}
+
+		if step.render == renderReflect {
+			if !vfm.IsValid() {
+				vfm = reflect.Indirect(reflect.ValueOf(msg))
+			}
+			str, ok := formatFieldReflect(msg, vfm, step.key, step.fieldName, quotes, sign, null)
+			if !ok {
+				b = b[:start]
+				continue
+			}
+			b = append(b, str...)
+			first = false
+			continue
+		}
+
+		if step.render == renderEmpty {
+			if null {
+				b = appendKey(b, step.key, quotes, sign)
+				b = append(b, "null"...)
+			}
+			first = false
+			continue
+		}
+
+		b = appendKey(b, step.key, quotes, sign)
+		if step.render == renderExtra {
+			b = strconv.AppendQuote(b, step.extra(msg))
+			first = false
+			continue
+		}
+
+		v = fieldValue{}
+		t.value(msg, step.index, &v)
+		switch step.render {
+		case renderStringFunc, renderString:
+			b = strconv.AppendQuote(b, v.str)
+		case renderInteger:
+			b = strconv.AppendUint(b, v.num, 10)
+		case renderIP:
+			b = strconv.AppendQuote(b, RenderIP(v.bytes))
+		case renderMac:
+			b = appendMac(b, v.num)
+		case renderBytes:
+			b = strconv.AppendQuote(b, hex.EncodeToString(v.bytes))
+		case renderIPList:
+			b = append(b, '[')
+			for i, ip := range v.list {
+				if i > 0 {
+					b = append(b, ',')
+				}
+				b = strconv.AppendQuote(b, RenderIP(ip))
+			}
+			b = append(b, ']')
+		default:
+			var ok bool
+			if b, ok = appendDefault(b, t.fields[step.index].kind, &v, quotes, sign); !ok {
+				b = b[:start]
+				continue
+			}
+		}
+		first = false
+	}
+	return string(b)
+}
+
+// Appends a value as printed by %v, for the hash keys
+func appendHashValue(b []byte, kind int, v *fieldValue) []byte {
+	switch kind {
+	case kindEnum, kindString:
+		b = append(b, v.str...)
+	case kindUint:
+		b = strconv.AppendUint(b, v.num, 10)
+	case kindBool:
+		b = strconv.AppendBool(b, v.flag)
+	case kindBytes:
+		b = appendBytesValue(b, v.bytes)
+	case kindUint32List:
+		b = append(b, '[')
+		for i, n := range v.nums {
+			if i > 0 {
+				b = append(b, ' ')
+			}
+			b = strconv.AppendUint(b, uint64(n), 10)
+		}
+		b = append(b, ']')
+	case kindBytesList:
+		b = append(b, '[')
+		for i, item := range v.list {
+			if i > 0 {
+				b = append(b, ' ')
+			}
+			b = appendBytesValue(b, item)
+		}
+		b = append(b, ']')
+	case kindStringMap:
+		keys := make([]string, 0, len(v.dict))
+		for k := range v.dict {
+			keys = append(keys, k)
+		}
+		sort.Strings(keys)
+		b = append(b, "map["...)
+		for i, k := range keys {
+			if i > 0 {
+				b = append(b, ' ')
+			}
+			b = append(b, k...)
+			b = append(b, ':')
+			b = append(b, v.dict[k]...)
+		}
+		b = append(b, ']')
+	}
+	return b
+}
+
+// Builds the hash key of a message without reflection, with the same output as hashProtoReflect
+func (t *messageType) hash(fields []string, msg interface{}) string {
+	planKey := strings.Join(fields, ",")
+	var plan []int
+	if cached, ok := t.hashPlans.Load(planKey); ok {
+		plan = cached.([]int)
+	} else {
+		for _, kf := range fields {
+			if index, ok := t.index[kf]; ok {
+				plan = append(plan, index)
+			}
+		}
+		t.hashPlans.Store(planKey, plan)
+	}
+
+	b := make([]byte, 0, 32*len(plan))
+	var v fieldValue
+	for _, index := range plan {
+		v = fieldValue{}
+		t.value(msg, index, &v)
+		b = appendHashValue(b, t.fields[index].kind, &v)
+		b = append(b, '-')
+	}
+	return string(b)
+}
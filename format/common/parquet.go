@@ -0,0 +1,136 @@
+package common
+
+import (
+	"errors"
+	"fmt"
+	"sort"
+	"strings"
+
+	"google.golang.org/protobuf/reflect/protoreflect"
+)
+
+type parquetColumn struct {
+	name  string
+	index int
+}
+
+// Parquet schema and rows of a protobuf message, restricted to the fields selected
+// with -format.selector (named with -format.tag, otherwise with their protobuf names):
+// integers are unsigned INT(64), enums are strings, repeated fields are LISTs
+// and maps are MAPs of strings.
+type ParquetCodec struct {
+	t       *messageType
+	name    string
+	columns []parquetColumn
+}
+
+// Returns the codec of a message for the configured selector, call ManualSelectorInit before
+func NewParquetCodec(msg interface{}) (*ParquetCodec, error) {
+	t := generatedMessageType(msg)
+	pmsg, ok := msg.(protoreflect.ProtoMessage)
+	if t == nil || !ok {
+		return nil, errors.New(fmt.Sprintf("Parquet: message %T is not supported", msg))
+	}
+	c := &ParquetCodec{
+		t:    t,
+		name: string(pmsg.ProtoReflect().Descriptor().Name()),
+	}
+	names := make(map[string]bool)
+	for _, step := range t.formatPlan(selector, selectorTag) {
+		name := step.key
+		if selectorTag == "" {
+			name = protoName(t.fields[step.index])
+		}
+		if names[name] {
+			continue
+		}
+		names[name] = true
+		c.columns = append(c.columns, parquetColumn{
+			name:  name,
+			index: step.index,
+		})
+	}
+	if len(c.columns) == 0 {
+		return nil, errors.New("Parquet: no field selected")
+	}
+	return c, nil
+}
+
+// Returns the schema definition, as parsed by parquetschema.ParseSchemaDefinition
+func (c *ParquetCodec) Schema() string {
+	var b strings.Builder
+	fmt.Fprintf(&b, "message %s {\n", c.name)
+	for _, column := range c.columns {
+		switch c.t.fields[column.index].kind {
+		case kindEnum, kindString:
+			fmt.Fprintf(&b, "  required binary %s (STRING);\n", column.name)
+		case kindUint:
+			fmt.Fprintf(&b, "  required int64 %s (INT(64, false));\n", column.name)
+		case kindBool:
+			fmt.Fprintf(&b, "  required boolean %s;\n", column.name)
+		case kindBytes:
+			fmt.Fprintf(&b, "  required binary %s;\n", column.name)
+		case kindUint32List:
+			fmt.Fprintf(&b, "  optional group %s (LIST) {\n    repeated group list {\n      required int32 element (INT(32, false));\n    }\n  }\n", column.name)
+		case kindBytesList:
+			fmt.Fprintf(&b, "  optional group %s (LIST) {\n    repeated group list {\n      required binary element;\n    }\n  }\n", column.name)
+		case kindStringMap:
+			fmt.Fprintf(&b, "  optional group %s (MAP) {\n    repeated group key_value {\n      required binary key (STRING);\n      required binary value (STRING);\n    }\n  }\n", column.name)
+		}
+	}
+	b.WriteString("}\n")
+	return b.String()
+}
+
+// Returns the row of a message, which must be of the type of the codec,
+// in the representation of goparquet.FileWriter.AddData. Empty lists and maps are null.
+// The bytes are copied: the writer keeps the rows until the row group is flushed
+// while the fields of the message may point into the received packet.
+func (c *ParquetCodec) Row(msg interface{}) map[string]interface{} {
+	row := make(map[string]interface{}, len(c.columns))
+	var v fieldValue
+	for _, column := range c.columns {
+		v = fieldValue{}
+		c.t.value(msg, column.index, &v)
+		switch c.t.fields[column.index].kind {
+		case kindEnum, kindString:
+			row[column.name] = []byte(v.str)
+		case kindUint:
+			row[column.name] = int64(v.num)
+		case kindBool:
+			row[column.name] = v.flag
+		case kindBytes:
+			row[column.name] = append([]byte{}, v.bytes...)
+		case kindUint32List:
+			if len(v.nums) > 0 {
+				list := make([]map[string]interface{}, len(v.nums))
+				for i, n := range v.nums {
+					list[i] = map[string]interface{}{"element": int32(n)}
+				}
+				row[column.name] = map[string]interface{}{"list": list}
+			}
+		case kindBytesList:
+			if len(v.list) > 0 {
+				list := make([]map[string]interface{}, len(v.list))
+				for i, item := range v.list {
+					list[i] = map[string]interface{}{"element": append([]byte{}, item...)}
+				}
+				row[column.name] = map[string]interface{}{"list": list}
+			}
+		case kindStringMap:
+			if len(v.dict) > 0 {
+				keys := make([]string, 0, len(v.dict))
+				for k := range v.dict {
+					keys = append(keys, k)
+				}
+				sort.Strings(keys)
+				list := make([]map[string]interface{}, len(keys))
+				for i, k := range keys {
+					list[i] = map[string]interface{}{"key": []byte(k), "value": []byte(v.dict[k])}
+				}
+				row[column.name] = map[string]interface{}{"key_value": list}
+			}
+		}
+	}
+	return row
+}
@@ -0,0 +1,128 @@
+package common
+
+import (
+	"bytes"
+	"io"
+	"testing"
+
+	goparquet "github.com/fraugster/parquet-go"
+	"github.com/fraugster/parquet-go/parquetschema"
+	flowmessage "github.com/netsampler/goflow2/pb"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// Writes the messages into a Parquet file and reads the rows
+func parquetRoundTrip(t *testing.T, c *ParquetCodec, msgs ...interface{}) []map[string]interface{} {
+	schema, err := parquetschema.ParseSchemaDefinition(c.Schema())
+	require.NoError(t, err)
+	buf := &bytes.Buffer{}
+	w := goparquet.NewFileWriter(buf, goparquet.WithSchemaDefinition(schema))
+	for _, msg := range msgs {
+		require.NoError(t, w.AddData(c.Row(msg)))
+	}
+	require.NoError(t, w.Close())
+
+	r, err := goparquet.NewFileReader(bytes.NewReader(buf.Bytes()))
+	require.NoError(t, err)
+	var rows []map[string]interface{}
+	for {
+		row, err := r.NextRow()
+		if err == io.EOF {
+			break
+		}
+		require.NoError(t, err)
+		rows = append(rows, row)
+	}
+	return rows
+}
+
+func TestParquetCodec(t *testing.T) {
+	defer func(s []string, tag string) {
+		selector, selectorTag = s, tag
+	}(selector, selectorTag)
+	selector, selectorTag = nil, ""
+
+	c, err := NewParquetCodec(&flowmessage.FlowMessage{})
+	require.NoError(t, err)
+	assert.Len(t, c.columns, len(flowMessageType.fields))
+
+	flowMessage := &flowmessage.FlowMessage{}
+	populate(flowMessage)
+	flowMessage.Type = flowmessage.FlowMessage_IPFIX
+	flowMessage.Bytes = 1 << 63
+
+	rows := parquetRoundTrip(t, c, flowMessage, &flowmessage.FlowMessage{})
+	require.Len(t, rows, 2)
+	row := rows[0]
+	assert.Equal(t, []byte("IPFIX"), row["type"])
+	assert.Equal(t, int64(-1<<63), row["bytes"], "stored as unsigned")
+	assert.Equal(t, int64(flowMessage.InIf), row["in_if"])
+	assert.Equal(t, flowMessage.SrcAddr, row["src_addr"])
+	assert.Equal(t, true, row["has_mpls"])
+	assert.Equal(t, map[string]interface{}{
+		"list": []map[string]interface{}{
+			{"element": int32(65000)}, {"element": int32(0)}, {"element": int32(flowMessage.AsPath[2])},
+		},
+	}, row["as_path"])
+	assert.Equal(t, map[string]interface{}{
+		"key_value": []map[string]interface{}{
+			{"key": []byte("a"), "value": []byte("va\"lue")},
+			{"key": []byte("b"), "value": []byte("2")},
+			{"key": []byte("c"), "value": []byte("")},
+		},
+	}, row["all_fields"])
+
+	// empty message
+	row = rows[1]
+	assert.Equal(t, []byte("FLOWUNKNOWN"), row["type"])
+	assert.Equal(t, []byte{}, row["src_addr"])
+	assert.NotContains(t, row, "as_path")
+	assert.NotContains(t, row, "all_fields")
+}
+
+func TestParquetCodecSelector(t *testing.T) {
+	defer func(s []string, tag string) {
+		selector, selectorTag = s, tag
+	}(selector, selectorTag)
+
+	flowMessage := &flowmessage.FlowMessage{
+		Type:           flowmessage.FlowMessage_SFLOW_5,
+		SamplerAddress: []byte{192, 0, 2, 1},
+		Bytes:          1500,
+	}
+
+	selector, selectorTag = []string{"SamplerAddress", "Bytes", "Unknown", "Bytes"}, ""
+	c, err := NewParquetCodec(&flowmessage.FlowMessage{})
+	require.NoError(t, err)
+	assert.Equal(t, "message FlowMessage {\n"+
+		"  required binary sampler_address;\n"+
+		"  required int64 bytes (INT(64, false));\n"+
+		"}\n", c.Schema())
+	assert.Equal(t, []map[string]interface{}{
+		{"sampler_address": []byte{192, 0, 2, 1}, "bytes": int64(1500)},
+	}, parquetRoundTrip(t, c, flowMessage))
+
+	selector, selectorTag = []string{"type", "sampler_address"}, "json"
+	c, err = NewParquetCodec(&flowmessage.FlowMessage{})
+	require.NoError(t, err)
+	assert.Equal(t, []map[string]interface{}{
+		{"type": []byte("SFLOW_5"), "sampler_address": []byte{192, 0, 2, 1}},
+	}, parquetRoundTrip(t, c, flowMessage))
+
+	selector, selectorTag = []string{"Unknown"}, ""
+	_, err = NewParquetCodec(&flowmessage.FlowMessage{})
+	assert.Error(t, err)
+	_, err = NewParquetCodec(&struct{}{})
+	assert.Error(t, err)
+}
+
+func BenchmarkParquetCodec(b *testing.B) {
+	c, err := NewParquetCodec(&flowmessage.FlowMessage{})
+	require.NoError(b, err)
+	msg := benchmarkFlowMessage()
+	b.ReportAllocs()
+	for i := 0; i < b.N; i++ {
+		c.Row(msg)
+	}
+}
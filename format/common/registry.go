@@ -0,0 +1,166 @@
+package common
+
+import (
+	"bytes"
+	"context"
+	"encoding/binary"
+	"encoding/json"
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"os"
+	"strings"
+	"sync"
+	"time"
+)
+
+const (
+	SCHEMA_TYPE_AVRO     = "AVRO"
+	SCHEMA_TYPE_PROTOBUF = "PROTOBUF"
+
+	REGISTRY_CONTENT_TYPE = "application/vnd.schemaregistry.v1+json"
+)
+
+var (
+	registryURL            string
+	registrySubject        string
+	registrySubjectOptions string
+
+	registryDeclared     bool
+	registryDeclaredLock = &sync.Mutex{}
+)
+
+func RegistryFlag() {
+	registryDeclaredLock.Lock()
+	defer registryDeclaredLock.Unlock()
+
+	if registryDeclared {
+		return
+	}
+	registryDeclared = true
+	flag.StringVar(&registryURL, "format.registry", "", "URL of a Confluent-compatible schema registry: the schema is registered and the messages start with its ID (environment variables SCHEMA_REGISTRY_USER and SCHEMA_REGISTRY_PASS for authentication)")
+	flag.StringVar(&registrySubject, "format.registry.subject", "flow-messages-value", "Subject of the schema of the flows")
+	flag.StringVar(&registrySubjectOptions, "format.registry.subject.options", "flow-options-value", "Subject of the schema of the NetFlow/IPFIX options data (when registered separately, eg: Avro)")
+}
+
+// Returns the schema registry configured with the flags, nil if disabled
+func ManualRegistryInit() *SchemaRegistry {
+	if registryURL == "" {
+		return nil
+	}
+	return NewSchemaRegistry(registryURL)
+}
+
+func RegistrySubjects() (string, string) {
+	return registrySubject, registrySubjectOptions
+}
+
+// Client of a Confluent-compatible schema registry
+type SchemaRegistry struct {
+	URL      string
+	User     string
+	Password string
+	Client   *http.Client
+}
+
+func NewSchemaRegistry(url string) *SchemaRegistry {
+	return &SchemaRegistry{
+		URL:      strings.TrimSuffix(url, "/"),
+		User:     os.Getenv("SCHEMA_REGISTRY_USER"),
+		Password: os.Getenv("SCHEMA_REGISTRY_PASS"),
+		Client: &http.Client{
+			Timeout: time.Second * 10,
+		},
+	}
+}
+
+type registerRequest struct {
+	Schema     string `json:"schema"`
+	SchemaType string `json:"schemaType,omitempty"`
+}
+
+type registerResponse struct {
+	Id int `json:"id"`
+}
+
+type registryError struct {
+	ErrorCode int    `json:"error_code"`
+	Message   string `json:"message"`
+}
+
+// Registers a schema under a subject and returns its ID.
+// The ID of an identical schema already registered is returned.
+func (r *SchemaRegistry) Register(ctx context.Context, subject, schemaType, schema string) (int, error) {
+	request := registerRequest{
+		Schema: schema,
+	}
+	if schemaType != SCHEMA_TYPE_AVRO { // the default type, not sent for older registries
+		request.SchemaType = schemaType
+	}
+	body, err := json.Marshal(request)
+	if err != nil {
+		return 0, err
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost,
+		fmt.Sprintf("%s/subjects/%s/versions", r.URL, url.PathEscape(subject)),
+		bytes.NewReader(body))
+	if err != nil {
+		return 0, err
+	}
+	req.Header.Set("Content-Type", REGISTRY_CONTENT_TYPE)
+	req.Header.Set("Accept", REGISTRY_CONTENT_TYPE)
+	if r.User != "" || r.Password != "" {
+		req.SetBasicAuth(r.User, r.Password)
+	}
+
+	resp, err := r.Client.Do(req)
+	if err != nil {
+		return 0, errors.New(fmt.Sprintf("Schema registry: %v", err))
+	}
+	defer resp.Body.Close()
+	respBody, err := io.ReadAll(io.LimitReader(resp.Body, 1<<20))
+	if err != nil {
+		return 0, errors.New(fmt.Sprintf("Schema registry: %v", err))
+	}
+
+	if resp.StatusCode != http.StatusOK {
+		var registryErr registryError
+		if json.Unmarshal(respBody, &registryErr) == nil && registryErr.Message != "" {
+			return 0, errors.New(fmt.Sprintf("Schema registry: registering %s: %s (error code %d)", subject, registryErr.Message, registryErr.ErrorCode))
+		}
+		return 0, errors.New(fmt.Sprintf("Schema registry: registering %s: %s", subject, resp.Status))
+	}
+	var registered registerResponse
+	if err := json.Unmarshal(respBody, &registered); err != nil {
+		return 0, errors.New(fmt.Sprintf("Schema registry: registering %s: %v", subject, err))
+	}
+	return registered.Id, nil
+}
+
+// Header of the messages serialized with a schema of the registry (Confluent wire format):
+// magic byte 0 and schema ID
+func RegistryHeader(id int) []byte {
+	header := make([]byte, 5)
+	binary.BigEndian.PutUint32(header[1:], uint32(id))
+	return header
+}
+
+// Header of the protobuf messages serialized with a schema of the registry:
+// magic byte 0, schema ID and indexes of the message in the schema (zigzag varints)
+func RegistryProtobufHeader(id int, indexes []int) []byte {
+	header := RegistryHeader(id)
+	// optimization of the wire format for the first message
+	if len(indexes) == 1 && indexes[0] == 0 {
+		return append(header, 0)
+	}
+	varint := make([]byte, binary.MaxVarintLen64)
+	header = append(header, varint[:binary.PutVarint(varint, int64(len(indexes)))]...)
+	for _, index := range indexes {
+		header = append(header, varint[:binary.PutVarint(varint, int64(index))]...)
+	}
+	return header
+}
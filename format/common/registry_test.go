@@ -0,0 +1,98 @@
+package common
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// Stand-in of the registration endpoint of a schema registry
+type registryStandIn struct {
+	schemas map[string]int // by schema type and schema
+	ids     map[string]int // by subject
+}
+
+func (s *registryStandIn) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	w.Header().Set("Content-Type", REGISTRY_CONTENT_TYPE)
+	if user, pass, ok := r.BasicAuth(); !ok || user != "user" || pass != "pass" {
+		w.WriteHeader(http.StatusUnauthorized)
+		w.Write([]byte(`{"error_code":401,"message":"Unauthorized"}`))
+		return
+	}
+	if r.Method != http.MethodPost || r.Header.Get("Content-Type") != REGISTRY_CONTENT_TYPE {
+		w.WriteHeader(http.StatusMethodNotAllowed)
+		return
+	}
+	if !strings.HasPrefix(r.URL.Path, "/subjects/") || !strings.HasSuffix(r.URL.Path, "/versions") {
+		w.WriteHeader(http.StatusNotFound)
+		return
+	}
+	subject := strings.TrimSuffix(strings.TrimPrefix(r.URL.Path, "/subjects/"), "/versions")
+
+	var request registerRequest
+	if err := json.NewDecoder(r.Body).Decode(&request); err != nil || request.Schema == "invalid" {
+		w.WriteHeader(http.StatusUnprocessableEntity)
+		w.Write([]byte(`{"error_code":42201,"message":"Invalid schema"}`))
+		return
+	}
+	key := request.SchemaType + request.Schema
+	id, ok := s.schemas[key]
+	if !ok {
+		id = len(s.schemas) + 1
+		s.schemas[key] = id
+	}
+	s.ids[subject] = id
+	json.NewEncoder(w).Encode(registerResponse{Id: id})
+}
+
+func TestSchemaRegistry(t *testing.T) {
+	standIn := &registryStandIn{
+		schemas: make(map[string]int),
+		ids:     make(map[string]int),
+	}
+	server := httptest.NewServer(standIn)
+	defer server.Close()
+
+	t.Setenv("SCHEMA_REGISTRY_USER", "user")
+	t.Setenv("SCHEMA_REGISTRY_PASS", "pass")
+	registry := NewSchemaRegistry(server.URL + "/")
+	ctx := context.Background()
+
+	id, err := registry.Register(ctx, "flows-value", SCHEMA_TYPE_PROTOBUF, "syntax = \"proto3\";")
+	require.NoError(t, err)
+	assert.Equal(t, 1, id)
+	id, err = registry.Register(ctx, "flows-avro-value", SCHEMA_TYPE_AVRO, `{"type":"record"}`)
+	require.NoError(t, err)
+	assert.Equal(t, 2, id)
+	assert.Contains(t, standIn.schemas, `{"type":"record"}`, "schema type not sent for Avro")
+
+	// same schema registered again
+	id, err = registry.Register(ctx, "flows-other-value", SCHEMA_TYPE_PROTOBUF, "syntax = \"proto3\";")
+	require.NoError(t, err)
+	assert.Equal(t, 1, id)
+	assert.Equal(t, map[string]int{"flows-value": 1, "flows-avro-value": 2, "flows-other-value": 1}, standIn.ids)
+
+	_, err = registry.Register(ctx, "flows-value", SCHEMA_TYPE_AVRO, "invalid")
+	assert.EqualError(t, err, "Schema registry: registering flows-value: Invalid schema (error code 42201)")
+
+	registry.Password = "wrong"
+	_, err = registry.Register(ctx, "flows-value", SCHEMA_TYPE_AVRO, "{}")
+	assert.EqualError(t, err, "Schema registry: registering flows-value: Unauthorized (error code 401)")
+
+	server.Close()
+	_, err = registry.Register(ctx, "flows-value", SCHEMA_TYPE_AVRO, "{}")
+	assert.Error(t, err)
+}
+
+func TestRegistryHeader(t *testing.T) {
+	assert.Equal(t, []byte{0, 0, 0, 1, 2}, RegistryHeader(258))
+	assert.Equal(t, []byte{0, 0, 0, 0, 42, 0}, RegistryProtobufHeader(42, []int{0}))
+	assert.Equal(t, []byte{0, 0, 0, 0, 42, 2, 2}, RegistryProtobufHeader(42, []int{1}))
+	assert.Equal(t, []byte{0, 0, 0, 0, 42, 4, 0, 6}, RegistryProtobufHeader(42, []int{0, 3}))
+}
@@ -2,9 +2,11 @@ package common
 
 import (
 	"encoding/binary"
+	"encoding/hex"
 	"fmt"
 	"net"
 	"reflect"
+	"sort"
 	"strings"
 )
 
@@ -16,6 +18,7 @@ const (
 	FORMAT_TYPE_IP
 	FORMAT_TYPE_MAC
 	FORMAT_TYPE_BYTES
+	FORMAT_TYPE_IP_LIST
 )
 
 var (
@@ -58,6 +61,15 @@ var (
 		"DstMac":         FORMAT_TYPE_MAC,
 		"NextHop":        FORMAT_TYPE_IP,
 		"MPLSLabelIP":    FORMAT_TYPE_IP,
+		"PostNatSrcAddr": FORMAT_TYPE_IP,
+		"PostNatDstAddr": FORMAT_TYPE_IP,
+		"WlanBssid":      FORMAT_TYPE_MAC,
+		"TunnelType":     FORMAT_TYPE_STRING_FUNC,
+		"TunnelSrcAddr":  FORMAT_TYPE_IP,
+		"TunnelDstAddr":  FORMAT_TYPE_IP,
+		"Srv6Segments":   FORMAT_TYPE_IP_LIST,
+		"RawHeader":      FORMAT_TYPE_BYTES,
+		"ApplicationId":  FORMAT_TYPE_BYTES,
 	}
 
 	RenderExtras = map[string]RenderExtraFunction{
@@ -139,6 +151,13 @@ func ExtractTag(name, original string, tag reflect.StructTag) string {
 }
 
 func FormatMessageReflectCustom(msg interface{}, ext, quotes, sep, sign string, null bool) string {
+	if t := generatedMessageType(msg); t != nil {
+		return t.format(msg, quotes, sep, sign, null)
+	}
+	return formatMessageReflectCustom(msg, ext, quotes, sep, sign, null)
+}
+
+func formatMessageReflectCustom(msg interface{}, ext, quotes, sep, sign string, null bool) string {
 	customSelector := selector
 	reMap := make(map[string]string)
 
@@ -175,72 +194,92 @@ func FormatMessageReflectCustom(msg interface{}, ext, quotes, sep, sign string,
 		}
 	}
 
-	fstr := make([]string, len(customSelector))
-
-	var i int
-
+	fstr := make([]string, 0, len(customSelector))
 	for _, s := range customSelector {
 		fieldName := s
 		if fieldNameMap, ok := reMap[fieldName]; ok {
 			fieldName = fieldNameMap
 		}
-		fieldValue := vfm.FieldByName(fieldName)
-		// todo: replace s by json mapping of protobuf
-		if fieldValue.IsValid() {
+		if str, ok := formatFieldReflect(msg, vfm, s, fieldName, quotes, sign, null); ok {
+			fstr = append(fstr, str)
+		}
+	}
 
-			if fieldType, ok := TextFields[fieldName]; ok {
-				switch fieldType {
-				case FORMAT_TYPE_STRING_FUNC:
-					strMethod := fieldValue.MethodByName("String").Call([]reflect.Value{})
-					fstr[i] = fmt.Sprintf("%s%s%s%s%q", quotes, s, quotes, sign, strMethod[0].String())
-				case FORMAT_TYPE_STRING:
-					fstr[i] = fmt.Sprintf("%s%s%s%s%q", quotes, s, quotes, sign, fieldValue.String())
-				case FORMAT_TYPE_INTEGER:
-					fstr[i] = fmt.Sprintf("%s%s%s%s%d", quotes, s, quotes, sign, fieldValue.Uint())
-				case FORMAT_TYPE_IP:
-					ip := fieldValue.Bytes()
-					fstr[i] = fmt.Sprintf("%s%s%s%s%q", quotes, s, quotes, sign, RenderIP(ip))
-				case FORMAT_TYPE_MAC:
-					mac := make([]byte, 8)
-					binary.BigEndian.PutUint64(mac, fieldValue.Uint())
-					fstr[i] = fmt.Sprintf("%s%s%s%s%q", quotes, s, quotes, sign, net.HardwareAddr(mac[2:]).String())
-				case FORMAT_TYPE_BYTES:
-					fstr[i] = fmt.Sprintf("%s%s%s%s%.2x", quotes, s, quotes, sign, fieldValue.Bytes())
-				default:
-					if null {
-						fstr[i] = fmt.Sprintf("%s%s%s%snull", quotes, s, quotes, sign)
-					} else {
-
-					}
-				}
-			} else if renderer, ok := RenderExtras[fieldName]; ok {
-				fstr[i] = fmt.Sprintf("%s%s%s%s%q", quotes, s, quotes, sign, renderer(msg))
-			} else {
-				// handle specific types here
-				switch fieldValue.Kind() {
-				case reflect.String:
-					fstr[i] = fmt.Sprintf("%s%s%s%s%q", quotes, s, quotes, sign, fieldValue.Interface())
-				case reflect.Slice:
-					c := fieldValue.Len()
-					v := "["
-					for i := 0; i < c; i++ {
-						v += fmt.Sprintf("%v", fieldValue.Index(i).Interface())
-						if i < c-1 {
-							v += ","
-						}
-					}
-					v += "]"
-					fstr[i] = fmt.Sprintf("%s%s%s%s%s", quotes, s, quotes, sign, v)
-				default:
-					fstr[i] = fmt.Sprintf("%s%s%s%s%v", quotes, s, quotes, sign, fieldValue.Interface())
-				}
+	return strings.Join(fstr, sep)
+}
 
+// Renders a field of a message as s, returns false when the field is not rendered
+func formatFieldReflect(msg interface{}, vfm reflect.Value, s, fieldName, quotes, sign string, null bool) (string, bool) {
+	fieldValue := vfm.FieldByName(fieldName)
+	// todo: replace s by json mapping of protobuf
+	if !fieldValue.IsValid() {
+		return "", false
+	}
+
+	if fieldType, ok := TextFields[fieldName]; ok {
+		switch fieldType {
+		case FORMAT_TYPE_STRING_FUNC:
+			strMethod := fieldValue.MethodByName("String").Call([]reflect.Value{})
+			return fmt.Sprintf("%s%s%s%s%q", quotes, s, quotes, sign, strMethod[0].String()), true
+		case FORMAT_TYPE_STRING:
+			return fmt.Sprintf("%s%s%s%s%q", quotes, s, quotes, sign, fieldValue.String()), true
+		case FORMAT_TYPE_INTEGER:
+			return fmt.Sprintf("%s%s%s%s%d", quotes, s, quotes, sign, fieldValue.Uint()), true
+		case FORMAT_TYPE_IP:
+			ip := fieldValue.Bytes()
+			return fmt.Sprintf("%s%s%s%s%q", quotes, s, quotes, sign, RenderIP(ip)), true
+		case FORMAT_TYPE_MAC:
+			mac := make([]byte, 8)
+			binary.BigEndian.PutUint64(mac, fieldValue.Uint())
+			return fmt.Sprintf("%s%s%s%s%q", quotes, s, quotes, sign, net.HardwareAddr(mac[2:]).String()), true
+		case FORMAT_TYPE_BYTES:
+			return fmt.Sprintf("%s%s%s%s%q", quotes, s, quotes, sign, hex.EncodeToString(fieldValue.Bytes())), true
+		case FORMAT_TYPE_IP_LIST:
+			ips := make([]string, fieldValue.Len())
+			for j := range ips {
+				ips[j] = fmt.Sprintf("%q", RenderIP(fieldValue.Index(j).Bytes()))
+			}
+			return fmt.Sprintf("%s%s%s%s[%s]", quotes, s, quotes, sign, strings.Join(ips, ",")), true
+		default:
+			if null {
+				return fmt.Sprintf("%s%s%s%snull", quotes, s, quotes, sign), true
 			}
-			i++
+			return "", true
 		}
-
+	} else if renderer, ok := RenderExtras[fieldName]; ok {
+		return fmt.Sprintf("%s%s%s%s%q", quotes, s, quotes, sign, renderer(msg)), true
 	}
-	fstr = fstr[0:i]
 
-	return strings.Join(fstr, sep)
+	// handle specific types here
+	switch fieldValue.Kind() {
+	case reflect.String:
+		return fmt.Sprintf("%s%s%s%s%q", quotes, s, quotes, sign, fieldValue.Interface()), true
+	case reflect.Slice:
+		c := fieldValue.Len()
+		v := "["
+		for i := 0; i < c; i++ {
+			v += fmt.Sprintf("%v", fieldValue.Index(i).Interface())
+			if i < c-1 {
+				v += ","
+			}
+		}
+		v += "]"
+		return fmt.Sprintf("%s%s%s%s%s", quotes, s, quotes, sign, v), true
+	case reflect.Map:
+		if fieldValue.Len() == 0 {
+			return "", false // only rendered when populated
+		}
+		keys := make([]string, 0, fieldValue.Len())
+		for _, k := range fieldValue.MapKeys() {
+			keys = append(keys, fmt.Sprintf("%v", k.Interface()))
+		}
+		sort.Strings(keys)
+		v := make([]string, len(keys))
+		for j, k := range keys {
+			v[j] = fmt.Sprintf("%s%s%s%s%s", quotes, k, quotes, sign, appendJSONString(nil, fieldValue.MapIndex(reflect.ValueOf(k)).String()))
+		}
+		return fmt.Sprintf("%s%s%s%s{%s}", quotes, s, quotes, sign, strings.Join(v, ",")), true
+	default:
+		return fmt.Sprintf("%s%s%s%s%v", quotes, s, quotes, sign, fieldValue.Interface()), true
+	}
 }
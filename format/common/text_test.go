@@ -0,0 +1,230 @@
+package common
+
+import (
+	"encoding/json"
+	"reflect"
+	"testing"
+
+	flowmessage "github.com/netsampler/goflow2/pb"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// Sets every exported field of a message to a non-zero value
+func populate(msg interface{}) {
+	vfm := reflect.ValueOf(msg).Elem()
+	for i := 0; i < vfm.NumField(); i++ {
+		field := vfm.Type().Field(i)
+		if !field.IsExported() {
+			continue
+		}
+		value := vfm.Field(i)
+		switch value.Kind() {
+		case reflect.Int32:
+			value.SetInt(int64(1 + i%2))
+		case reflect.Uint32, reflect.Uint64:
+			value.SetUint(uint64(0x1000 + i))
+		case reflect.Bool:
+			value.SetBool(true)
+		case reflect.String:
+			value.SetString(field.Name + " \"quoted\" é\t")
+		case reflect.Map:
+			value.Set(reflect.ValueOf(map[string]string{"b": "2", "a": "va\"lue", "c": ""}))
+		case reflect.Slice:
+			switch value.Interface().(type) {
+			case []byte:
+				if i%2 == 0 {
+					value.SetBytes([]byte{192, 0, 2, byte(i)})
+				} else {
+					value.SetBytes([]byte{0x20, 0x01, 0x0d, 0xb8, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, byte(i)})
+				}
+			case []uint32:
+				value.Set(reflect.ValueOf([]uint32{65000, 0, uint32(i)}))
+			case [][]byte:
+				value.Set(reflect.ValueOf([][]byte{{0x20, 0x01, 0x0d, 0xb8, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 1}, {1, 2, 3}}))
+			}
+		}
+	}
+}
+
+func clearPlans(t *messageType) {
+	t.formatPlans.Range(func(key, _ interface{}) bool {
+		t.formatPlans.Delete(key)
+		return true
+	})
+}
+
+func assertFormatEqual(t *testing.T, msg interface{}) {
+	assert.Equal(t,
+		formatMessageReflectCustom(msg, "", "\"", ",", ":", true),
+		FormatMessageReflectCustom(msg, "", "\"", ",", ":", true), "json")
+	assert.Equal(t,
+		formatMessageReflectCustom(msg, "", "", " ", "=", false),
+		FormatMessageReflectCustom(msg, "", "", " ", "=", false), "text")
+}
+
+func TestFormatMessageGenerated(t *testing.T) {
+	defer func(s []string, tag string) {
+		selector, selectorTag = s, tag
+	}(selector, selectorTag)
+
+	flowMessage := &flowmessage.FlowMessage{}
+	populate(flowMessage)
+	optionsMessage := &flowmessage.OptionsMessage{}
+	populate(optionsMessage)
+	messages := []interface{}{
+		flowMessage,
+		&flowmessage.FlowMessage{},
+		&flowmessage.FlowMessage{SrcAddr: []byte{1, 2, 3}, Srv6Segments: [][]byte{{192, 0, 2, 1}}},
+		optionsMessage,
+		&flowmessage.OptionsMessage{},
+	}
+
+	for _, test := range []struct {
+		selector []string
+		tag      string
+	}{
+		{nil, ""},
+		{[]string{"Type", "SrcAddr", "Unknown", "AllFields", "Srv6Segments", "SrcMac", "RawHeader", "AsPath", "HasMpls", "Scopes"}, ""},
+		{nil, "json"},
+		{[]string{"type", "src_addr", "SrcAddr", "all_fields", "time_received", "options"}, "json"},
+		{nil, "unknown"},
+	} {
+		selector, selectorTag = test.selector, test.tag
+		for _, msg := range messages {
+			assertFormatEqual(t, msg)
+		}
+	}
+
+	// custom formats, including a format which does not match the type of the field
+	selector, selectorTag = nil, ""
+	defer func() {
+		delete(TextFields, "Proto")
+		delete(TextFields, "SrcAs")
+		delete(TextFields, "DstAs")
+		clearPlans(flowMessageType)
+	}()
+	TextFields["Proto"] = FORMAT_TYPE_INTEGER
+	TextFields["SrcAs"] = FORMAT_TYPE_UNKNOWN
+	TextFields["DstAs"] = FORMAT_TYPE_STRING
+	clearPlans(flowMessageType)
+	assertFormatEqual(t, flowMessage)
+}
+
+func TestHashProtoGenerated(t *testing.T) {
+	flowMessage := &flowmessage.FlowMessage{}
+	populate(flowMessage)
+	optionsMessage := &flowmessage.OptionsMessage{}
+	populate(optionsMessage)
+
+	var allFields []string
+	for _, field := range flowMessageType.fields {
+		allFields = append(allFields, field.name)
+	}
+	for _, fields := range [][]string{
+		{"SamplerAddress"},
+		{"SamplerAddress", "DstAS", "DstAs", "Type", "Unknown"},
+		{""},
+		nil,
+		allFields,
+	} {
+		assert.Equal(t, hashProtoReflect(fields, flowMessage), HashProto(fields, flowMessage))
+		assert.Equal(t, hashProtoReflect(fields, &flowmessage.FlowMessage{}), HashProto(fields, &flowmessage.FlowMessage{}))
+	}
+	fields := []string{"Type", "SamplerAddress", "Scopes", "Options", "TemplateId"}
+	assert.Equal(t, hashProtoReflect(fields, optionsMessage), HashProto(fields, optionsMessage))
+	assert.Equal(t, "", HashProto(fields, nil))
+}
+
+func benchmarkFlowMessage() *flowmessage.FlowMessage {
+	return &flowmessage.FlowMessage{
+		Type:           flowmessage.FlowMessage_IPFIX,
+		TimeReceived:   1700000000,
+		SequenceNum:    1234,
+		SamplingRate:   1000,
+		SamplerAddress: []byte{192, 0, 2, 1},
+		TimeFlowStart:  1699999990,
+		TimeFlowEnd:    1700000000,
+		Bytes:          1500,
+		Packets:        1,
+		SrcAddr:        []byte{198, 51, 100, 1},
+		DstAddr:        []byte{203, 0, 113, 1},
+		Etype:          0x800,
+		Proto:          6,
+		SrcPort:        443,
+		DstPort:        51000,
+		InIf:           1,
+		OutIf:          2,
+		SrcMac:         0x005e00530001,
+		DstMac:         0x005e00530002,
+		SrcAs:          65000,
+		DstAs:          65001,
+		NextHop:        []byte{192, 0, 2, 254},
+		TcpFlags:       0x18,
+	}
+}
+
+func BenchmarkFormatJSON(b *testing.B) {
+	msg := benchmarkFlowMessage()
+	b.Run("generated", func(b *testing.B) {
+		b.ReportAllocs()
+		for i := 0; i < b.N; i++ {
+			FormatMessageReflectJSON(msg, "")
+		}
+	})
+	b.Run("reflect", func(b *testing.B) {
+		b.ReportAllocs()
+		for i := 0; i < b.N; i++ {
+			formatMessageReflectCustom(msg, "", "\"", ",", ":", true)
+		}
+	})
+}
+
+func BenchmarkFormatText(b *testing.B) {
+	msg := benchmarkFlowMessage()
+	b.Run("generated", func(b *testing.B) {
+		b.ReportAllocs()
+		for i := 0; i < b.N; i++ {
+			FormatMessageReflectText(msg, "")
+		}
+	})
+	b.Run("reflect", func(b *testing.B) {
+		b.ReportAllocs()
+		for i := 0; i < b.N; i++ {
+			formatMessageReflectCustom(msg, "", "", " ", "=", false)
+		}
+	})
+}
+
+func BenchmarkHashProto(b *testing.B) {
+	msg := benchmarkFlowMessage()
+	fields := []string{"SamplerAddress", "SrcAddr", "DstAs"}
+	b.Run("generated", func(b *testing.B) {
+		b.ReportAllocs()
+		for i := 0; i < b.N; i++ {
+			HashProto(fields, msg)
+		}
+	})
+	b.Run("reflect", func(b *testing.B) {
+		b.ReportAllocs()
+		for i := 0; i < b.N; i++ {
+			hashProtoReflect(fields, msg)
+		}
+	})
+}
+
+func TestFormatMessageMapJSON(t *testing.T) {
+	defer func(s []string, tag string) {
+		selector, selectorTag = s, tag
+	}(selector, selectorTag)
+	selector, selectorTag = []string{"AllFields"}, ""
+
+	flowMessage := &flowmessage.FlowMessage{
+		AllFields: map[string]string{"interfaceDescription": "eth\x00\x1f\xff \"0\"\\\n é\u2028"},
+	}
+	assertFormatEqual(t, flowMessage)
+
+	var record map[string]map[string]string
+	require.NoError(t, json.Unmarshal([]byte(FormatMessageReflectJSON(flowMessage, "")), &record))
+	assert.Equal(t, "eth\x00\x1f\ufffd \"0\"\\\n é\u2028", record["AllFields"]["interfaceDescription"])
+}
@@ -0,0 +1,42 @@
+package parquet
+
+import (
+	"context"
+
+	"github.com/netsampler/goflow2/format"
+	"github.com/netsampler/goflow2/format/common"
+	flowmessage "github.com/netsampler/goflow2/pb"
+)
+
+// Formats the flows for the parquet transport, which writes the fields selected
+// with -format.selector of the flows into Parquet files: only the key is computed.
+// Options data are skipped.
+type ParquetDriver struct {
+}
+
+func (d *ParquetDriver) Prepare() error {
+	common.HashFlag()
+	common.SelectorFlag()
+	return nil
+}
+
+func (d *ParquetDriver) Init(context.Context) error {
+	if err := common.ManualHashInit(); err != nil {
+		return err
+	}
+	return common.ManualSelectorInit()
+}
+
+func (d *ParquetDriver) Format(data interface{}) ([]byte, []byte, error) {
+	msg, ok := data.(*flowmessage.FlowMessage)
+	if !ok {
+		return nil, nil, nil
+	}
+	key := common.HashProtoLocal(msg)
+	return []byte(key), nil, nil
+}
+
+func init() {
+	d := &ParquetDriver{}
+	format.RegisterFormatDriver("parquet", d)
+}
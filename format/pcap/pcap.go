@@ -0,0 +1,113 @@
+package pcap
+
+import (
+	"context"
+	"encoding/binary"
+	"sync"
+
+	"github.com/netsampler/goflow2/format"
+	"github.com/netsampler/goflow2/format/common"
+	flowmessage "github.com/netsampler/goflow2/pb"
+)
+
+const (
+	// sFlow header protocols
+	HEADER_PROTOCOL_ETHERNET = 1
+	HEADER_PROTOCOL_IPV4     = 11
+	HEADER_PROTOCOL_IPV6     = 12
+
+	RECORD_HEADER_LENGTH = 16
+)
+
+// Formats the sampled headers (RawHeader) into pcap records.
+// The pcap transport adds the file header. Messages without a header are skipped.
+type PcapDriver struct {
+	lock    *sync.Mutex
+	lastSec uint64
+	usec    uint32
+}
+
+func (d *PcapDriver) Prepare() error {
+	common.HashFlag()
+	return nil
+}
+
+func (d *PcapDriver) Init(context.Context) error {
+	return common.ManualHashInit()
+}
+
+// Returns the time of reception of a sample in seconds and microseconds.
+// Without the time in nanoseconds, successive samples received in the same second
+// are spaced by one microsecond to keep their order.
+func (d *PcapDriver) timestamp(msg *flowmessage.FlowMessage) (uint32, uint32) {
+	if msg.TimeReceivedNs != 0 {
+		return uint32(msg.TimeReceivedNs / 1e9), uint32(msg.TimeReceivedNs % 1e9 / 1e3)
+	}
+	sec := msg.TimeReceived
+	d.lock.Lock()
+	defer d.lock.Unlock()
+	if sec != d.lastSec {
+		d.lastSec = sec
+		d.usec = 0
+	} else if d.usec < 999999 {
+		d.usec++
+	}
+	return uint32(sec), d.usec
+}
+
+// Returns the sampled header as an Ethernet frame.
+// IPv4 and IPv6 headers are prepended an Ethernet header with empty addresses.
+func EthernetFrame(msg *flowmessage.FlowMessage) []byte {
+	switch msg.RawHeaderProtocol {
+	case HEADER_PROTOCOL_ETHERNET:
+		return msg.RawHeader
+	case HEADER_PROTOCOL_IPV4, HEADER_PROTOCOL_IPV6:
+		frame := make([]byte, 14, 14+len(msg.RawHeader))
+		if msg.RawHeaderProtocol == HEADER_PROTOCOL_IPV4 {
+			binary.BigEndian.PutUint16(frame[12:14], 0x0800)
+		} else {
+			binary.BigEndian.PutUint16(frame[12:14], 0x86dd)
+		}
+		return append(frame, msg.RawHeader...)
+	}
+	return nil
+}
+
+func (d *PcapDriver) Format(data interface{}) ([]byte, []byte, error) {
+	msg, ok := data.(*flowmessage.FlowMessage)
+	if !ok || len(msg.RawHeader) == 0 {
+		return nil, nil, nil
+	}
+	frame := EthernetFrame(msg)
+	if frame == nil {
+		return nil, nil, nil
+	}
+	key := common.HashProtoLocal(msg)
+
+	// the original length does not include the stripped bytes (eg: FCS)
+	origLen := uint32(len(frame))
+	if msg.RawHeaderFrameLength > msg.RawHeaderStripped {
+		frameLength := msg.RawHeaderFrameLength - msg.RawHeaderStripped
+		if msg.RawHeaderProtocol != HEADER_PROTOCOL_ETHERNET {
+			frameLength += 14
+		}
+		if frameLength > origLen {
+			origLen = frameLength
+		}
+	}
+
+	sec, usec := d.timestamp(msg)
+	record := make([]byte, RECORD_HEADER_LENGTH, RECORD_HEADER_LENGTH+len(frame))
+	binary.LittleEndian.PutUint32(record[0:4], sec)
+	binary.LittleEndian.PutUint32(record[4:8], usec)
+	binary.LittleEndian.PutUint32(record[8:12], uint32(len(frame)))
+	binary.LittleEndian.PutUint32(record[12:16], origLen)
+	return []byte(key), append(record, frame...), nil
+}
+
+func init() {
+	d := &PcapDriver{
+		lock: &sync.Mutex{},
+	}
+	format.RegisterFormatDriver("pcap", d)
+}
@@ -0,0 +1,37 @@
+package pcap
+
+import (
+	"encoding/binary"
+	"sync"
+	"testing"
+
+	flowmessage "github.com/netsampler/goflow2/pb"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestPcapTimestamp(t *testing.T) {
+	d := &PcapDriver{
+		lock: &sync.Mutex{},
+	}
+	msg := &flowmessage.FlowMessage{
+		TimeReceived:      1677664500,
+		TimeReceivedNs:    1677664500123456789,
+		RawHeader:         make([]byte, 20),
+		RawHeaderProtocol: HEADER_PROTOCOL_IPV4,
+	}
+	_, record, err := d.Format(msg)
+	require.NoError(t, err)
+	assert.Equal(t, uint32(1677664500), binary.LittleEndian.Uint32(record[0:4]))
+	assert.Equal(t, uint32(123456), binary.LittleEndian.Uint32(record[4:8]))
+	assert.Equal(t, uint32(34), binary.LittleEndian.Uint32(record[8:12]))
+
+	// synthesized from the time in seconds
+	msg.TimeReceivedNs = 0
+	for i := uint32(0); i < 2; i++ {
+		_, record, err = d.Format(msg)
+		require.NoError(t, err)
+		assert.Equal(t, uint32(1677664500), binary.LittleEndian.Uint32(record[0:4]))
+		assert.Equal(t, i, binary.LittleEndian.Uint32(record[4:8]))
+	}
+}
@@ -7,20 +7,47 @@ import (
 	"github.com/golang/protobuf/proto"
 	"github.com/netsampler/goflow2/format"
 	"github.com/netsampler/goflow2/format/common"
+	flowmessage "github.com/netsampler/goflow2/pb"
+	"google.golang.org/protobuf/encoding/protowire"
+	protov2 "google.golang.org/protobuf/proto"
 )
 
 type ProtobufDriver struct {
 	fixedLen bool
+
+	// prepended to the messages when using a schema registry, by message name
+	headers map[string][]byte
 }
 
 func (d *ProtobufDriver) Prepare() error {
 	common.HashFlag()
+	common.RegistryFlag()
 	flag.BoolVar(&d.fixedLen, "format.protobuf.fixedlen", false, "Prefix the protobuf with message length")
 	return nil
 }
 
-func (d *ProtobufDriver) Init(context.Context) error {
-	return common.ManualHashInit()
+func (d *ProtobufDriver) Init(ctx context.Context) error {
+	if err := common.ManualHashInit(); err != nil {
+		return err
+	}
+
+	d.headers = nil
+	registry := common.ManualRegistryInit()
+	if registry == nil {
+		return nil
+	}
+	// flow.proto contains the flows and the options data, identified by their index
+	subject, _ := common.RegistrySubjects()
+	id, err := registry.Register(ctx, subject, common.SCHEMA_TYPE_PROTOBUF, flowmessage.FlowProto)
+	if err != nil {
+		return err
+	}
+	d.headers = make(map[string][]byte)
+	messages := flowmessage.File_pb_flow_proto.Messages()
+	for i := 0; i < messages.Len(); i++ {
+		d.headers[string(messages.Get(i).FullName())] = common.RegistryProtobufHeader(id, []int{i})
+	}
+	return nil
 }
 
 func (d *ProtobufDriver) Format(data interface{}) ([]byte, []byte, error) {
@@ -30,6 +57,22 @@ func (d *ProtobufDriver) Format(data interface{}) ([]byte, []byte, error) {
 	}
 	key := common.HashProtoLocal(msg)
 
+	if d.headers != nil {
+		msgV2 := proto.MessageV2(msg)
+		header, ok := d.headers[string(msgV2.ProtoReflect().Descriptor().FullName())]
+		if !ok {
+			return nil, nil, fmt.Errorf("message is not in the registered schema")
+		}
+		b := make([]byte, len(header), len(header)+protov2.Size(msgV2))
+		copy(b, header)
+		b, err := protov2.MarshalOptions{}.MarshalAppend(b, msgV2)
+		if err != nil || !d.fixedLen {
+			return []byte(key), b, err
+		}
+		// the length covers the header
+		return []byte(key), append(protowire.AppendVarint(nil, uint64(len(b))), b...), nil
+	}
+
 	if !d.fixedLen {
 		b, err := proto.Marshal(msg)
 		return []byte(key), b, err
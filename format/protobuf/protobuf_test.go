@@ -0,0 +1,68 @@
+package protobuf
+
+import (
+	"context"
+	"encoding/json"
+	"flag"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/golang/protobuf/proto"
+	flowmessage "github.com/netsampler/goflow2/pb"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/protobuf/encoding/protowire"
+)
+
+func TestProtobufRegistry(t *testing.T) {
+	var registered map[string]string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		require.Equal(t, "/subjects/flows-value/versions", r.URL.Path)
+		require.NoError(t, json.NewDecoder(r.Body).Decode(&registered))
+		w.Write([]byte(`{"id":7}`))
+	}))
+	defer server.Close()
+
+	// flags declared when registering the driver
+	d := &ProtobufDriver{}
+	require.NoError(t, flag.Set("format.registry", server.URL))
+	require.NoError(t, flag.Set("format.registry.subject", "flows-value"))
+	require.NoError(t, d.Init(context.Background()))
+	assert.Equal(t, "PROTOBUF", registered["schemaType"])
+	assert.Equal(t, flowmessage.FlowProto, registered["schema"])
+
+	flowMessage := &flowmessage.FlowMessage{SamplerAddress: []byte{192, 0, 2, 1}, Bytes: 1500}
+	_, data, err := d.Format(flowMessage)
+	require.NoError(t, err)
+	assert.Equal(t, []byte{0, 0, 0, 0, 7, 0}, data[:6])
+	decodedFlow := &flowmessage.FlowMessage{}
+	require.NoError(t, proto.Unmarshal(data[6:], decodedFlow))
+	assert.True(t, proto.Equal(flowMessage, decodedFlow))
+
+	// second message of flow.proto
+	optionsMessage := &flowmessage.OptionsMessage{TemplateId: 256}
+	_, data, err = d.Format(optionsMessage)
+	require.NoError(t, err)
+	assert.Equal(t, []byte{0, 0, 0, 0, 7, 2, 2}, data[:7])
+	decodedOptions := &flowmessage.OptionsMessage{}
+	require.NoError(t, proto.Unmarshal(data[7:], decodedOptions))
+	assert.True(t, proto.Equal(optionsMessage, decodedOptions))
+
+	// the length prefix covers the header
+	d.fixedLen = true
+	_, data, err = d.Format(flowMessage)
+	require.NoError(t, err)
+	length, n := protowire.ConsumeVarint(data)
+	require.Greater(t, n, 0)
+	assert.Equal(t, uint64(len(data)-n), length)
+	assert.Equal(t, []byte{0, 0, 0, 0, 7, 0}, data[n:n+6])
+
+	require.NoError(t, flag.Set("format.registry", ""))
+	require.NoError(t, d.Init(context.Background()))
+	d.fixedLen = false
+	_, data, err = d.Format(flowMessage)
+	require.NoError(t, err)
+	expected, _ := proto.Marshal(flowMessage)
+	assert.Equal(t, expected, data)
+}
@@ -0,0 +1,10 @@
+package flowpb
+
+import (
+	_ "embed"
+)
+
+// Source of flow.proto, registered in schema registries
+//
+//go:embed flow.proto
+var FlowProto string
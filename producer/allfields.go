@@ -0,0 +1,170 @@
+package producer
+
+import (
+	"encoding/hex"
+	"fmt"
+	"math"
+	"net"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/netsampler/goflow2/decoders/netflow"
+)
+
+func decodeUNumberAny(b []byte) uint64 {
+	var o uint64
+	for _, c := range b {
+		o = o<<8 | uint64(c)
+	}
+	return o
+}
+
+func decodeNumberAny(b []byte) int64 {
+	if len(b) == 0 {
+		return 0
+	}
+	o := decodeUNumberAny(b)
+	shift := uint(64 - 8*len(b))
+	return int64(o<<shift) >> shift
+}
+
+// Returns the name of a field for the AllFields map.
+// NetFlow v9 names are used when known, then IPFIX names, then the numeric type.
+// Enterprise-specific fields are keyed with "<pen>.<type>", except for the
+// reverse elements of biflows (RFC 5103) which are prefixed with "reverse".
+func NetFlowFieldName(version uint16, df netflow.DataField) string {
+	if df.PenProvided {
+		if df.Pen == netflow.IPFIX_PEN_REVERSE {
+			if name := netflow.IPFIXTypeToString(df.Type); name != "" && name != "Unassigned" {
+				return "reverse" + strings.ToUpper(name[:1]) + name[1:]
+			}
+		}
+		return fmt.Sprintf("%d.%d", df.Pen, df.Type)
+	}
+	if version == 9 && df.Type > 0 && df.Type <= 104 {
+		if name := netflow.NFv9TypeToString(df.Type); name != "" {
+			return name
+		}
+	}
+	if df.Type < 105 || df.Type > 127 {
+		if name := netflow.IPFIXTypeToString(df.Type); name != "" && name != "Unassigned" {
+			return name
+		}
+	}
+	return strconv.Itoa(int(df.Type))
+}
+
+// Renders the value of a field according to its IPFIX abstract data type.
+// Enterprise-specific fields are rendered in hexadecimal, except for the
+// reverse elements of biflows which have the type of their forward element.
+func NetFlowFieldValue(df netflow.DataField) string {
+	v, ok := df.Value.([]byte)
+	if !ok {
+		return ""
+	}
+	if df.PenProvided && df.Pen != netflow.IPFIX_PEN_REVERSE {
+		return hex.EncodeToString(v)
+	}
+
+	dataType := netflow.IPFIXTypeToDataType(df.Type, len(v))
+	switch dataType {
+	case netflow.IPFIX_TYPE_UNSIGNED:
+		return strconv.FormatUint(decodeUNumberAny(v), 10)
+	case netflow.IPFIX_TYPE_SIGNED:
+		if len(v) > 8 {
+			break
+		}
+		return strconv.FormatInt(decodeNumberAny(v), 10)
+	case netflow.IPFIX_TYPE_FLOAT:
+		if len(v) == 4 {
+			return strconv.FormatFloat(float64(math.Float32frombits(uint32(decodeUNumberAny(v)))), 'g', -1, 32)
+		} else if len(v) == 8 {
+			return strconv.FormatFloat(math.Float64frombits(decodeUNumberAny(v)), 'g', -1, 64)
+		}
+	case netflow.IPFIX_TYPE_BOOLEAN:
+		if len(v) == 1 && v[0] == 1 {
+			return "true"
+		} else if len(v) == 1 && v[0] == 2 {
+			return "false"
+		}
+	case netflow.IPFIX_TYPE_MAC_ADDRESS:
+		if len(v) == 6 {
+			return net.HardwareAddr(v).String()
+		}
+	case netflow.IPFIX_TYPE_IPV4_ADDRESS:
+		if len(v) == 4 {
+			return net.IP(v).String()
+		}
+	case netflow.IPFIX_TYPE_IPV6_ADDRESS:
+		if len(v) == 16 {
+			return net.IP(v).String()
+		}
+	case netflow.IPFIX_TYPE_STRING:
+		return strings.ToValidUTF8(strings.TrimRight(string(v), "\x00"), "�")
+	case netflow.IPFIX_TYPE_DATETIME_SECONDS:
+		if len(v) <= 8 {
+			return time.Unix(int64(decodeUNumberAny(v)), 0).UTC().Format(time.RFC3339Nano)
+		}
+	case netflow.IPFIX_TYPE_DATETIME_MILLISECONDS:
+		if len(v) <= 8 {
+			ms := int64(decodeUNumberAny(v))
+			return time.Unix(ms/1000, (ms%1000)*1000000).UTC().Format(time.RFC3339Nano)
+		}
+	case netflow.IPFIX_TYPE_DATETIME_MICROSECONDS, netflow.IPFIX_TYPE_DATETIME_NANOSECONDS:
+		if len(v) == 8 {
+			ts := decodeUNumberAny(v)
+			if dataType == netflow.IPFIX_TYPE_DATETIME_MICROSECONDS {
+				ts &= 0xfffffffffffff800 // the last 11 bits are not significant (RFC 7011, section 6.1.9)
+			}
+			return ntpToTime(ts).UTC().Format(time.RFC3339Nano)
+		}
+	}
+	return hex.EncodeToString(v)
+}
+
+// Adds every field of a record to the AllFields map. A suffix is appended to keys repeated within a record.
+func MapAllFields(version uint16, record []netflow.DataField) map[string]string {
+	return mapNamedFields(record, func(df netflow.DataField) string {
+		return NetFlowFieldName(version, df)
+	}, NetFlowFieldValue)
+}
+
+// Returns the name of a NetFlow v9 scope field (System, Interface...) or its numeric type.
+func NetFlowV9ScopeName(df netflow.DataField) string {
+	if name := netflow.NFv9ScopeToString(df.Type); name != "Unassigned" {
+		return name
+	}
+	return strconv.Itoa(int(df.Type))
+}
+
+// Renders the value of a NetFlow v9 scope field in decimal or hexadecimal when larger than 8 bytes.
+func NetFlowV9ScopeValue(df netflow.DataField) string {
+	v, ok := df.Value.([]byte)
+	if !ok {
+		return ""
+	}
+	if len(v) > 8 {
+		return hex.EncodeToString(v)
+	}
+	return strconv.FormatUint(decodeUNumberAny(v), 10)
+}
+
+func mapNamedFields(record []netflow.DataField, nameFunc func(netflow.DataField) string, valueFunc func(netflow.DataField) string) map[string]string {
+	fields := make(map[string]string, len(record))
+	for _, df := range record {
+		if _, ok := df.Value.([]byte); !ok {
+			continue
+		}
+		name := nameFunc(df)
+		key := name
+		for i := 2; ; i++ {
+			if _, exists := fields[key]; !exists {
+				break
+			}
+			key = fmt.Sprintf("%s_%d", name, i)
+		}
+		fields[key] = valueFunc(df)
+	}
+	return fields
+}
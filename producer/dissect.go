@@ -0,0 +1,227 @@
+package producer
+
+import (
+	"bytes"
+	"encoding/binary"
+	"strings"
+)
+
+const (
+	PORT_DNS   = 53
+	PORT_HTTPS = 443
+
+	dnsMaxNameLength = 253
+	httpMaxLineCount = 32
+)
+
+var (
+	httpMethods = []string{"GET", "POST", "PUT", "HEAD", "DELETE", "OPTIONS", "PATCH", "CONNECT", "TRACE"}
+)
+
+// Runs the enabled dissectors on the payload following the transport header
+func (p *packetParser) parseApplication(offset int, nextHeader byte, srcPort, dstPort uint16) {
+	dissectors := p.config.Dissectors()
+	if offset >= len(p.data) {
+		return
+	}
+	payload := p.data[offset:]
+	flowMessage := p.flowMessage
+
+	if dissectors.DNS && (srcPort == PORT_DNS || dstPort == PORT_DNS) {
+		dnsPayload := payload
+		if nextHeader == PROTO_TCP {
+			// messages are prefixed by their length
+			if len(dnsPayload) < 2 {
+				return
+			}
+			dnsPayload = dnsPayload[2:]
+		}
+		if qname, ok := DissectDNSQueryName(dnsPayload); ok {
+			flowMessage.DnsQname = qname
+		}
+	}
+	if dissectors.TLS && nextHeader == PROTO_TCP {
+		if sni, ok := DissectTLSServerName(payload); ok {
+			flowMessage.TlsSni = sni
+		}
+	}
+	if dissectors.HTTP && nextHeader == PROTO_TCP {
+		if method, host, ok := DissectHTTPRequest(payload); ok {
+			flowMessage.HttpMethod = method
+			if flowMessage.HttpHost == "" {
+				flowMessage.HttpHost = host
+			}
+		}
+	}
+	if dissectors.QUIC && nextHeader == PROTO_UDP && (srcPort == PORT_HTTPS || dstPort == PORT_HTTPS) {
+		if version, ok := DissectQUICVersion(payload); ok {
+			flowMessage.QuicVersion = version
+		}
+	}
+}
+
+func isPrintableASCII(b []byte) bool {
+	for _, c := range b {
+		if c < 0x21 || c > 0x7e {
+			return false
+		}
+	}
+	return true
+}
+
+// Returns the name of the first question of a DNS message
+func DissectDNSQueryName(payload []byte) (string, bool) {
+	if len(payload) < 12 {
+		return "", false
+	}
+	opcode := (payload[2] >> 3) & 0xf
+	qdcount := binary.BigEndian.Uint16(payload[4:6])
+	if opcode != 0 || qdcount == 0 {
+		return "", false
+	}
+
+	var labels []string
+	length := 0
+	offset := 12
+	for {
+		if offset >= len(payload) {
+			return "", false
+		}
+		labelLength := int(payload[offset])
+		offset++
+		if labelLength == 0 {
+			break
+		}
+		// compression pointers are not expected in the question
+		if labelLength > 63 || offset+labelLength > len(payload) {
+			return "", false
+		}
+		label := payload[offset : offset+labelLength]
+		if !isPrintableASCII(label) {
+			return "", false
+		}
+		length += labelLength + 1
+		if length > dnsMaxNameLength+1 {
+			return "", false
+		}
+		labels = append(labels, string(label))
+		offset += labelLength
+	}
+	if len(labels) == 0 {
+		return ".", true
+	}
+	return strings.Join(labels, "."), true
+}
+
+// Returns the server name indication of a TLS ClientHello
+func DissectTLSServerName(payload []byte) (string, bool) {
+	// record: content type (handshake), version, length
+	if len(payload) < 5 || payload[0] != 22 || payload[1] != 3 {
+		return "", false
+	}
+	data := payload[5:]
+	if recordLength := int(binary.BigEndian.Uint16(payload[3:5])); recordLength < len(data) {
+		data = data[:recordLength]
+	}
+
+	// handshake: type (ClientHello), length, version, random
+	if len(data) < 4+2+32 || data[0] != 1 {
+		return "", false
+	}
+	offset := 4 + 2 + 32
+
+	// session id
+	if len(data) < offset+1 {
+		return "", false
+	}
+	offset += 1 + int(data[offset])
+	// cipher suites
+	if len(data) < offset+2 {
+		return "", false
+	}
+	offset += 2 + int(binary.BigEndian.Uint16(data[offset:offset+2]))
+	// compression methods
+	if len(data) < offset+1 {
+		return "", false
+	}
+	offset += 1 + int(data[offset])
+	// extensions
+	if len(data) < offset+2 {
+		return "", false
+	}
+	offset += 2
+
+	for len(data) >= offset+4 {
+		extensionType := binary.BigEndian.Uint16(data[offset : offset+2])
+		extensionLength := int(binary.BigEndian.Uint16(data[offset+2 : offset+4]))
+		offset += 4
+		if extensionType != 0 {
+			offset += extensionLength
+			continue
+		}
+
+		// server name list: list length, name type (host_name), name length, name
+		if len(data) < offset+5 || data[offset+2] != 0 {
+			return "", false
+		}
+		nameLength := int(binary.BigEndian.Uint16(data[offset+3 : offset+5]))
+		offset += 5
+		if nameLength == 0 || len(data) < offset+nameLength {
+			return "", false
+		}
+		name := data[offset : offset+nameLength]
+		if !isPrintableASCII(name) {
+			return "", false
+		}
+		return string(name), true
+	}
+	return "", false
+}
+
+// Returns the method and the Host header of an HTTP/1.x request.
+// The host is empty when the header is not within the payload.
+func DissectHTTPRequest(payload []byte) (string, string, bool) {
+	var method string
+	for _, m := range httpMethods {
+		if len(payload) > len(m) && string(payload[:len(m)]) == m && payload[len(m)] == ' ' {
+			method = m
+			break
+		}
+	}
+	if method == "" {
+		return "", "", false
+	}
+
+	var host string
+	lines := bytes.SplitN(payload, []byte("\n"), httpMaxLineCount)
+	// the last element is either empty or an incomplete line
+	for _, line := range lines[1 : len(lines)-1] {
+		line = bytes.TrimRight(line, "\r")
+		if len(line) == 0 {
+			break // end of the headers
+		}
+		sep := bytes.IndexByte(line, ':')
+		if sep < 0 || !strings.EqualFold(string(line[:sep]), "host") {
+			continue
+		}
+		value := bytes.TrimSpace(line[sep+1:])
+		if isPrintableASCII(value) {
+			host = string(value)
+		}
+		break
+	}
+	return method, host, true
+}
+
+// Returns the version of a QUIC long header packet (0 for version negotiation)
+func DissectQUICVersion(payload []byte) (uint32, bool) {
+	// header form and fixed bit
+	if len(payload) < 5 || payload[0]&0x80 == 0 {
+		return 0, false
+	}
+	version := binary.BigEndian.Uint32(payload[1:5])
+	if version != 0 && payload[0]&0x40 == 0 {
+		return 0, false
+	}
+	return version, true
+}
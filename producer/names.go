@@ -0,0 +1,164 @@
+package producer
+
+import (
+	"github.com/netsampler/goflow2/decoders/netflow"
+	flowmessage "github.com/netsampler/goflow2/pb"
+)
+
+// NetFlow v9 scope of the interface options (ifIndex)
+const NFV9_SCOPE_INTERFACE = 2
+
+// Names announced by an exporter in its options data records
+// (interface table, application table and VRF table)
+type NameTables struct {
+	Interfaces   map[uint32]string // ifIndex to interface name
+	Applications map[string]string // applicationId to application name
+	VRFs         map[uint32]string // VRF ID to VRF name
+}
+
+func NewNameTables() *NameTables {
+	return &NameTables{
+		Interfaces:   make(map[uint32]string),
+		Applications: make(map[string]string),
+		VRFs:         make(map[uint32]string),
+	}
+}
+
+func (t *NameTables) empty() bool {
+	return len(t.Interfaces) == 0 && len(t.Applications) == 0 && len(t.VRFs) == 0
+}
+
+// Adds the names of another table, replacing the existing entries
+func (t *NameTables) Merge(other *NameTables) {
+	for k, v := range other.Interfaces {
+		t.Interfaces[k] = v
+	}
+	for k, v := range other.Applications {
+		t.Applications[k] = v
+	}
+	for k, v := range other.VRFs {
+		t.VRFs[k] = v
+	}
+}
+
+// Sets the names of the interfaces, application and VRFs of a flow
+func (t *NameTables) Enrich(flowMessage *flowmessage.FlowMessage) {
+	if name, ok := t.Interfaces[flowMessage.InIf]; ok {
+		flowMessage.InIfName = name
+	}
+	if name, ok := t.Interfaces[flowMessage.OutIf]; ok {
+		flowMessage.OutIfName = name
+	}
+	if len(flowMessage.ApplicationId) > 0 {
+		if name, ok := t.Applications[string(flowMessage.ApplicationId)]; ok {
+			flowMessage.ApplicationName = name
+		}
+	}
+	if name, ok := t.VRFs[flowMessage.IngressVrfId]; ok {
+		flowMessage.IngressVrfName = name
+	}
+	if name, ok := t.VRFs[flowMessage.EgressVrfId]; ok {
+		flowMessage.EgressVrfName = name
+	}
+}
+
+// Adds the names found in an options data record
+func (t *NameTables) learn(version uint16, record netflow.OptionsDataRecord) {
+	var ifIndex, vrfId uint32
+	var hasIf, hasVrf bool
+	var ifName, ifDescr, appName, vrfName string
+	var appId []byte
+
+	fields := record.OptionsValues
+	if version == 9 {
+		// NetFlow v9 scopes have their own types
+		for _, df := range record.ScopesValues {
+			if v, ok := df.Value.([]byte); ok && df.Type == NFV9_SCOPE_INTERFACE {
+				hasIf = DecodeUNumber(v, &ifIndex) == nil
+			}
+		}
+	} else {
+		fields = append(append([]netflow.DataField{}, record.ScopesValues...), record.OptionsValues...)
+	}
+
+	for _, df := range fields {
+		v, ok := df.Value.([]byte)
+		if !ok || df.PenProvided {
+			continue
+		}
+		switch df.Type {
+		case netflow.IPFIX_FIELD_ingressInterface, netflow.IPFIX_FIELD_egressInterface:
+			hasIf = DecodeUNumber(v, &ifIndex) == nil
+		case netflow.IPFIX_FIELD_interfaceName:
+			ifName = decodeString(v)
+		case netflow.IPFIX_FIELD_interfaceDescription:
+			ifDescr = decodeString(v)
+		case netflow.IPFIX_FIELD_applicationId:
+			appId = v
+		case netflow.IPFIX_FIELD_applicationName:
+			appName = decodeString(v)
+		case netflow.IPFIX_FIELD_ingressVRFID, netflow.IPFIX_FIELD_egressVRFID:
+			hasVrf = DecodeUNumber(v, &vrfId) == nil
+		case netflow.IPFIX_FIELD_VRFname:
+			vrfName = decodeString(v)
+		}
+	}
+
+	if ifName == "" {
+		ifName = ifDescr
+	}
+	if hasIf && ifName != "" {
+		t.Interfaces[ifIndex] = ifName
+	}
+	if len(appId) > 0 && appName != "" {
+		t.Applications[string(appId)] = appName
+	}
+	if hasVrf && vrfName != "" {
+		t.VRFs[vrfId] = vrfName
+	}
+}
+
+// Returns the names found in the options data records, nil when there are none
+func SearchNetFlowOptionDataSetsNames(version uint16, dataFlowSet []netflow.OptionsDataFlowSet) *NameTables {
+	names := NewNameTables()
+	for _, dataFlowSetItem := range dataFlowSet {
+		for _, record := range dataFlowSetItem.Records {
+			names.learn(version, record)
+		}
+	}
+	if names.empty() {
+		return nil
+	}
+	return names
+}
+
+// Stores the names announced by the exporters to enrich their flows.
+// Implemented by the sampling rate system created with CreateSamplingSystem.
+type NameTableSystem interface {
+	AddNames(version uint16, obsDomainId uint32, names *NameTables)
+	EnrichNames(version uint16, obsDomainId uint32, flowMessages []*flowmessage.FlowMessage)
+}
+
+func (s *basicSamplingRateSystem) AddNames(version uint16, obsDomainId uint32, names *NameTables) {
+	s.samplinglock.Lock()
+	defer s.samplinglock.Unlock()
+	key := exporterKey{version, obsDomainId}
+	tables, ok := s.names[key]
+	if !ok {
+		tables = NewNameTables()
+		s.names[key] = tables
+	}
+	tables.Merge(names)
+}
+
+func (s *basicSamplingRateSystem) EnrichNames(version uint16, obsDomainId uint32, flowMessages []*flowmessage.FlowMessage) {
+	s.samplinglock.RLock()
+	defer s.samplinglock.RUnlock()
+	tables, ok := s.names[exporterKey{version, obsDomainId}]
+	if !ok {
+		return
+	}
+	for _, flowMessage := range flowMessages {
+		tables.Enrich(flowMessage)
+	}
+}
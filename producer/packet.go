@@ -0,0 +1,471 @@
+package producer
+
+import (
+	"encoding/binary"
+
+	flowmessage "github.com/netsampler/goflow2/pb"
+	"google.golang.org/protobuf/proto"
+)
+
+const (
+	ETYPE_IPV4   = 0x0800
+	ETYPE_ARP    = 0x0806
+	ETYPE_DOT1Q  = 0x8100
+	ETYPE_DOT1AD = 0x88a8
+	ETYPE_QINQ   = 0x9100 // legacy QinQ
+	ETYPE_IPV6   = 0x86dd
+	ETYPE_MPLS   = 0x8847
+	ETYPE_TEB    = 0x6558 // Transparent Ethernet Bridging (Ethernet over GRE/GENEVE)
+	PROTO_IPIP   = 4
+	PROTO_TCP    = 6
+	PROTO_UDP    = 17
+	PROTO_IPV6   = 41
+	PROTO_GRE    = 47
+	PROTO_ICMP   = 1
+	PROTO_ICMPV6 = 58
+	PORT_VXLAN   = 4789
+	PORT_GENEVE  = 6081
+
+	IPV6_EXT_HOP_BY_HOP  = 0
+	IPV6_EXT_ROUTING     = 43
+	IPV6_EXT_FRAGMENT    = 44
+	IPV6_EXT_AH          = 51
+	IPV6_EXT_DESTINATION = 60
+	IPV6_ROUTING_SRH     = 4 // Segment Routing Header (SRv6)
+	IPV6_MAX_EXTENSIONS  = 8
+)
+
+// State of the parsing of a sampled header
+type packetParser struct {
+	flowMessage *flowmessage.FlowMessage
+	data        []byte
+	config      *SFlowMapper
+	depth       int // current amount of decapsulated tunnels
+
+	mplsCount uint32
+}
+
+func ParseEthernetHeader(flowMessage *flowmessage.FlowMessage, data []byte, config *SFlowMapper) {
+	p := &packetParser{
+		flowMessage: flowMessage,
+		data:        data,
+		config:      config,
+	}
+	for _, configLayer := range GetSFlowConfigLayer(config, 0) {
+		extracted := GetBytes(data, configLayer.Offset, configLayer.Length)
+		MapCustom(flowMessage, extracted, configLayer.Destination, configLayer.Endian)
+	}
+	p.parseEthernet(0)
+}
+
+func (p *packetParser) mapCustomLayer(layer int, offset int) {
+	for _, configLayer := range GetSFlowConfigLayer(p.config, layer) {
+		extracted := GetBytes(p.data, offset*8+configLayer.Offset, configLayer.Length)
+		MapCustom(p.flowMessage, extracted, configLayer.Destination, configLayer.Endian)
+	}
+}
+
+// Returns false when the network header is truncated
+func (p *packetParser) parseEthernet(offset int) bool {
+	data := p.data
+	if len(data) < offset+14 {
+		return false
+	}
+	flowMessage := p.flowMessage
+
+	flowMessage.DstMac = binary.BigEndian.Uint64(append([]byte{0, 0}, data[offset:offset+6]...))
+	flowMessage.SrcMac = binary.BigEndian.Uint64(append([]byte{0, 0}, data[offset+6:offset+12]...))
+	etherType := binary.BigEndian.Uint16(data[offset+12 : offset+14])
+	offset += 14
+
+	etherType, offset = p.parseVlans(offset, etherType)
+
+	if etherType == ETYPE_MPLS {
+		etherType, offset = p.parseMPLS(offset)
+	}
+
+	return p.parseNetwork(offset, etherType)
+}
+
+func isVlanEtherType(etherType uint16) bool {
+	return etherType == ETYPE_DOT1Q || etherType == ETYPE_DOT1AD || etherType == ETYPE_QINQ
+}
+
+// Walks the VLAN tags (802.1Q, 802.1ad and QinQ) and returns the EtherType of the payload.
+// The first tag is reported as the outer VLAN and, when stacked, the last one as the inner VLAN.
+func (p *packetParser) parseVlans(offset int, etherType uint16) (uint16, int) {
+	data := p.data
+	flowMessage := p.flowMessage
+
+	tags := 0
+	for isVlanEtherType(etherType) && len(data) >= offset+4 {
+		tci := binary.BigEndian.Uint16(data[offset : offset+2])
+		vlanId := uint32(tci & 0xfff)
+		pcp := uint32(tci >> 13)
+		etherType = binary.BigEndian.Uint16(data[offset+2 : offset+4])
+		offset += 4
+
+		if tags == 0 {
+			flowMessage.OuterVlanId = vlanId
+			flowMessage.OuterVlanPcp = pcp
+		} else {
+			flowMessage.InnerVlanId = vlanId
+			flowMessage.InnerVlanPcp = pcp
+		}
+		flowMessage.VlanId = vlanId
+		tags++
+	}
+	return etherType, offset
+}
+
+func (p *packetParser) parseMPLS(offset int) (uint16, int) {
+	data := p.data
+	flowMessage := p.flowMessage
+	etherType := uint16(ETYPE_MPLS)
+
+	flowMessage.HasMpls = true
+	for len(data) >= offset+4 {
+		label := binary.BigEndian.Uint32(append([]byte{0}, data[offset:offset+3]...)) >> 4
+		bottom := data[offset+2] & 1
+		mplsTtl := uint32(data[offset+3])
+		offset += 4
+
+		switch p.mplsCount {
+		case 0:
+			flowMessage.Mpls_1Label = label
+			flowMessage.Mpls_1Ttl = mplsTtl
+		case 1:
+			flowMessage.Mpls_2Label = label
+			flowMessage.Mpls_2Ttl = mplsTtl
+		case 2:
+			flowMessage.Mpls_3Label = label
+			flowMessage.Mpls_3Ttl = mplsTtl
+		default:
+			flowMessage.MplsLastLabel = label
+			flowMessage.MplsLastTtl = mplsTtl
+		}
+		p.mplsCount++
+		flowMessage.MplsCount = p.mplsCount
+
+		if bottom == 1 || label <= 15 {
+			if len(data) > offset {
+				// guess the payload from the IP version
+				if data[offset]&0xf0>>4 == 4 {
+					etherType = ETYPE_IPV4
+				} else if data[offset]&0xf0>>4 == 6 {
+					etherType = ETYPE_IPV6
+				}
+			}
+			break
+		}
+	}
+	return etherType, offset
+}
+
+// Returns false when the header is truncated or not IP: the fields of an encapsulated header are not set
+func (p *packetParser) parseNetwork(offset int, etherType uint16) bool {
+	data := p.data
+	flowMessage := p.flowMessage
+
+	var nextHeader byte
+	var srcIP, dstIP []byte
+	var tos, ttl byte
+	var identification uint32
+	var fragOffset uint16
+	var nonFirstFragment bool
+	var flowLabel uint32
+	parsed := true
+
+	p.mapCustomLayer(3, offset)
+
+	switch etherType {
+	case ETYPE_IPV4:
+		if len(data) >= offset+20 {
+			nextHeader = data[offset+9]
+			srcIP = data[offset+12 : offset+16]
+			dstIP = data[offset+16 : offset+20]
+			tos = data[offset+1]
+			ttl = data[offset+8]
+
+			identification = uint32(binary.BigEndian.Uint16(data[offset+4 : offset+6]))
+			fragOffset = binary.BigEndian.Uint16(data[offset+6 : offset+8])
+			nonFirstFragment = fragOffset&0x1fff != 0
+
+			// skip the options
+			ihl := int(data[offset]&0xf) * 4
+			if ihl < 20 {
+				ihl = 20
+			}
+			offset += ihl
+		} else {
+			parsed = false
+		}
+	case ETYPE_IPV6:
+		if len(data) >= offset+40 {
+			nextHeader = data[offset+6]
+			srcIP = data[offset+8 : offset+24]
+			dstIP = data[offset+24 : offset+40]
+
+			tostmp := uint32(binary.BigEndian.Uint16(data[offset : offset+2]))
+			tos = uint8(tostmp & 0x0ff0 >> 4)
+			ttl = data[offset+7]
+
+			flowLabel = binary.BigEndian.Uint32(data[offset : offset+4])
+
+			offset += 40
+			nextHeader, offset, identification, fragOffset, nonFirstFragment = p.parseIPv6Extensions(offset, nextHeader)
+		} else {
+			parsed = false
+		}
+	default: // ARP...
+		parsed = false
+	}
+
+	if !parsed && p.depth > 0 {
+		return false
+	}
+	flowMessage.Etype = uint32(etherType)
+	flowMessage.SrcAddr = srcIP
+	flowMessage.DstAddr = dstIP
+	flowMessage.Proto = uint32(nextHeader)
+	flowMessage.IpTos = uint32(tos)
+	flowMessage.IpTtl = uint32(ttl)
+	flowMessage.FragmentId = identification
+	flowMessage.FragmentOffset = uint32(fragOffset)
+	flowMessage.Ipv6FlowLabel = flowLabel & 0xFFFFF
+
+	if nonFirstFragment {
+		// the transport header is only in the first fragment
+		flowMessage.SrcPort = 0
+		flowMessage.DstPort = 0
+		flowMessage.TcpFlags = 0
+		flowMessage.IcmpType = 0
+		flowMessage.IcmpCode = 0
+		return parsed
+	}
+	p.parseTransport(offset, nextHeader)
+	return parsed
+}
+
+// Walks the IPv6 extension headers and returns the upper-layer protocol with its offset.
+// The fragment identification and offset are returned when a fragment header is found.
+func (p *packetParser) parseIPv6Extensions(offset int, nextHeader byte) (byte, int, uint32, uint16, bool) {
+	data := p.data
+	flowMessage := p.flowMessage
+
+	var identification uint32
+	var fragOffset uint16
+	var nonFirstFragment bool
+
+	for i := 0; i < IPV6_MAX_EXTENSIONS && len(data) >= offset+8; i++ {
+		var length int
+		switch nextHeader {
+		case IPV6_EXT_HOP_BY_HOP, IPV6_EXT_DESTINATION:
+			length = (int(data[offset+1]) + 1) * 8
+		case IPV6_EXT_ROUTING:
+			length = (int(data[offset+1]) + 1) * 8
+			if data[offset+2] == IPV6_ROUTING_SRH && len(data) >= offset+length {
+				lastEntry := int(data[offset+4])
+				flowMessage.Srv6SegmentsLeft = uint32(data[offset+3])
+				flowMessage.Srv6Segments = nil
+				for j := 0; j <= lastEntry && offset+8+(j+1)*16 <= offset+length; j++ {
+					segmentOffset := offset + 8 + j*16
+					flowMessage.Srv6Segments = append(flowMessage.Srv6Segments, data[segmentOffset:segmentOffset+16])
+				}
+			}
+		case IPV6_EXT_FRAGMENT:
+			length = 8
+			fragOffset = binary.BigEndian.Uint16(data[offset+2:offset+4]) >> 3
+			identification = binary.BigEndian.Uint32(data[offset+4 : offset+8])
+			nonFirstFragment = fragOffset != 0
+		case IPV6_EXT_AH:
+			length = (int(data[offset+1]) + 2) * 4
+		default:
+			return nextHeader, offset, identification, fragOffset, nonFirstFragment
+		}
+		nextHeader = data[offset]
+		offset += length
+	}
+	return nextHeader, offset, identification, fragOffset, nonFirstFragment
+}
+
+func (p *packetParser) parseTransport(offset int, nextHeader byte) {
+	data := p.data
+	flowMessage := p.flowMessage
+
+	var srcPort, dstPort uint16
+	var tcpflags byte
+	var icmpType, icmpCode byte
+
+	p.mapCustomLayer(4, offset)
+
+	appOffset := 0
+	if len(data) >= offset+4 && (nextHeader == PROTO_UDP || nextHeader == PROTO_TCP) {
+		srcPort = binary.BigEndian.Uint16(data[offset+0 : offset+2])
+		dstPort = binary.BigEndian.Uint16(data[offset+2 : offset+4])
+	}
+
+	if nextHeader == PROTO_UDP {
+		appOffset = 8
+	}
+
+	if len(data) > offset+13 && nextHeader == PROTO_TCP {
+		tcpflags = data[offset+13]
+
+		appOffset = int(data[offset+12]>>4) * 4
+	}
+
+	// ICMP and ICMPv6
+	if len(data) >= offset+2 && (nextHeader == PROTO_ICMP || nextHeader == PROTO_ICMPV6) {
+		icmpType = data[offset+0]
+		icmpCode = data[offset+1]
+	}
+
+	flowMessage.SrcPort = uint32(srcPort)
+	flowMessage.DstPort = uint32(dstPort)
+	flowMessage.TcpFlags = uint32(tcpflags)
+	flowMessage.IcmpType = uint32(icmpType)
+	flowMessage.IcmpCode = uint32(icmpCode)
+
+	if appOffset > 0 {
+		p.mapCustomLayer(7, offset+appOffset)
+		p.parseApplication(offset+appOffset, nextHeader, srcPort, dstPort)
+	}
+
+	if p.depth < p.config.DecapDepth() {
+		p.parseTunnel(offset, nextHeader, dstPort)
+	}
+}
+
+// Fields of a message before decoding an encapsulated packet
+type tunnelState struct {
+	flowMessage *flowmessage.FlowMessage
+	depth       int
+	mplsCount   uint32
+}
+
+// Saves the outermost headers before decoding the encapsulated packet.
+// Returns the state restored by leaveTunnel when the encapsulated packet is truncated.
+func (p *packetParser) enterTunnel(tunnelType flowmessage.FlowMessage_TunnelType) tunnelState {
+	flowMessage := p.flowMessage
+	state := tunnelState{
+		flowMessage: proto.Clone(flowMessage).(*flowmessage.FlowMessage),
+		depth:       p.depth,
+		mplsCount:   p.mplsCount,
+	}
+	if p.depth == 0 {
+		flowMessage.TunnelType = tunnelType
+		flowMessage.TunnelSrcAddr = flowMessage.SrcAddr
+		flowMessage.TunnelDstAddr = flowMessage.DstAddr
+		flowMessage.TunnelProto = flowMessage.Proto
+		flowMessage.TunnelSrcPort = flowMessage.SrcPort
+		flowMessage.TunnelDstPort = flowMessage.DstPort
+	}
+	p.depth++
+	flowMessage.TunnelDepth = uint32(p.depth)
+	return state
+}
+
+// Keeps the headers of the encapsulated packet only once its network header is parsed
+func (p *packetParser) leaveTunnel(state tunnelState, parsed bool) {
+	if parsed {
+		return
+	}
+	proto.Reset(p.flowMessage)
+	proto.Merge(p.flowMessage, state.flowMessage)
+	p.depth = state.depth
+	p.mplsCount = state.mplsCount
+}
+
+func (p *packetParser) parseTunnel(offset int, nextHeader byte, dstPort uint16) {
+	data := p.data
+	flowMessage := p.flowMessage
+
+	switch {
+	case nextHeader == PROTO_UDP && dstPort == PORT_VXLAN:
+		// UDP header, flags (I bit set for a valid VNI), reserved, VNI, reserved
+		offset += 8
+		if len(data) < offset+8+14 || data[offset]&0x08 == 0 {
+			return
+		}
+		state := p.enterTunnel(flowmessage.FlowMessage_TUNNEL_VXLAN)
+		flowMessage.TunnelVni = binary.BigEndian.Uint32(data[offset+4:offset+8]) >> 8
+		p.leaveTunnel(state, p.parseEthernet(offset+8))
+
+	case nextHeader == PROTO_UDP && dstPort == PORT_GENEVE:
+		// UDP header, version and options length, flags, protocol type, VNI, reserved, options
+		offset += 8
+		if len(data) < offset+8 || data[offset]>>6 != 0 {
+			return
+		}
+		optionsLength := int(data[offset]&0x3f) * 4
+		protocolType := binary.BigEndian.Uint16(data[offset+2 : offset+4])
+		vni := binary.BigEndian.Uint32(data[offset+4:offset+8]) >> 8
+		offset += 8 + optionsLength
+		if len(data) <= offset {
+			return
+		}
+		state := p.enterTunnel(flowmessage.FlowMessage_TUNNEL_GENEVE)
+		flowMessage.TunnelVni = vni
+		p.leaveTunnel(state, p.parseEncapsulated(offset, protocolType))
+
+	case nextHeader == PROTO_GRE:
+		if len(data) < offset+4 {
+			return
+		}
+		flags := binary.BigEndian.Uint16(data[offset : offset+2])
+		protocolType := binary.BigEndian.Uint16(data[offset+2 : offset+4])
+		if flags&0x7 != 0 { // only version 0
+			return
+		}
+		offset += 4
+		if flags&0x8000 != 0 { // checksum
+			offset += 4
+		}
+		var key uint32
+		hasKey := flags&0x2000 != 0
+		if hasKey {
+			if len(data) < offset+4 {
+				return
+			}
+			key = binary.BigEndian.Uint32(data[offset : offset+4])
+			offset += 4
+		}
+		if flags&0x1000 != 0 { // sequence number
+			offset += 4
+		}
+		if len(data) <= offset {
+			return
+		}
+		var state tunnelState
+		if protocolType == ETYPE_TEB && hasKey {
+			// NVGRE: the key carries the Virtual Subnet ID and a flow ID
+			state = p.enterTunnel(flowmessage.FlowMessage_TUNNEL_NVGRE)
+			flowMessage.TunnelVni = key >> 8
+		} else {
+			state = p.enterTunnel(flowmessage.FlowMessage_TUNNEL_GRE)
+		}
+		flowMessage.TunnelGreKey = key
+		p.leaveTunnel(state, p.parseEncapsulated(offset, protocolType))
+
+	case nextHeader == PROTO_IPIP:
+		state := p.enterTunnel(flowmessage.FlowMessage_TUNNEL_IP_IN_IP)
+		p.leaveTunnel(state, p.parseNetwork(offset, ETYPE_IPV4))
+
+	case nextHeader == PROTO_IPV6:
+		state := p.enterTunnel(flowmessage.FlowMessage_TUNNEL_IPV6_IN_IP)
+		p.leaveTunnel(state, p.parseNetwork(offset, ETYPE_IPV6))
+	}
+}
+
+func (p *packetParser) parseEncapsulated(offset int, protocolType uint16) bool {
+	switch protocolType {
+	case ETYPE_TEB:
+		return p.parseEthernet(offset)
+	case ETYPE_MPLS:
+		etherType, offset := p.parseMPLS(offset)
+		return p.parseNetwork(offset, etherType)
+	default:
+		return p.parseNetwork(offset, protocolType)
+	}
+}
@@ -6,11 +6,13 @@ import (
 	"errors"
 	"fmt"
 	"net"
+	"strings"
 	"sync"
 	"time"
 
 	"github.com/netsampler/goflow2/decoders/netflow"
 	flowmessage "github.com/netsampler/goflow2/pb"
+	"google.golang.org/protobuf/proto"
 )
 
 type SamplingRateSystem interface {
@@ -18,14 +20,23 @@ type SamplingRateSystem interface {
 	AddSamplingRate(version uint16, obsDomainId uint32, samplingRate uint32)
 }
 
+type exporterKey struct {
+	version     uint16
+	obsDomainId uint32
+}
+
 type basicSamplingRateSystem struct {
 	sampling     map[uint16]map[uint32]uint32
+	systemInit   map[exporterKey]uint64
+	names        map[exporterKey]*NameTables
 	samplinglock *sync.RWMutex
 }
 
 func CreateSamplingSystem() SamplingRateSystem {
 	ts := &basicSamplingRateSystem{
 		sampling:     make(map[uint16]map[uint32]uint32),
+		systemInit:   make(map[exporterKey]uint64),
+		names:        make(map[exporterKey]*NameTables),
 		samplinglock: &sync.RWMutex{},
 	}
 	return ts
@@ -55,6 +66,26 @@ func (s *basicSamplingRateSystem) GetSamplingRate(version uint16, obsDomainId ui
 	return 0, errors.New("") // TBC
 }
 
+// Stores the initialization time of the exporters, announced in IPFIX options data records.
+// Implemented by the sampling rate system created with CreateSamplingSystem.
+type SystemInitTimeSystem interface {
+	GetSystemInitTime(version uint16, obsDomainId uint32) (uint64, bool)
+	AddSystemInitTime(version uint16, obsDomainId uint32, systemInitMs uint64)
+}
+
+func (s *basicSamplingRateSystem) AddSystemInitTime(version uint16, obsDomainId uint32, systemInitMs uint64) {
+	s.samplinglock.Lock()
+	defer s.samplinglock.Unlock()
+	s.systemInit[exporterKey{version, obsDomainId}] = systemInitMs
+}
+
+func (s *basicSamplingRateSystem) GetSystemInitTime(version uint16, obsDomainId uint32) (uint64, bool) {
+	s.samplinglock.RLock()
+	defer s.samplinglock.RUnlock()
+	systemInitMs, ok := s.systemInit[exporterKey{version, obsDomainId}]
+	return systemInitMs, ok
+}
+
 type SingleSamplingRateSystem struct {
 	Sampling uint32
 }
@@ -256,9 +287,13 @@ func addrReplaceCheck(dstAddr *[]byte, v []byte, eType *uint32, ipv6 bool) {
 	}
 }
 
-func ConvertNetFlowDataSet(version uint16, baseTime uint32, uptime uint32, record []netflow.DataField, mapperNetFlow *NetFlowMapper, mapperSFlow *SFlowMapper) *flowmessage.FlowMessage {
+// Converts a NetFlow v9/IPFIX data record. The initialization time of the exporter, in milliseconds
+// since the Unix epoch (0 when unknown), resolves the IPFIX flowStartSysUpTime and flowEndSysUpTime fields.
+func ConvertNetFlowDataSet(version uint16, baseTime uint32, uptime uint32, systemInitMs uint64, record []netflow.DataField, mapperNetFlow *NetFlowMapper, mapperSFlow *SFlowMapper) *flowmessage.FlowMessage {
 	flowMessage := &flowmessage.FlowMessage{}
-	var time uint64
+	exportTimeMs := uint64(baseTime) * 1000
+	var times, reverseTimes netFlowTimes
+	var biFlow bool
 
 	if version == 9 {
 		flowMessage.Type = flowmessage.FlowMessage_NETFLOW_V9
@@ -266,6 +301,10 @@ func ConvertNetFlowDataSet(version uint16, baseTime uint32, uptime uint32, recor
 		flowMessage.Type = flowmessage.FlowMessage_IPFIX
 	}
 
+	if mapperNetFlow.AllFields() {
+		flowMessage.AllFields = MapAllFields(version, record)
+	}
+
 	for i := range record {
 		df := record[i]
 
@@ -277,6 +316,12 @@ func ConvertNetFlowDataSet(version uint16, baseTime uint32, uptime uint32, recor
 		MapCustomNetFlow(flowMessage, df, mapperNetFlow)
 
 		if df.PenProvided {
+			if df.Pen == netflow.IPFIX_PEN_REVERSE {
+				biFlow = true
+				if !reverseTimes.decode(df.Type, v, exportTimeMs) {
+					mapReverseField(flowMessage, df.Type, v)
+				}
+			}
 			continue
 		}
 
@@ -407,6 +452,9 @@ func ConvertNetFlowDataSet(version uint16, baseTime uint32, uptime uint32, recor
 		case netflow.IPFIX_FIELD_egressVRFID:
 			DecodeUNumber(v, &(flowMessage.EgressVrfId))
 
+		case netflow.NFV9_FIELD_APPLICATION_TAG:
+			flowMessage.ApplicationId = v
+
 		case netflow.NFV9_FIELD_IPV4_IDENT:
 			DecodeUNumber(v, &(flowMessage.FragmentId))
 		case netflow.NFV9_FIELD_FRAGMENT_OFFSET:
@@ -445,65 +493,51 @@ func ConvertNetFlowDataSet(version uint16, baseTime uint32, uptime uint32, recor
 		case netflow.IPFIX_FIELD_mplsTopLabelIPv6Address:
 			flowMessage.MplsLabelIp = v
 
+		// NAT and firewall events
+		case netflow.IPFIX_FIELD_postNATSourceIPv4Address, netflow.IPFIX_FIELD_postNATSourceIPv6Address, netflow.NFV9_FIELD_NSEL_XLATE_SRC_ADDR_84:
+			flowMessage.PostNatSrcAddr = v
+		case netflow.IPFIX_FIELD_postNATDestinationIPv4Address, netflow.IPFIX_FIELD_postNATDestinationIPv6Address, netflow.NFV9_FIELD_NSEL_XLATE_DST_ADDR_84:
+			flowMessage.PostNatDstAddr = v
+		case netflow.IPFIX_FIELD_postNAPTSourceTransportPort, netflow.NFV9_FIELD_NSEL_XLATE_SRC_PORT_84:
+			DecodeUNumber(v, &(flowMessage.PostNatSrcPort))
+		case netflow.IPFIX_FIELD_postNAPTDestinationTransportPort, netflow.NFV9_FIELD_NSEL_XLATE_DST_PORT_84:
+			DecodeUNumber(v, &(flowMessage.PostNatDstPort))
+		case netflow.IPFIX_FIELD_natEvent:
+			DecodeUNumber(v, &(flowMessage.NatEvent))
+		case netflow.IPFIX_FIELD_natPoolId:
+			DecodeUNumber(v, &(flowMessage.NatPoolId))
+		case netflow.IPFIX_FIELD_natPoolName:
+			flowMessage.NatPoolName = decodeString(v)
+		case netflow.IPFIX_FIELD_firewallEvent, netflow.NFV9_FIELD_NSEL_FW_EVENT_84:
+			DecodeUNumber(v, &(flowMessage.FirewallEvent))
+		case netflow.NFV9_FIELD_NSEL_FW_EXT_EVENT:
+			DecodeUNumber(v, &(flowMessage.FirewallExtEvent))
+		case netflow.IPFIX_FIELD_flowId:
+			DecodeUNumber(v, &(flowMessage.ConnId))
+		case netflow.NFV9_FIELD_NSEL_INGRESS_ACL_ID:
+			decodeACL(v, &(flowMessage.IngressAclId), &(flowMessage.IngressAceId))
+		case netflow.NFV9_FIELD_NSEL_EGRESS_ACL_ID:
+			decodeACL(v, &(flowMessage.EgressAclId), &(flowMessage.EgressAceId))
+		case netflow.NFV9_FIELD_NSEL_USERNAME:
+			flowMessage.UserName = decodeString(v)
+		// counters of both directions of a connection (NSEL)
+		case netflow.IPFIX_FIELD_initiatorOctets:
+			DecodeUNumber(v, &(flowMessage.Bytes))
+		case netflow.IPFIX_FIELD_initiatorPackets:
+			DecodeUNumber(v, &(flowMessage.Packets))
+		case netflow.IPFIX_FIELD_responderOctets:
+			biFlow = true
+			DecodeUNumber(v, &(flowMessage.ReverseBytes))
+		case netflow.IPFIX_FIELD_responderPackets:
+			biFlow = true
+			DecodeUNumber(v, &(flowMessage.ReversePackets))
+
 		default:
-			if version == 9 {
-				// NetFlow v9 time works with a differential based on router's uptime
-				switch df.Type {
-				case netflow.NFV9_FIELD_FIRST_SWITCHED:
-					var timeFirstSwitched uint32
-					DecodeUNumber(v, &timeFirstSwitched)
-					timeDiff := (uptime - timeFirstSwitched)
-					flowMessage.TimeFlowStart = uint64(baseTime - timeDiff/1000)
-					flowMessage.TimeFlowStartMs = uint64(baseTime)*1000 - uint64(timeDiff)
-				case netflow.NFV9_FIELD_LAST_SWITCHED:
-					var timeLastSwitched uint32
-					DecodeUNumber(v, &timeLastSwitched)
-					timeDiff := (uptime - timeLastSwitched)
-					flowMessage.TimeFlowEnd = uint64(baseTime - timeDiff/1000)
-					flowMessage.TimeFlowEndMs = uint64(baseTime)*1000 - uint64(timeDiff)
-				}
-			} else if version == 10 {
+			if times.decode(df.Type, v, exportTimeMs) {
+				continue
+			}
+			if version == 10 {
 				switch df.Type {
-				case netflow.IPFIX_FIELD_flowStartSeconds:
-					DecodeUNumber(v, &time)
-					flowMessage.TimeFlowStart = time
-					flowMessage.TimeFlowStartMs = time * 1000
-				case netflow.IPFIX_FIELD_flowStartMilliseconds:
-					DecodeUNumber(v, &time)
-					flowMessage.TimeFlowStart = time / 1000
-					flowMessage.TimeFlowStartMs = time
-				case netflow.IPFIX_FIELD_flowStartMicroseconds:
-					DecodeUNumber(v, &time)
-					flowMessage.TimeFlowStart = time / 1000000
-					flowMessage.TimeFlowStartMs = time / 1000
-				case netflow.IPFIX_FIELD_flowStartNanoseconds:
-					DecodeUNumber(v, &time)
-					flowMessage.TimeFlowStart = time / 1000000000
-					flowMessage.TimeFlowStartMs = time / 1000000
-				case netflow.IPFIX_FIELD_flowEndSeconds:
-					DecodeUNumber(v, &time)
-					flowMessage.TimeFlowEnd = time
-					flowMessage.TimeFlowEndMs = time * 1000
-				case netflow.IPFIX_FIELD_flowEndMilliseconds:
-					DecodeUNumber(v, &time)
-					flowMessage.TimeFlowEnd = time / 1000
-					flowMessage.TimeFlowEndMs = time
-				case netflow.IPFIX_FIELD_flowEndMicroseconds:
-					DecodeUNumber(v, &time)
-					flowMessage.TimeFlowEnd = time / 1000000
-					flowMessage.TimeFlowEndMs = time / 1000
-				case netflow.IPFIX_FIELD_flowEndNanoseconds:
-					DecodeUNumber(v, &time)
-					flowMessage.TimeFlowEnd = time / 1000000000
-					flowMessage.TimeFlowEndMs = time / 1000000
-				case netflow.IPFIX_FIELD_flowStartDeltaMicroseconds:
-					DecodeUNumber(v, &time)
-					flowMessage.TimeFlowStart = uint64(baseTime) - time/1000000
-					flowMessage.TimeFlowStartMs = uint64(baseTime)*1000 - time/1000
-				case netflow.IPFIX_FIELD_flowEndDeltaMicroseconds:
-					DecodeUNumber(v, &time)
-					flowMessage.TimeFlowEnd = uint64(baseTime) - time/1000000
-					flowMessage.TimeFlowEndMs = uint64(baseTime)*1000 - time/1000
 				// RFC7133
 				case netflow.IPFIX_FIELD_dataLinkFrameSize:
 					DecodeUNumber(v, &(flowMessage.Bytes))
@@ -519,25 +553,137 @@ func ConvertNetFlowDataSet(version uint16, baseTime uint32, uptime uint32, recor
 		}
 
 	}
+	times.resolve(flowMessage, version, exportTimeMs, uptime, systemInitMs)
+
+	if biFlow {
+		if times.hasSystemInit && !reverseTimes.hasSystemInit {
+			systemInitMs = times.systemInitMs
+		}
+		reverseTimes.resolveReverse(flowMessage, version, exportTimeMs, uptime, systemInitMs)
+		if flowMessage.BiFlowDirection == BIFLOW_DIRECTION_REVERSE_INITIATOR {
+			// the source of the record is the responder
+			SwapBiFlow(flowMessage)
+			flowMessage.BiFlowDirection = BIFLOW_DIRECTION_INITIATOR
+		}
+	}
 
 	return flowMessage
 }
 
-func SearchNetFlowDataSetsRecords(version uint16, baseTime uint32, uptime uint32, dataRecords []netflow.DataRecord, mapperNetFlow *NetFlowMapper, mapperSFlow *SFlowMapper) []*flowmessage.FlowMessage {
+// Decodes a string padded with null bytes
+func decodeString(v []byte) string {
+	return strings.TrimRight(string(v), "\x00")
+}
+
+// Decodes a NSEL ACL field: ACL ID, ACE ID and extended ACE ID (4 bytes each)
+func decodeACL(v []byte, aclId *uint32, aceId *uint32) {
+	if len(v) >= 8 {
+		DecodeUNumber(v[0:4], aclId)
+		DecodeUNumber(v[4:8], aceId)
+	} else {
+		DecodeUNumber(v, aclId)
+	}
+}
+
+// Values of biflowDirection (RFC 5103)
+const (
+	BIFLOW_DIRECTION_ARBITRARY         = 0
+	BIFLOW_DIRECTION_INITIATOR         = 1 // the source is the initiator of the connection
+	BIFLOW_DIRECTION_REVERSE_INITIATOR = 2 // the destination is the initiator of the connection
+	BIFLOW_DIRECTION_PERIMETER         = 3 // the source is inside the observed network
+)
+
+// Decodes the reverse Information Elements of a bidirectional flow (RFC 5103), except the times
+func mapReverseField(flowMessage *flowmessage.FlowMessage, typeId uint16, v []byte) {
+	switch typeId {
+	case netflow.IPFIX_FIELD_octetDeltaCount, netflow.IPFIX_FIELD_postOctetDeltaCount:
+		DecodeUNumber(v, &(flowMessage.ReverseBytes))
+	case netflow.IPFIX_FIELD_packetDeltaCount, netflow.IPFIX_FIELD_postPacketDeltaCount:
+		DecodeUNumber(v, &(flowMessage.ReversePackets))
+	case netflow.IPFIX_FIELD_tcpControlBits:
+		DecodeUNumber(v, &(flowMessage.ReverseTcpFlags))
+	case netflow.IPFIX_FIELD_ipClassOfService:
+		DecodeUNumber(v, &(flowMessage.ReverseIpTos))
+	case netflow.IPFIX_FIELD_minimumTTL:
+		DecodeUNumber(v, &(flowMessage.ReverseIpTtl))
+	}
+}
+
+// Swaps the directions of a bidirectional flow: the endpoints and the forward and reverse fields.
+// The times of the record are kept when the times of the reverse direction are unknown.
+// The routing information only applies to the forward direction and is removed.
+func SwapBiFlow(flowMessage *flowmessage.FlowMessage) {
+	flowMessage.SrcAddr, flowMessage.DstAddr = flowMessage.DstAddr, flowMessage.SrcAddr
+	flowMessage.SrcPort, flowMessage.DstPort = flowMessage.DstPort, flowMessage.SrcPort
+	flowMessage.SrcAs, flowMessage.DstAs = flowMessage.DstAs, flowMessage.SrcAs
+	flowMessage.SrcNet, flowMessage.DstNet = flowMessage.DstNet, flowMessage.SrcNet
+	flowMessage.SrcMac, flowMessage.DstMac = flowMessage.DstMac, flowMessage.SrcMac
+	flowMessage.SrcVlan, flowMessage.DstVlan = flowMessage.DstVlan, flowMessage.SrcVlan
+	flowMessage.InIf, flowMessage.OutIf = flowMessage.OutIf, flowMessage.InIf
+	flowMessage.IngressVrfId, flowMessage.EgressVrfId = flowMessage.EgressVrfId, flowMessage.IngressVrfId
+	flowMessage.PostNatSrcAddr, flowMessage.PostNatDstAddr = flowMessage.PostNatDstAddr, flowMessage.PostNatSrcAddr
+	flowMessage.PostNatSrcPort, flowMessage.PostNatDstPort = flowMessage.PostNatDstPort, flowMessage.PostNatSrcPort
+	flowMessage.NextHop = nil
+	flowMessage.BgpNextHop = nil
+
+	flowMessage.Bytes, flowMessage.ReverseBytes = flowMessage.ReverseBytes, flowMessage.Bytes
+	flowMessage.Packets, flowMessage.ReversePackets = flowMessage.ReversePackets, flowMessage.Packets
+	flowMessage.TcpFlags, flowMessage.ReverseTcpFlags = flowMessage.ReverseTcpFlags, flowMessage.TcpFlags
+	flowMessage.IpTos, flowMessage.ReverseIpTos = flowMessage.ReverseIpTos, flowMessage.IpTos
+	flowMessage.IpTtl, flowMessage.ReverseIpTtl = flowMessage.ReverseIpTtl, flowMessage.IpTtl
+
+	startMs, endMs := flowMessage.TimeFlowStartMs, flowMessage.TimeFlowEndMs
+	if flowMessage.ReverseTimeFlowStartMs != 0 {
+		setFlowStartMs(flowMessage, flowMessage.ReverseTimeFlowStartMs)
+	}
+	if flowMessage.ReverseTimeFlowEndMs != 0 {
+		setFlowEndMs(flowMessage, flowMessage.ReverseTimeFlowEndMs)
+	}
+	flowMessage.ReverseTimeFlowStartMs, flowMessage.ReverseTimeFlowEndMs = startMs, endMs
+}
+
+// Returns the reverse direction of a bidirectional flow as a unidirectional message and
+// removes the reverse fields from the original message.
+// Returns nil when no packet was observed in the reverse direction.
+func SplitBiFlow(flowMessage *flowmessage.FlowMessage) *flowmessage.FlowMessage {
+	if flowMessage.ReverseBytes == 0 && flowMessage.ReversePackets == 0 {
+		return nil
+	}
+	reverse := proto.Clone(flowMessage).(*flowmessage.FlowMessage)
+	SwapBiFlow(reverse)
+
+	for _, msg := range []*flowmessage.FlowMessage{flowMessage, reverse} {
+		msg.ReverseBytes = 0
+		msg.ReversePackets = 0
+		msg.ReverseTcpFlags = 0
+		msg.ReverseIpTos = 0
+		msg.ReverseIpTtl = 0
+		msg.ReverseTimeFlowStartMs = 0
+		msg.ReverseTimeFlowEndMs = 0
+	}
+	return reverse
+}
+
+func SearchNetFlowDataSetsRecords(version uint16, baseTime uint32, uptime uint32, systemInitMs uint64, dataRecords []netflow.DataRecord, mapperNetFlow *NetFlowMapper, mapperSFlow *SFlowMapper) []*flowmessage.FlowMessage {
 	var flowMessageSet []*flowmessage.FlowMessage
 	for _, record := range dataRecords {
-		fmsg := ConvertNetFlowDataSet(version, baseTime, uptime, record.Values, mapperNetFlow, mapperSFlow)
+		fmsg := ConvertNetFlowDataSet(version, baseTime, uptime, systemInitMs, record.Values, mapperNetFlow, mapperSFlow)
 		if fmsg != nil {
 			flowMessageSet = append(flowMessageSet, fmsg)
+			if mapperNetFlow.BiFlowSplit() {
+				if reverse := SplitBiFlow(fmsg); reverse != nil {
+					flowMessageSet = append(flowMessageSet, reverse)
+				}
+			}
 		}
 	}
 	return flowMessageSet
 }
 
-func SearchNetFlowDataSets(version uint16, baseTime uint32, uptime uint32, dataFlowSet []netflow.DataFlowSet, mapperNetFlow *NetFlowMapper, mapperSFlow *SFlowMapper) []*flowmessage.FlowMessage {
+func SearchNetFlowDataSets(version uint16, baseTime uint32, uptime uint32, systemInitMs uint64, dataFlowSet []netflow.DataFlowSet, mapperNetFlow *NetFlowMapper, mapperSFlow *SFlowMapper) []*flowmessage.FlowMessage {
 	var flowMessageSet []*flowmessage.FlowMessage
 	for _, dataFlowSetItem := range dataFlowSet {
-		fmsg := SearchNetFlowDataSetsRecords(version, baseTime, uptime, dataFlowSetItem.Records, mapperNetFlow, mapperSFlow)
+		fmsg := SearchNetFlowDataSetsRecords(version, baseTime, uptime, systemInitMs, dataFlowSetItem.Records, mapperNetFlow, mapperSFlow)
 		if fmsg != nil {
 			flowMessageSet = append(flowMessageSet, fmsg...)
 		}
@@ -545,6 +691,19 @@ func SearchNetFlowDataSets(version uint16, baseTime uint32, uptime uint32, dataF
 	return flowMessageSet
 }
 
+// Returns the initialization time of the exporter (systemInitTimeMilliseconds) from the options data records
+func SearchNetFlowOptionDataSetsSystemInit(dataFlowSet []netflow.OptionsDataFlowSet) (uint64, bool) {
+	var systemInitMs uint64
+	for _, dataFlowSetItem := range dataFlowSet {
+		for _, record := range dataFlowSetItem.Records {
+			if NetFlowPopulate(record.OptionsValues, netflow.IPFIX_FIELD_systemInitTimeMilliseconds, &systemInitMs) {
+				return systemInitMs, true
+			}
+		}
+	}
+	return systemInitMs, false
+}
+
 func SearchNetFlowOptionDataSets(dataFlowSet []netflow.OptionsDataFlowSet) (uint32, bool) {
 	var samplingRate uint32
 	var found bool
@@ -567,6 +726,33 @@ func SearchNetFlowOptionDataSets(dataFlowSet []netflow.OptionsDataFlowSet) (uint
 	return samplingRate, found
 }
 
+func ConvertNetFlowOptionsDataRecord(version uint16, templateId uint16, record netflow.OptionsDataRecord) *flowmessage.OptionsMessage {
+	optionsMessage := &flowmessage.OptionsMessage{
+		TemplateId: uint32(templateId),
+	}
+
+	if version == 9 {
+		optionsMessage.Type = flowmessage.FlowMessage_NETFLOW_V9
+		optionsMessage.Scopes = mapNamedFields(record.ScopesValues, NetFlowV9ScopeName, NetFlowV9ScopeValue)
+	} else if version == 10 {
+		optionsMessage.Type = flowmessage.FlowMessage_IPFIX
+		optionsMessage.Scopes = MapAllFields(version, record.ScopesValues)
+	}
+	optionsMessage.Options = MapAllFields(version, record.OptionsValues)
+
+	return optionsMessage
+}
+
+func SearchNetFlowOptionsRecords(version uint16, dataFlowSet []netflow.OptionsDataFlowSet) []*flowmessage.OptionsMessage {
+	var optionsMessageSet []*flowmessage.OptionsMessage
+	for _, dataFlowSetItem := range dataFlowSet {
+		for _, record := range dataFlowSetItem.Records {
+			optionsMessageSet = append(optionsMessageSet, ConvertNetFlowOptionsDataRecord(version, dataFlowSetItem.Id, record))
+		}
+	}
+	return optionsMessageSet
+}
+
 func SplitNetFlowSets(packetNFv9 netflow.NFv9Packet) ([]netflow.DataFlowSet, []netflow.TemplateFlowSet, []netflow.NFv9OptionsTemplateFlowSet, []netflow.OptionsDataFlowSet) {
 	var dataFlowSet []netflow.DataFlowSet
 	var templatesFlowSet []netflow.TemplateFlowSet
@@ -633,7 +819,7 @@ func ProcessMessageNetFlowConfig(msgDec interface{}, samplingRateSys SamplingRat
 		if config != nil {
 			cfg = config.NetFlowV9
 		}
-		flowMessageSet = SearchNetFlowDataSets(9, baseTime, uptime, dataFlowSet, cfg, nil)
+		flowMessageSet = SearchNetFlowDataSets(9, baseTime, uptime, 0, dataFlowSet, cfg, nil)
 		samplingRate, found := SearchNetFlowOptionDataSets(optionDataFlowSet)
 		if samplingRateSys != nil {
 			if found {
@@ -646,6 +832,12 @@ func ProcessMessageNetFlowConfig(msgDec interface{}, samplingRateSys SamplingRat
 			fmsg.SequenceNum = seqnum
 			fmsg.SamplingRate = uint64(samplingRate)
 		}
+		if namesSys, ok := samplingRateSys.(NameTableSystem); ok {
+			if names := SearchNetFlowOptionDataSetsNames(9, optionDataFlowSet); names != nil {
+				namesSys.AddNames(9, obsDomainId, names)
+			}
+			namesSys.EnrichNames(9, obsDomainId, flowMessageSet)
+		}
 	case netflow.IPFIXPacket:
 		dataFlowSet, _, _, optionDataFlowSet := SplitIPFIXSets(msgDecConv)
 
@@ -659,7 +851,15 @@ func ProcessMessageNetFlowConfig(msgDec interface{}, samplingRateSys SamplingRat
 			cfgIpfix = config.IPFIX
 			cfgSflow = config.SFlow
 		}
-		flowMessageSet = SearchNetFlowDataSets(10, baseTime, uptime, dataFlowSet, cfgIpfix, cfgSflow)
+		systemInitMs, found := SearchNetFlowOptionDataSetsSystemInit(optionDataFlowSet)
+		if systemInitSys, ok := samplingRateSys.(SystemInitTimeSystem); ok {
+			if found {
+				systemInitSys.AddSystemInitTime(10, obsDomainId, systemInitMs)
+			} else {
+				systemInitMs, _ = systemInitSys.GetSystemInitTime(10, obsDomainId)
+			}
+		}
+		flowMessageSet = SearchNetFlowDataSets(10, baseTime, uptime, systemInitMs, dataFlowSet, cfgIpfix, cfgSflow)
 
 		samplingRate, found := SearchNetFlowOptionDataSets(optionDataFlowSet)
 		if samplingRateSys != nil {
@@ -674,9 +874,47 @@ func ProcessMessageNetFlowConfig(msgDec interface{}, samplingRateSys SamplingRat
 			fmsg.SamplingRate = uint64(samplingRate)
 			fmsg.ObservationDomainId = obsDomainId
 		}
+		if namesSys, ok := samplingRateSys.(NameTableSystem); ok {
+			if names := SearchNetFlowOptionDataSetsNames(10, optionDataFlowSet); names != nil {
+				namesSys.AddNames(10, obsDomainId, names)
+			}
+			namesSys.EnrichNames(10, obsDomainId, flowMessageSet)
+		}
 	default:
 		return flowMessageSet, errors.New("Bad NetFlow/IPFIX version")
 	}
 
 	return flowMessageSet, nil
 }
+
+// Converts the options data records of a packet into messages, when enabled in the configuration
+func ProcessMessageNetFlowOptionsConfig(msgDec interface{}, config *ProducerConfigMapped) ([]*flowmessage.OptionsMessage, error) {
+	var optionsMessageSet []*flowmessage.OptionsMessage
+
+	switch msgDecConv := msgDec.(type) {
+	case netflow.NFv9Packet:
+		if config == nil || !config.NetFlowV9.Options() {
+			return nil, nil
+		}
+		_, _, _, optionDataFlowSet := SplitNetFlowSets(msgDecConv)
+		optionsMessageSet = SearchNetFlowOptionsRecords(9, optionDataFlowSet)
+		for _, omsg := range optionsMessageSet {
+			omsg.SequenceNum = msgDecConv.SequenceNumber
+			omsg.ObservationDomainId = msgDecConv.SourceId
+		}
+	case netflow.IPFIXPacket:
+		if config == nil || !config.IPFIX.Options() {
+			return nil, nil
+		}
+		_, _, _, optionDataFlowSet := SplitIPFIXSets(msgDecConv)
+		optionsMessageSet = SearchNetFlowOptionsRecords(10, optionDataFlowSet)
+		for _, omsg := range optionsMessageSet {
+			omsg.SequenceNum = msgDecConv.SequenceNumber
+			omsg.ObservationDomainId = msgDecConv.ObservationDomainId
+		}
+	default:
+		return optionsMessageSet, errors.New("Bad NetFlow/IPFIX version")
+	}
+
+	return optionsMessageSet, nil
+}
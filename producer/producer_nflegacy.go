@@ -9,17 +9,14 @@ import (
 	flowmessage "github.com/netsampler/goflow2/pb"
 )
 
-func ConvertNetFlowLegacyRecord(baseTime uint32, uptime uint32, record netflowlegacy.RecordsNetFlowV5) *flowmessage.FlowMessage {
+func ConvertNetFlowLegacyRecord(baseTime uint32, baseTimeNsecs uint32, uptime uint32, record netflowlegacy.RecordsNetFlowV5) *flowmessage.FlowMessage {
 	flowMessage := &flowmessage.FlowMessage{}
 
 	flowMessage.Type = flowmessage.FlowMessage_NETFLOW_V5
 
-	timeDiffFirst := (uptime - record.First)
-	timeDiffLast := (uptime - record.Last)
-	flowMessage.TimeFlowStart = uint64(baseTime - timeDiffFirst/1000)
-	flowMessage.TimeFlowStartMs = uint64(baseTime)*1000 - uint64(timeDiffFirst)
-	flowMessage.TimeFlowEnd = uint64(baseTime - timeDiffLast/1000)
-	flowMessage.TimeFlowEndMs = uint64(baseTime)*1000 - uint64(timeDiffLast)
+	baseTimeMs := uint64(baseTime)*1000 + uint64(baseTimeNsecs/1000000)
+	setFlowStartMs(flowMessage, uptimeToMs(baseTimeMs, uptime, record.First))
+	setFlowEndMs(flowMessage, uptimeToMs(baseTimeMs, uptime, record.Last))
 
 	v := make(net.IP, 4)
 	binary.BigEndian.PutUint32(v, record.NextHop)
@@ -49,10 +46,10 @@ func ConvertNetFlowLegacyRecord(baseTime uint32, uptime uint32, record netflowle
 	return flowMessage
 }
 
-func SearchNetFlowLegacyRecords(baseTime uint32, uptime uint32, dataRecords []netflowlegacy.RecordsNetFlowV5) []*flowmessage.FlowMessage {
+func SearchNetFlowLegacyRecords(baseTime uint32, baseTimeNsecs uint32, uptime uint32, dataRecords []netflowlegacy.RecordsNetFlowV5) []*flowmessage.FlowMessage {
 	var flowMessageSet []*flowmessage.FlowMessage
 	for _, record := range dataRecords {
-		fmsg := ConvertNetFlowLegacyRecord(baseTime, uptime, record)
+		fmsg := ConvertNetFlowLegacyRecord(baseTime, baseTimeNsecs, uptime, record)
 		if fmsg != nil {
 			flowMessageSet = append(flowMessageSet, fmsg)
 		}
@@ -68,7 +65,7 @@ func ProcessMessageNetFlowLegacy(msgDec interface{}) ([]*flowmessage.FlowMessage
 		baseTime := packet.UnixSecs
 		uptime := packet.SysUptime
 
-		flowMessageSet := SearchNetFlowLegacyRecords(baseTime, uptime, packet.Records)
+		flowMessageSet := SearchNetFlowLegacyRecords(baseTime, packet.UnixNSecs, uptime, packet.Records)
 		for _, fmsg := range flowMessageSet {
 			fmsg.SequenceNum = seqnum
 			fmsg.SamplingRate = uint64(samplingRate)
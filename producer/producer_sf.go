@@ -9,6 +9,13 @@ import (
 	flowmessage "github.com/netsampler/goflow2/pb"
 )
 
+func macToUint64(mac []byte) uint64 {
+	if len(mac) != 6 {
+		return 0
+	}
+	return binary.BigEndian.Uint64(append([]byte{0, 0}, mac...))
+}
+
 func GetSFlowFlowSamples(packet *sflow.Packet) []interface{} {
 	var flowSamples []interface{}
 	for _, sample := range packet.Samples {
@@ -17,213 +24,34 @@ func GetSFlowFlowSamples(packet *sflow.Packet) []interface{} {
 			flowSamples = append(flowSamples, sample)
 		case sflow.ExpandedFlowSample:
 			flowSamples = append(flowSamples, sample)
+		case sflow.DropSample:
+			flowSamples = append(flowSamples, sample)
 		}
 	}
 	return flowSamples
 }
 
-func ParseSampledHeader(flowMessage *flowmessage.FlowMessage, sampledHeader *sflow.SampledHeader) error {
-	return ParseSampledHeaderConfig(flowMessage, sampledHeader, nil)
+// Reports the tunnel header of an extended IPv4 tunnel record as the outer headers
+func mapSFlowTunnel(flowMessage *flowmessage.FlowMessage, header sflow.SampledIPv4) {
+	flowMessage.TunnelSrcAddr = header.Base.SrcIP
+	flowMessage.TunnelDstAddr = header.Base.DstIP
+	flowMessage.TunnelProto = header.Base.Protocol
+	flowMessage.TunnelSrcPort = header.Base.SrcPort
+	flowMessage.TunnelDstPort = header.Base.DstPort
 }
 
-func ParseEthernetHeader(flowMessage *flowmessage.FlowMessage, data []byte, config *SFlowMapper) {
-	var hasMpls bool
-	var countMpls uint32
-	var firstLabelMpls uint32
-	var firstTtlMpls uint8
-	var secondLabelMpls uint32
-	var secondTtlMpls uint8
-	var thirdLabelMpls uint32
-	var thirdTtlMpls uint8
-	var lastLabelMpls uint32
-	var lastTtlMpls uint8
-
-	var nextHeader byte
-	var tcpflags byte
-	srcIP := net.IP{}
-	dstIP := net.IP{}
-	offset := 14
-
-	var srcMac uint64
-	var dstMac uint64
-
-	var tos byte
-	var ttl byte
-	var identification uint16
-	var fragOffset uint16
-	var flowLabel uint32
-
-	var srcPort uint16
-	var dstPort uint16
-
-	for _, configLayer := range GetSFlowConfigLayer(config, 0) {
-		extracted := GetBytes(data, configLayer.Offset, configLayer.Length)
-		MapCustom(flowMessage, extracted, configLayer.Destination, configLayer.Endian)
-	}
-
-	etherType := data[12:14]
-
-	dstMac = binary.BigEndian.Uint64(append([]byte{0, 0}, data[0:6]...))
-	srcMac = binary.BigEndian.Uint64(append([]byte{0, 0}, data[6:12]...))
-	(*flowMessage).SrcMac = srcMac
-	(*flowMessage).DstMac = dstMac
-
-	encap := true
-	iterations := 0
-	for encap && iterations <= 1 {
-		encap = false
-
-		if etherType[0] == 0x81 && etherType[1] == 0x0 { // VLAN 802.1Q
-			(*flowMessage).VlanId = uint32(binary.BigEndian.Uint16(data[14:16]))
-			offset += 4
-			etherType = data[16:18]
-		}
-
-		if etherType[0] == 0x88 && etherType[1] == 0x47 { // MPLS
-			iterateMpls := true
-			hasMpls = true
-			for iterateMpls {
-				if len(data) < offset+5 {
-					iterateMpls = false
-					break
-				}
-				label := binary.BigEndian.Uint32(append([]byte{0}, data[offset:offset+3]...)) >> 4
-				//exp := data[offset+2] > 1
-				bottom := data[offset+2] & 1
-				mplsTtl := data[offset+3]
-				offset += 4
-
-				if bottom == 1 || label <= 15 || offset > len(data) {
-					if data[offset]&0xf0>>4 == 4 {
-						etherType = []byte{0x8, 0x0}
-					} else if data[offset]&0xf0>>4 == 6 {
-						etherType = []byte{0x86, 0xdd}
-					}
-					iterateMpls = false
-				}
-
-				if countMpls == 0 {
-					firstLabelMpls = label
-					firstTtlMpls = mplsTtl
-				} else if countMpls == 1 {
-					secondLabelMpls = label
-					secondTtlMpls = mplsTtl
-				} else if countMpls == 2 {
-					thirdLabelMpls = label
-					thirdTtlMpls = mplsTtl
-				} else {
-					lastLabelMpls = label
-					lastTtlMpls = mplsTtl
-				}
-				countMpls++
-			}
-		}
-
-		for _, configLayer := range GetSFlowConfigLayer(config, 3) {
-			extracted := GetBytes(data, offset*8+configLayer.Offset, configLayer.Length)
-			MapCustom(flowMessage, extracted, configLayer.Destination, configLayer.Endian)
-		}
-
-		if etherType[0] == 0x8 && etherType[1] == 0x0 { // IPv4
-			if len(data) >= offset+20 {
-				nextHeader = data[offset+9]
-				srcIP = data[offset+12 : offset+16]
-				dstIP = data[offset+16 : offset+20]
-				tos = data[offset+1]
-				ttl = data[offset+8]
-
-				identification = binary.BigEndian.Uint16(data[offset+4 : offset+6])
-				fragOffset = binary.BigEndian.Uint16(data[offset+6 : offset+8])
-
-				offset += 20
-			}
-		} else if etherType[0] == 0x86 && etherType[1] == 0xdd { // IPv6
-			if len(data) >= offset+40 {
-				nextHeader = data[offset+6]
-				srcIP = data[offset+8 : offset+24]
-				dstIP = data[offset+24 : offset+40]
-
-				tostmp := uint32(binary.BigEndian.Uint16(data[offset : offset+2]))
-				tos = uint8(tostmp & 0x0ff0 >> 4)
-				ttl = data[offset+7]
-
-				flowLabel = binary.BigEndian.Uint32(data[offset : offset+4])
-
-				offset += 40
-
-			}
-		} else if etherType[0] == 0x8 && etherType[1] == 0x6 { // ARP
-		} /*else {
-			return errors.New(fmt.Sprintf("Unknown EtherType: %v\n", etherType))
-		} */
-
-		for _, configLayer := range GetSFlowConfigLayer(config, 4) {
-			extracted := GetBytes(data, offset*8+configLayer.Offset, configLayer.Length)
-			MapCustom(flowMessage, extracted, configLayer.Destination, configLayer.Endian)
-		}
-
-		appOffset := 0
-		if len(data) >= offset+4 && (nextHeader == 17 || nextHeader == 6) {
-			srcPort = binary.BigEndian.Uint16(data[offset+0 : offset+2])
-			dstPort = binary.BigEndian.Uint16(data[offset+2 : offset+4])
-		}
-
-		if nextHeader == 17 {
-			appOffset = 8
-		}
-
-		if len(data) > offset+13 && nextHeader == 6 {
-			tcpflags = data[offset+13]
-
-			appOffset = int(data[13]>>4) * 4
-		}
-
-		// ICMP and ICMPv6
-		if len(data) >= offset+2 && (nextHeader == 1 || nextHeader == 58) {
-			(*flowMessage).IcmpType = uint32(data[offset+0])
-			(*flowMessage).IcmpCode = uint32(data[offset+1])
-		}
-
-		if appOffset > 0 {
-			for _, configLayer := range GetSFlowConfigLayer(config, 7) {
-				extracted := GetBytes(data, (offset+appOffset)*8+configLayer.Offset, configLayer.Length)
-				MapCustom(flowMessage, extracted, configLayer.Destination, configLayer.Endian)
-			}
-		}
-
-		iterations++
-	}
-
-	(*flowMessage).HasMpls = hasMpls
-	(*flowMessage).MplsCount = countMpls
-	(*flowMessage).Mpls_1Label = firstLabelMpls
-	(*flowMessage).Mpls_1Ttl = uint32(firstTtlMpls)
-	(*flowMessage).Mpls_2Label = secondLabelMpls
-	(*flowMessage).Mpls_2Ttl = uint32(secondTtlMpls)
-	(*flowMessage).Mpls_3Label = thirdLabelMpls
-	(*flowMessage).Mpls_3Ttl = uint32(thirdTtlMpls)
-	(*flowMessage).MplsLastLabel = lastLabelMpls
-	(*flowMessage).MplsLastTtl = uint32(lastTtlMpls)
-
-	(*flowMessage).Etype = uint32(binary.BigEndian.Uint16(etherType[0:2]))
-	(*flowMessage).Ipv6FlowLabel = flowLabel & 0xFFFFF
-
-	(*flowMessage).SrcPort = uint32(srcPort)
-	(*flowMessage).DstPort = uint32(dstPort)
-
-	(*flowMessage).SrcAddr = srcIP
-	(*flowMessage).DstAddr = dstIP
-	(*flowMessage).Proto = uint32(nextHeader)
-	(*flowMessage).IpTos = uint32(tos)
-	(*flowMessage).IpTtl = uint32(ttl)
-	(*flowMessage).TcpFlags = uint32(tcpflags)
-
-	(*flowMessage).FragmentId = uint32(identification)
-	(*flowMessage).FragmentOffset = uint32(fragOffset)
+func ParseSampledHeader(flowMessage *flowmessage.FlowMessage, sampledHeader *sflow.SampledHeader) error {
+	return ParseSampledHeaderConfig(flowMessage, sampledHeader, nil)
 }
 
 func ParseSampledHeaderConfig(flowMessage *flowmessage.FlowMessage, sampledHeader *sflow.SampledHeader, config *SFlowMapper) error {
 	data := (*sampledHeader).HeaderData
+	if config.RawHeader() {
+		flowMessage.RawHeader = data
+		flowMessage.RawHeaderFrameLength = sampledHeader.FrameLength
+		flowMessage.RawHeaderStripped = sampledHeader.Stripped
+		flowMessage.RawHeaderProtocol = sampledHeader.Protocol
+	}
 	switch (*sampledHeader).Protocol {
 	case 1: // Ethernet
 		ParseEthernetHeader(flowMessage, data, config)
@@ -255,6 +83,14 @@ func SearchSFlowSamplesConfig(samples []interface{}, config *SFlowMapper) []*flo
 			flowMessage.SamplingRate = uint64(flowSample.SamplingRate)
 			flowMessage.InIf = flowSample.InputIfValue
 			flowMessage.OutIf = flowSample.OutputIfValue
+		case sflow.DropSample:
+			records = flowSample.Records
+			flowMessage.SamplingRate = 1
+			flowMessage.InIf = flowSample.Input
+			flowMessage.OutIf = flowSample.Output
+			flowMessage.Dropped = true
+			flowMessage.DropReason = flowSample.Reason
+			flowMessage.DropReasonName = sflow.DropReasonToString(flowSample.Reason)
 		}
 
 		ipNh := net.IP{}
@@ -312,6 +148,61 @@ func SearchSFlowSamplesConfig(samples []interface{}, config *SFlowMapper) []*flo
 			case sflow.ExtendedSwitch:
 				flowMessage.SrcVlan = recordData.SrcVlan
 				flowMessage.DstVlan = recordData.DstVlan
+			case sflow.ExtendedUser:
+				flowMessage.SrcUser = recordData.SrcUser
+				flowMessage.DstUser = recordData.DstUser
+			case sflow.ExtendedURL:
+				flowMessage.HttpUrl = recordData.URL
+				flowMessage.HttpHost = recordData.Host
+			case sflow.ExtendedMPLS:
+				if len(flowMessage.NextHop) == 0 {
+					flowMessage.NextHop = recordData.NextHop
+				}
+				// label stack entries: only keep the labels
+				flowMessage.MplsLabelsIn = make([]uint32, len(recordData.InLabels))
+				for i, entry := range recordData.InLabels {
+					flowMessage.MplsLabelsIn[i] = entry >> 12
+				}
+				flowMessage.MplsLabelsOut = make([]uint32, len(recordData.OutLabels))
+				for i, entry := range recordData.OutLabels {
+					flowMessage.MplsLabelsOut[i] = entry >> 12
+				}
+			case sflow.ExtendedMPLSTunnel:
+				flowMessage.MplsTunnelName = recordData.Name
+				flowMessage.MplsTunnelId = recordData.TunnelId
+			case sflow.ExtendedMPLSVC:
+				flowMessage.MplsVcName = recordData.InstanceName
+				flowMessage.MplsVcId = recordData.VCId
+			case sflow.ExtendedMPLSFTN:
+				flowMessage.MplsFtnDescr = recordData.Description
+			case sflow.ExtendedNAT:
+				flowMessage.PostNatSrcAddr = recordData.SrcIP
+				flowMessage.PostNatDstAddr = recordData.DstIP
+			case sflow.ExtendedNATPort:
+				flowMessage.PostNatSrcPort = recordData.SrcPort
+				flowMessage.PostNatDstPort = recordData.DstPort
+			case sflow.Extended80211Rx:
+				flowMessage.WlanSsid = recordData.SSID
+				flowMessage.WlanBssid = macToUint64(recordData.BSSID)
+				flowMessage.WlanChannel = recordData.Channel
+			case sflow.Extended80211Tx:
+				flowMessage.WlanSsid = recordData.SSID
+				flowMessage.WlanBssid = macToUint64(recordData.BSSID)
+				flowMessage.WlanChannel = recordData.Channel
+			case sflow.ExtendedIPv4TunnelIngress:
+				mapSFlowTunnel(flowMessage, recordData.Header)
+			case sflow.ExtendedIPv4TunnelEgress:
+				if len(flowMessage.TunnelSrcAddr) == 0 {
+					mapSFlowTunnel(flowMessage, recordData.Header)
+				}
+			case sflow.ExtendedVNIIngress:
+				flowMessage.VniIngress = recordData.VNI
+			case sflow.ExtendedVNIEgress:
+				flowMessage.VniEgress = recordData.VNI
+			case sflow.ExtendedEgressQueue:
+				flowMessage.DropEgressQueue = recordData.Queue
+			case sflow.ExtendedLinuxDropReason:
+				flowMessage.DropReasonName = recordData.Reason
 			}
 		}
 		flowMessageSet = append(flowMessageSet, flowMessage)
@@ -1,10 +1,13 @@
 package producer
 
 import (
+	"bytes"
 	"testing"
 
 	"github.com/netsampler/goflow2/decoders/netflow"
+	"github.com/netsampler/goflow2/decoders/netflowlegacy"
 	"github.com/netsampler/goflow2/decoders/sflow"
+	flowmessage "github.com/netsampler/goflow2/pb"
 	"github.com/stretchr/testify/assert"
 )
 
@@ -39,6 +42,37 @@ func TestProcessMessageNetFlow(t *testing.T) {
 	assert.Nil(t, err)
 }
 
+func FuzzProcessMessageNetFlow(f *testing.F) {
+	// IPFIX: template (addresses, interfaces, reverse octets, application name), options template and their data
+	f.Add([]byte{
+		0x00, 0x0a, 0x00, 0x62, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x01, 0x00, 0x00, 0x00, 0x00,
+		0x00, 0x02, 0x00, 0x1c, 0x01, 0x00, 0x00, 0x04, 0x00, 0x08, 0x00, 0x04, 0x00, 0x0a, 0x00, 0x04,
+		0x80, 0x01, 0x00, 0x04, 0x00, 0x00, 0x72, 0x79, 0x00, 0x60, 0xff, 0xff,
+		0x00, 0x03, 0x00, 0x12, 0x01, 0x01, 0x00, 0x02, 0x00, 0x01, 0x00, 0x0a, 0x00, 0x04, 0x00, 0x52,
+		0xff, 0xff,
+		0x01, 0x00, 0x00, 0x18, 0x0a, 0x00, 0x00, 0x01, 0x00, 0x00, 0x00, 0x02, 0x00, 0x00, 0x00, 0x64,
+		0x07, 0x65, 0x78, 0x61, 0x6d, 0x70, 0x6c, 0x65,
+		0x01, 0x01, 0x00, 0x0c, 0x00, 0x00, 0x00, 0x02, 0x03, 0x65, 0x74, 0x68,
+	})
+	config := NewProducerConfigMapped(&ProducerConfig{
+		IPFIX:     IPFIXProducerConfig{AllFields: true, Options: true, BiFlow: BIFLOW_SPLIT},
+		NetFlowV9: NetFlowV9ProducerConfig{AllFields: true, Options: true},
+	})
+	f.Fuzz(func(t *testing.T, data []byte) {
+		templates := netflow.CreateTemplateSystem()
+		samplingRateSys := CreateSamplingSystem()
+		// decoded twice: the data sets can use the templates of the first pass
+		for i := 0; i < 2; i++ {
+			msgDec, err := netflow.DecodeMessage(bytes.NewBuffer(data), templates)
+			if err != nil {
+				continue
+			}
+			_, _ = ProcessMessageNetFlowConfig(msgDec, samplingRateSys, config)
+			_, _ = ProcessMessageNetFlowOptionsConfig(msgDec, config)
+		}
+	})
+}
+
 func TestProcessMessageSFlow(t *testing.T) {
 	sh := sflow.SampledHeader{
 		FrameLength: 10,
@@ -189,3 +223,1105 @@ func getSflowPacket() sflow.Packet {
 		},
 	}
 }
+
+func TestMapAllFields(t *testing.T) {
+	record := []netflow.DataField{
+		netflow.DataField{
+			Type:  netflow.IPFIX_FIELD_sourceIPv4Address,
+			Value: []byte{10, 0, 0, 1},
+		},
+		netflow.DataField{
+			Type:  netflow.IPFIX_FIELD_octetDeltaCount,
+			Value: []byte{0, 0, 0x01, 0x00},
+		},
+		netflow.DataField{
+			Type:  netflow.IPFIX_FIELD_octetDeltaCount,
+			Value: []byte{0x02},
+		},
+		netflow.DataField{
+			Type:  netflow.IPFIX_FIELD_sourceMacAddress,
+			Value: []byte{0x01, 0x02, 0x03, 0x04, 0x05, 0x06},
+		},
+		netflow.DataField{
+			Type:  netflow.IPFIX_FIELD_interfaceName,
+			Value: []byte("eth0\x00\x00"),
+		},
+		netflow.DataField{
+			Type:  netflow.IPFIX_FIELD_flowStartMilliseconds,
+			Value: []byte{0, 0, 0x01, 0x86, 0x9a, 0x77, 0xfc, 0x00},
+		},
+		netflow.DataField{
+			Type:  netflow.IPFIX_FIELD_flowStartNanoseconds,
+			Value: []byte{0xe7, 0x5b, 0x4b, 0x80, 0x80, 0x00, 0x00, 0x00},
+		},
+		netflow.DataField{
+			Type:  netflow.IPFIX_FIELD_dataRecordsReliability,
+			Value: []byte{2},
+		},
+		netflow.DataField{
+			PenProvided: true,
+			Pen:         2636,
+			Type:        137,
+			Value:       []byte{0xab, 0xcd},
+		},
+	}
+
+	allFields := MapAllFields(10, record)
+	assert.Equal(t, map[string]string{
+		"sourceIPv4Address":      "10.0.0.1",
+		"octetDeltaCount":        "256",
+		"octetDeltaCount_2":      "2",
+		"sourceMacAddress":       "01:02:03:04:05:06",
+		"interfaceName":          "eth0",
+		"flowStartMilliseconds":  "2023-03-01T00:00:00Z",
+		"flowStartNanoseconds":   "2023-01-01T00:00:00.5Z",
+		"dataRecordsReliability": "false",
+		"2636.137":               "abcd",
+	}, allFields)
+
+	allFields = MapAllFields(9, record[0:1])
+	assert.Equal(t, map[string]string{"IPV4_SRC_ADDR": "10.0.0.1"}, allFields)
+}
+
+func TestConvertNetFlowDataSetAllFields(t *testing.T) {
+	record := []netflow.DataField{
+		netflow.DataField{
+			Type:  netflow.IPFIX_FIELD_sourceIPv4Address,
+			Value: []byte{10, 0, 0, 1},
+		},
+	}
+
+	msg := ConvertNetFlowDataSet(10, 0, 0, 0, record, nil, nil)
+	assert.Nil(t, msg.AllFields)
+
+	mapped := NewProducerConfigMapped(&ProducerConfig{
+		IPFIX: IPFIXProducerConfig{AllFields: true},
+	})
+	msg = ConvertNetFlowDataSet(10, 0, 0, 0, record, mapped.IPFIX, mapped.SFlow)
+	assert.Equal(t, map[string]string{"sourceIPv4Address": "10.0.0.1"}, msg.AllFields)
+}
+
+func TestProcessMessageNetFlowOptions(t *testing.T) {
+	dfs := []interface{}{
+		netflow.OptionsDataFlowSet{
+			FlowSetHeader: netflow.FlowSetHeader{
+				Id: 260,
+			},
+			Records: []netflow.OptionsDataRecord{
+				netflow.OptionsDataRecord{
+					ScopesValues: []netflow.DataField{
+						netflow.DataField{
+							Type:  netflow.IPFIX_FIELD_ingressInterface,
+							Value: []byte{0, 0, 0, 10},
+						},
+					},
+					OptionsValues: []netflow.DataField{
+						netflow.DataField{
+							Type:  netflow.IPFIX_FIELD_interfaceName,
+							Value: []byte("eth0"),
+						},
+					},
+				},
+			},
+		},
+	}
+
+	pktipfix := netflow.IPFIXPacket{
+		SequenceNumber:      5,
+		ObservationDomainId: 1,
+		FlowSets:            dfs,
+	}
+	omsgs, err := ProcessMessageNetFlowOptionsConfig(pktipfix, nil)
+	assert.Nil(t, err)
+	assert.Len(t, omsgs, 0)
+
+	mapped := NewProducerConfigMapped(&ProducerConfig{
+		IPFIX:     IPFIXProducerConfig{Options: true},
+		NetFlowV9: NetFlowV9ProducerConfig{Options: true},
+	})
+	omsgs, err = ProcessMessageNetFlowOptionsConfig(pktipfix, mapped)
+	assert.Nil(t, err)
+	if assert.Len(t, omsgs, 1) {
+		assert.Equal(t, uint32(260), omsgs[0].TemplateId)
+		assert.Equal(t, uint32(5), omsgs[0].SequenceNum)
+		assert.Equal(t, uint32(1), omsgs[0].ObservationDomainId)
+		assert.Equal(t, map[string]string{"ingressInterface": "10"}, omsgs[0].Scopes)
+		assert.Equal(t, map[string]string{"interfaceName": "eth0"}, omsgs[0].Options)
+	}
+
+	pktnf9 := netflow.NFv9Packet{
+		FlowSets: []interface{}{
+			netflow.OptionsDataFlowSet{
+				Records: []netflow.OptionsDataRecord{
+					netflow.OptionsDataRecord{
+						ScopesValues: []netflow.DataField{
+							netflow.DataField{
+								Type:  2, // Interface
+								Value: []byte{0, 0, 0, 10},
+							},
+						},
+					},
+				},
+			},
+		},
+	}
+	omsgs, err = ProcessMessageNetFlowOptionsConfig(pktnf9, mapped)
+	assert.Nil(t, err)
+	if assert.Len(t, omsgs, 1) {
+		assert.Equal(t, map[string]string{"Interface": "10"}, omsgs[0].Scopes)
+	}
+}
+
+func TestSearchSFlowSamplesExtended(t *testing.T) {
+	samples := []interface{}{
+		sflow.FlowSample{
+			SamplingRate: 1,
+			Records: []sflow.FlowRecord{
+				sflow.FlowRecord{
+					Data: sflow.ExtendedMPLS{
+						NextHop:   []byte{10, 0, 0, 1},
+						InLabels:  []uint32{0x03e80140},
+						OutLabels: []uint32{0x03e81040, 0x3140},
+					},
+				},
+				sflow.FlowRecord{
+					Data: sflow.ExtendedNAT{
+						SrcIP: []byte{192, 0, 2, 1},
+						DstIP: []byte{198, 51, 100, 1},
+					},
+				},
+				sflow.FlowRecord{
+					Data: sflow.ExtendedUser{
+						SrcUser: "alice",
+						DstUser: "bob",
+					},
+				},
+				sflow.FlowRecord{
+					Data: sflow.ExtendedURL{
+						URL:  "/index.html",
+						Host: "example.com",
+					},
+				},
+				sflow.FlowRecord{
+					Data: sflow.ExtendedVNIIngress{
+						VNI: 42,
+					},
+				},
+				sflow.FlowRecord{
+					Data: sflow.ExtendedIPv4TunnelIngress{
+						Header: sflow.SampledIPv4{
+							Base: sflow.SampledIP_Base{
+								Protocol: 47,
+								SrcIP:    []byte{203, 0, 113, 1},
+								DstIP:    []byte{203, 0, 113, 2},
+							},
+						},
+					},
+				},
+				sflow.FlowRecord{
+					Data: sflow.Extended80211Tx{
+						SSID:    "wifi",
+						BSSID:   []byte{1, 2, 3, 4, 5, 6},
+						Channel: 6,
+					},
+				},
+			},
+		},
+	}
+
+	flowMessages := SearchSFlowSamplesConfig(samples, nil)
+	if assert.Len(t, flowMessages, 1) {
+		fmsg := flowMessages[0]
+		assert.Equal(t, []byte{10, 0, 0, 1}, fmsg.NextHop)
+		assert.Equal(t, []uint32{16000}, fmsg.MplsLabelsIn)
+		assert.Equal(t, []uint32{16001, 3}, fmsg.MplsLabelsOut)
+		assert.Equal(t, []byte{192, 0, 2, 1}, fmsg.PostNatSrcAddr)
+		assert.Equal(t, []byte{198, 51, 100, 1}, fmsg.PostNatDstAddr)
+		assert.Equal(t, "alice", fmsg.SrcUser)
+		assert.Equal(t, "bob", fmsg.DstUser)
+		assert.Equal(t, "/index.html", fmsg.HttpUrl)
+		assert.Equal(t, "example.com", fmsg.HttpHost)
+		assert.Equal(t, uint32(42), fmsg.VniIngress)
+		assert.Equal(t, []byte{203, 0, 113, 1}, fmsg.TunnelSrcAddr)
+		assert.Equal(t, []byte{203, 0, 113, 2}, fmsg.TunnelDstAddr)
+		assert.Equal(t, uint32(47), fmsg.TunnelProto)
+		assert.Equal(t, "wifi", fmsg.WlanSsid)
+		assert.Equal(t, uint64(0x010203040506), fmsg.WlanBssid)
+		assert.Equal(t, uint32(6), fmsg.WlanChannel)
+	}
+}
+
+func TestProcessMessageSFlowDrop(t *testing.T) {
+	pkt := sflow.Packet{
+		Version: 5,
+		Samples: []interface{}{
+			sflow.DropSample{
+				Input:  3,
+				Reason: 257,
+				Records: []sflow.FlowRecord{
+					sflow.FlowRecord{
+						Data: sflow.ExtendedEgressQueue{
+							Queue: 2,
+						},
+					},
+				},
+			},
+		},
+	}
+	flowMessages, err := ProcessMessageSFlow(pkt)
+	assert.Nil(t, err)
+	if assert.Len(t, flowMessages, 1) {
+		assert.True(t, flowMessages[0].Dropped)
+		assert.Equal(t, uint32(257), flowMessages[0].DropReason)
+		assert.Equal(t, "ttl_exceeded", flowMessages[0].DropReasonName)
+		assert.Equal(t, uint32(2), flowMessages[0].DropEgressQueue)
+		assert.Equal(t, uint32(3), flowMessages[0].InIf)
+	}
+}
+
+func buildIPv4Header(proto byte, src, dst []byte) []byte {
+	header := []byte{
+		0x45, 0x00, 0x00, 0x00, 0x00, 0x01, 0x00, 0x00,
+		0x40, proto, 0x00, 0x00,
+	}
+	header = append(header, src...)
+	return append(header, dst...)
+}
+
+func buildEthernetHeader(etherType uint16) []byte {
+	return []byte{
+		0x00, 0x00, 0x5e, 0x00, 0x01, 0x01,
+		0x00, 0x00, 0x5e, 0x00, 0x01, 0x02,
+		byte(etherType >> 8), byte(etherType),
+	}
+}
+
+func TestParseEthernetHeaderTunnels(t *testing.T) {
+	outerSrc := []byte{192, 0, 2, 1}
+	outerDst := []byte{192, 0, 2, 2}
+	innerSrc := []byte{10, 0, 0, 1}
+	innerDst := []byte{10, 0, 0, 2}
+	innerTcp := []byte{0x04, 0xd2, 0x00, 0x50, 0, 0, 0, 0, 0, 0, 0, 0, 0x50, 0x02, 0, 0, 0, 0, 0, 0}
+
+	// VXLAN with VNI 5000
+	vxlan := buildEthernetHeader(0x0800)
+	vxlan = append(vxlan, buildIPv4Header(17, outerSrc, outerDst)...)
+	vxlan = append(vxlan, 0xc0, 0x00, 0x12, 0xb5, 0x00, 0x00, 0x00, 0x00) // UDP 49152 -> 4789
+	vxlan = append(vxlan, 0x08, 0x00, 0x00, 0x00, 0x00, 0x13, 0x88, 0x00)
+	vxlan = append(vxlan, buildEthernetHeader(0x0800)...)
+	vxlan = append(vxlan, buildIPv4Header(6, innerSrc, innerDst)...)
+	vxlan = append(vxlan, innerTcp...)
+
+	// decapsulation disabled: the outer headers are reported
+	var fmsg flowmessage.FlowMessage
+	ParseEthernetHeader(&fmsg, vxlan, nil)
+	assert.Equal(t, outerSrc, fmsg.SrcAddr)
+	assert.Equal(t, uint32(4789), fmsg.DstPort)
+	assert.Equal(t, flowmessage.FlowMessage_TUNNEL_NONE, fmsg.TunnelType)
+
+	config := NewProducerConfigMapped(&ProducerConfig{SFlow: SFlowProducerConfig{DecapDepth: 1}})
+
+	fmsg = flowmessage.FlowMessage{}
+	ParseEthernetHeader(&fmsg, vxlan, config.SFlow)
+	assert.Equal(t, innerSrc, fmsg.SrcAddr)
+	assert.Equal(t, innerDst, fmsg.DstAddr)
+	assert.Equal(t, uint32(6), fmsg.Proto)
+	assert.Equal(t, uint32(1234), fmsg.SrcPort)
+	assert.Equal(t, uint32(80), fmsg.DstPort)
+	assert.Equal(t, uint32(2), fmsg.TcpFlags)
+	assert.Equal(t, flowmessage.FlowMessage_TUNNEL_VXLAN, fmsg.TunnelType)
+	assert.Equal(t, outerSrc, fmsg.TunnelSrcAddr)
+	assert.Equal(t, outerDst, fmsg.TunnelDstAddr)
+	assert.Equal(t, uint32(17), fmsg.TunnelProto)
+	assert.Equal(t, uint32(49152), fmsg.TunnelSrcPort)
+	assert.Equal(t, uint32(4789), fmsg.TunnelDstPort)
+	assert.Equal(t, uint32(5000), fmsg.TunnelVni)
+	assert.Equal(t, uint32(1), fmsg.TunnelDepth)
+
+	// GRE with a key carrying IPv4
+	gre := buildEthernetHeader(0x0800)
+	gre = append(gre, buildIPv4Header(47, outerSrc, outerDst)...)
+	gre = append(gre, 0x20, 0x00, 0x08, 0x00, 0x00, 0x00, 0x00, 0x2a)
+	gre = append(gre, buildIPv4Header(6, innerSrc, innerDst)...)
+	gre = append(gre, innerTcp...)
+
+	fmsg = flowmessage.FlowMessage{}
+	ParseEthernetHeader(&fmsg, gre, config.SFlow)
+	assert.Equal(t, innerSrc, fmsg.SrcAddr)
+	assert.Equal(t, uint32(80), fmsg.DstPort)
+	assert.Equal(t, flowmessage.FlowMessage_TUNNEL_GRE, fmsg.TunnelType)
+	assert.Equal(t, uint32(47), fmsg.TunnelProto)
+	assert.Equal(t, uint32(42), fmsg.TunnelGreKey)
+
+	// IP-in-IP truncated after the inner header keeps the inner addresses
+	ipip := buildEthernetHeader(0x0800)
+	ipip = append(ipip, buildIPv4Header(4, outerSrc, outerDst)...)
+	ipip = append(ipip, buildIPv4Header(17, innerSrc, innerDst)...)
+
+	fmsg = flowmessage.FlowMessage{}
+	ParseEthernetHeader(&fmsg, ipip, config.SFlow)
+	assert.Equal(t, innerSrc, fmsg.SrcAddr)
+	assert.Equal(t, uint32(17), fmsg.Proto)
+	assert.Equal(t, flowmessage.FlowMessage_TUNNEL_IP_IN_IP, fmsg.TunnelType)
+
+	// inner IPv4 header truncated: the outer headers are kept
+	truncated := vxlan[:14+20+8+8+14+10]
+	fmsg = flowmessage.FlowMessage{}
+	ParseEthernetHeader(&fmsg, truncated, config.SFlow)
+	assert.Equal(t, outerSrc, fmsg.SrcAddr)
+	assert.Equal(t, outerDst, fmsg.DstAddr)
+	assert.Equal(t, uint32(0x800), fmsg.Etype)
+	assert.Equal(t, uint32(17), fmsg.Proto)
+	assert.Equal(t, uint32(4789), fmsg.DstPort)
+	assert.Equal(t, uint64(0x00005e000102), fmsg.SrcMac)
+	assert.Equal(t, flowmessage.FlowMessage_TUNNEL_NONE, fmsg.TunnelType)
+	assert.Equal(t, uint32(0), fmsg.TunnelVni)
+	assert.Equal(t, uint32(0), fmsg.TunnelDepth)
+	assert.Nil(t, fmsg.TunnelSrcAddr)
+
+	// inner Ethernet header carrying ARP
+	arp := append(append([]byte{}, vxlan[:14+20+8+8]...), buildEthernetHeader(0x0806)...)
+	arp = append(arp, make([]byte, 28)...)
+	fmsg = flowmessage.FlowMessage{}
+	ParseEthernetHeader(&fmsg, arp, config.SFlow)
+	assert.Equal(t, outerSrc, fmsg.SrcAddr)
+	assert.Equal(t, uint32(0x800), fmsg.Etype)
+	assert.Equal(t, flowmessage.FlowMessage_TUNNEL_NONE, fmsg.TunnelType)
+
+	// truncated headers must not panic
+	for i := range vxlan {
+		fmsg = flowmessage.FlowMessage{}
+		ParseEthernetHeader(&fmsg, vxlan[:i], config.SFlow)
+	}
+}
+
+func TestParseEthernetHeaderTCPPayload(t *testing.T) {
+	config := NewProducerConfigMapped(&ProducerConfig{
+		SFlow: SFlowProducerConfig{
+			Mapping: []SFlowMapField{
+				SFlowMapField{
+					Layer:       7,
+					Offset:      0,
+					Length:      16,
+					Destination: "CustomInteger_1",
+				},
+			},
+		},
+	})
+
+	// TCP header of 24 bytes (data offset 6) with an option
+	data := buildEthernetHeader(0x0800)
+	data = append(data, buildIPv4Header(6, []byte{10, 0, 0, 1}, []byte{10, 0, 0, 2})...)
+	data = append(data, 0x04, 0xd2, 0x00, 0x50, 0, 0, 0, 0, 0, 0, 0, 0, 0x60, 0x18, 0, 0, 0, 0, 0, 0)
+	data = append(data, 0x02, 0x04, 0x05, 0xb4) // MSS
+	data = append(data, 0x12, 0x34, 0x56, 0x78)
+
+	var fmsg flowmessage.FlowMessage
+	ParseEthernetHeader(&fmsg, data, config.SFlow)
+	assert.Equal(t, uint32(80), fmsg.DstPort)
+	assert.Equal(t, uint64(0x1234), fmsg.CustomInteger_1)
+}
+
+func FuzzParseEthernetHeader(f *testing.F) {
+	vxlan := buildEthernetHeader(0x0800)
+	vxlan = append(vxlan, buildIPv4Header(17, []byte{192, 0, 2, 1}, []byte{192, 0, 2, 2})...)
+	vxlan = append(vxlan, 0xc0, 0x00, 0x12, 0xb5, 0x00, 0x00, 0x00, 0x00)
+	vxlan = append(vxlan, 0x08, 0x00, 0x00, 0x00, 0x00, 0x13, 0x88, 0x00)
+	vxlan = append(vxlan, buildEthernetHeader(0x8100)...)
+	vxlan = append(vxlan, 0x00, 0x64, 0x86, 0xdd)
+	vxlan = append(vxlan, buildIPv6Header(6, make([]byte, 16), make([]byte, 16))...)
+	vxlan = append(vxlan, 0x04, 0xd2, 0x01, 0xbb, 0, 0, 0, 0, 0, 0, 0, 0, 0x50, 0x18, 0, 0, 0, 0, 0, 0)
+	vxlan = append(vxlan, 0x16, 0x03, 0x01, 0x00, 0x10, 0x01)
+	f.Add(vxlan)
+
+	mpls := buildEthernetHeader(0x8847)
+	mpls = append(mpls, 0x00, 0x01, 0x01, 0x40)
+	mpls = append(mpls, buildIPv4Header(47, []byte{10, 0, 0, 1}, []byte{10, 0, 0, 2})...)
+	mpls = append(mpls, 0xb0, 0x00, 0x65, 0x58, 0, 0, 0, 0, 0, 0, 0, 0x2a, 0, 0, 0, 1)
+	mpls = append(mpls, buildEthernetHeader(0x0800)...)
+	f.Add(mpls)
+
+	config := NewProducerConfigMapped(&ProducerConfig{
+		SFlow: SFlowProducerConfig{
+			DecapDepth: 3,
+			Dissectors: SFlowDissectorConfig{DNS: true, TLS: true, HTTP: true, QUIC: true},
+			Mapping: []SFlowMapField{
+				{Layer: 3, Offset: 0, Length: 4, Destination: "CustomInteger1"},
+				{Layer: 4, Offset: 32, Length: 16, Destination: "CustomInteger2"},
+				{Layer: 7, Offset: 0, Length: 64, Destination: "CustomBytes1"},
+			},
+		},
+	})
+	f.Fuzz(func(t *testing.T, data []byte) {
+		var fmsg flowmessage.FlowMessage
+		ParseEthernetHeader(&fmsg, data, config.SFlow)
+	})
+}
+
+func TestParseEthernetHeaderStackedVlans(t *testing.T) {
+	for _, outerEtherType := range []uint16{0x88a8, 0x9100, 0x8100} {
+		data := buildEthernetHeader(outerEtherType)
+		data = append(data, 0x60, 0x64, 0x81, 0x00) // PCP 3, VLAN 100
+		data = append(data, 0xa0, 0xc8, 0x08, 0x00) // PCP 5, VLAN 200
+		data = append(data, buildIPv4Header(6, []byte{10, 0, 0, 1}, []byte{10, 0, 0, 2})...)
+		data = append(data, 0x04, 0xd2, 0x00, 0x50)
+
+		var fmsg flowmessage.FlowMessage
+		ParseEthernetHeader(&fmsg, data, nil)
+		assert.Equal(t, uint32(0x800), fmsg.Etype)
+		assert.Equal(t, []byte{10, 0, 0, 1}, fmsg.SrcAddr)
+		assert.Equal(t, uint32(80), fmsg.DstPort)
+		assert.Equal(t, uint32(100), fmsg.OuterVlanId)
+		assert.Equal(t, uint32(3), fmsg.OuterVlanPcp)
+		assert.Equal(t, uint32(200), fmsg.InnerVlanId)
+		assert.Equal(t, uint32(5), fmsg.InnerVlanPcp)
+		assert.Equal(t, uint32(200), fmsg.VlanId)
+	}
+
+	// single tag
+	data := buildEthernetHeader(0x8100)
+	data = append(data, 0x20, 0x0a, 0x86, 0xdd) // PCP 1, VLAN 10
+	var fmsg flowmessage.FlowMessage
+	ParseEthernetHeader(&fmsg, data, nil)
+	assert.Equal(t, uint32(0x86dd), fmsg.Etype)
+	assert.Equal(t, uint32(10), fmsg.VlanId)
+	assert.Equal(t, uint32(10), fmsg.OuterVlanId)
+	assert.Equal(t, uint32(1), fmsg.OuterVlanPcp)
+	assert.Equal(t, uint32(0), fmsg.InnerVlanId)
+}
+
+func buildIPv6Header(nextHeader byte, src, dst []byte) []byte {
+	header := []byte{0x60, 0x00, 0x00, 0x00, 0x00, 0x00, nextHeader, 0x40}
+	header = append(header, src...)
+	return append(header, dst...)
+}
+
+func TestParseEthernetHeaderIPv6Extensions(t *testing.T) {
+	src := []byte{0x20, 0x01, 0x0d, 0xb8, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 1}
+	dst := []byte{0x20, 0x01, 0x0d, 0xb8, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 2}
+	segment1 := []byte{0xfc, 0x00, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 1}
+	segment2 := []byte{0xfc, 0x00, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 2}
+
+	// hop-by-hop, SRH with two segments, fragment (first), TCP
+	data := buildEthernetHeader(0x86dd)
+	data = append(data, buildIPv6Header(0, src, dst)...)
+	data = append(data, 43, 0, 1, 4, 0, 0, 0, 0) // hop-by-hop with padding
+	data = append(data, 44, 4, 4, 1, 1, 0, 0, 0) // SRH, segments left 1, last entry 1
+	data = append(data, segment1...)
+	data = append(data, segment2...)
+	data = append(data, 6, 0, 0x00, 0x01, 0, 0, 0x30, 0x39) // fragment offset 0, more fragments, id 12345
+	data = append(data, 0x04, 0xd2, 0x00, 0x50, 0, 0, 0, 0, 0, 0, 0, 0, 0x50, 0x12)
+
+	var fmsg flowmessage.FlowMessage
+	ParseEthernetHeader(&fmsg, data, nil)
+	assert.Equal(t, uint32(6), fmsg.Proto)
+	assert.Equal(t, uint32(1234), fmsg.SrcPort)
+	assert.Equal(t, uint32(80), fmsg.DstPort)
+	assert.Equal(t, uint32(0x12), fmsg.TcpFlags)
+	assert.Equal(t, uint32(12345), fmsg.FragmentId)
+	assert.Equal(t, uint32(0), fmsg.FragmentOffset)
+	assert.Equal(t, [][]byte{segment1, segment2}, fmsg.Srv6Segments)
+	assert.Equal(t, uint32(1), fmsg.Srv6SegmentsLeft)
+
+	// non-first fragment: no transport header
+	data = buildEthernetHeader(0x86dd)
+	data = append(data, buildIPv6Header(44, src, dst)...)
+	data = append(data, 17, 0, 0x00, 0xb8, 0, 0, 0, 1) // fragment offset 23
+	data = append(data, 0x04, 0xd2, 0x00, 0x35)
+
+	fmsg = flowmessage.FlowMessage{}
+	ParseEthernetHeader(&fmsg, data, nil)
+	assert.Equal(t, uint32(17), fmsg.Proto)
+	assert.Equal(t, uint32(1), fmsg.FragmentId)
+	assert.Equal(t, uint32(23), fmsg.FragmentOffset)
+	assert.Equal(t, uint32(0), fmsg.SrcPort)
+	assert.Equal(t, uint32(0), fmsg.DstPort)
+
+	// truncated chains must not panic
+	for i := range data {
+		fmsg = flowmessage.FlowMessage{}
+		ParseEthernetHeader(&fmsg, data[:i], nil)
+	}
+}
+
+func TestParseEthernetHeaderIPv4Options(t *testing.T) {
+	header := buildIPv4Header(17, []byte{10, 0, 0, 1}, []byte{10, 0, 0, 2})
+	header[0] = 0x46 // IHL of 24 bytes
+	data := buildEthernetHeader(0x0800)
+	data = append(data, header...)
+	data = append(data, 0x94, 0x04, 0x00, 0x00) // router alert option
+	data = append(data, 0x04, 0xd2, 0x00, 0x35)
+
+	var fmsg flowmessage.FlowMessage
+	ParseEthernetHeader(&fmsg, data, nil)
+	assert.Equal(t, uint32(1234), fmsg.SrcPort)
+	assert.Equal(t, uint32(53), fmsg.DstPort)
+}
+
+func buildTLSClientHello(serverName string) []byte {
+	name := []byte(serverName)
+	serverNameExtension := []byte{0x00, 0x00, 0x00, byte(len(name) + 5), 0x00, byte(len(name) + 3), 0x00, 0x00, byte(len(name))}
+	serverNameExtension = append(serverNameExtension, name...)
+	otherExtension := []byte{0x00, 0x17, 0x00, 0x00} // extended master secret
+
+	hello := []byte{0x03, 0x03}
+	hello = append(hello, make([]byte, 32)...) // random
+	hello = append(hello, 0x00)                // session id
+	hello = append(hello, 0x00, 0x02, 0x13, 0x01)
+	hello = append(hello, 0x01, 0x00) // compression
+	extensionsLength := len(otherExtension) + len(serverNameExtension)
+	hello = append(hello, byte(extensionsLength>>8), byte(extensionsLength))
+	hello = append(hello, otherExtension...)
+	hello = append(hello, serverNameExtension...)
+
+	handshake := append([]byte{0x01, 0x00, byte(len(hello) >> 8), byte(len(hello))}, hello...)
+	return append([]byte{0x16, 0x03, 0x01, byte(len(handshake) >> 8), byte(len(handshake))}, handshake...)
+}
+
+func TestDissectors(t *testing.T) {
+	dnsQuery := []byte{0x12, 0x34, 0x01, 0x00, 0x00, 0x01, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00,
+		3, 'w', 'w', 'w', 7, 'e', 'x', 'a', 'm', 'p', 'l', 'e', 3, 'c', 'o', 'm', 0, 0x00, 0x01, 0x00, 0x01}
+	qname, ok := DissectDNSQueryName(dnsQuery)
+	assert.True(t, ok)
+	assert.Equal(t, "www.example.com", qname)
+	_, ok = DissectDNSQueryName(dnsQuery[:20])
+	assert.False(t, ok)
+
+	clientHello := buildTLSClientHello("example.net")
+	sni, ok := DissectTLSServerName(clientHello)
+	assert.True(t, ok)
+	assert.Equal(t, "example.net", sni)
+	for i := range clientHello {
+		_, ok = DissectTLSServerName(clientHello[:i])
+		assert.False(t, ok)
+	}
+
+	method, host, ok := DissectHTTPRequest([]byte("POST /api HTTP/1.1\r\nUser-Agent: test\r\nhost:  example.org \r\nAccept: */*\r\n\r\n"))
+	assert.True(t, ok)
+	assert.Equal(t, "POST", method)
+	assert.Equal(t, "example.org", host)
+	method, host, ok = DissectHTTPRequest([]byte("GET / HTTP/1.1\r\nHost: exam"))
+	assert.True(t, ok)
+	assert.Equal(t, "GET", method)
+	assert.Equal(t, "", host)
+	_, _, ok = DissectHTTPRequest([]byte("HTTP/1.1 200 OK\r\n"))
+	assert.False(t, ok)
+
+	version, ok := DissectQUICVersion([]byte{0xc3, 0x00, 0x00, 0x00, 0x01, 0x08})
+	assert.True(t, ok)
+	assert.Equal(t, uint32(1), version)
+	_, ok = DissectQUICVersion([]byte{0x43, 0x00, 0x00, 0x00, 0x01})
+	assert.False(t, ok)
+}
+
+func TestParseEthernetHeaderDissectors(t *testing.T) {
+	src := []byte{10, 0, 0, 1}
+	dst := []byte{10, 0, 0, 2}
+	config := NewProducerConfigMapped(&ProducerConfig{SFlow: SFlowProducerConfig{
+		Dissectors: SFlowDissectorConfig{DNS: true, TLS: true, HTTP: true},
+	}})
+
+	dns := buildEthernetHeader(0x0800)
+	dns = append(dns, buildIPv4Header(17, src, dst)...)
+	dns = append(dns, 0xc0, 0x00, 0x00, 0x35, 0x00, 0x00, 0x00, 0x00)
+	dns = append(dns, 0x12, 0x34, 0x01, 0x00, 0x00, 0x01, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00,
+		7, 'e', 'x', 'a', 'm', 'p', 'l', 'e', 0, 0x00, 0x01, 0x00, 0x01)
+
+	var fmsg flowmessage.FlowMessage
+	ParseEthernetHeader(&fmsg, dns, config.SFlow)
+	assert.Equal(t, "example", fmsg.DnsQname)
+
+	// disabled by default
+	fmsg = flowmessage.FlowMessage{}
+	ParseEthernetHeader(&fmsg, dns, nil)
+	assert.Equal(t, "", fmsg.DnsQname)
+
+	tls := buildEthernetHeader(0x0800)
+	tls = append(tls, buildIPv4Header(6, src, dst)...)
+	tls = append(tls, 0xc0, 0x00, 0x01, 0xbb, 0, 0, 0, 0, 0, 0, 0, 0, 0x50, 0x18, 0, 0, 0, 0, 0, 0)
+	tls = append(tls, buildTLSClientHello("example.com")...)
+
+	fmsg = flowmessage.FlowMessage{}
+	ParseEthernetHeader(&fmsg, tls, config.SFlow)
+	assert.Equal(t, "example.com", fmsg.TlsSni)
+	assert.Equal(t, "", fmsg.HttpMethod)
+}
+
+func TestParseSampledHeaderRaw(t *testing.T) {
+	sh := sflow.SampledHeader{
+		Protocol:    1,
+		FrameLength: 1518,
+		Stripped:    4,
+		HeaderData:  append(buildEthernetHeader(0x0800), buildIPv4Header(6, []byte{10, 0, 0, 1}, []byte{10, 0, 0, 2})...),
+	}
+
+	var fmsg flowmessage.FlowMessage
+	assert.Nil(t, ParseSampledHeaderConfig(&fmsg, &sh, nil))
+	assert.Nil(t, fmsg.RawHeader)
+
+	config := NewProducerConfigMapped(&ProducerConfig{SFlow: SFlowProducerConfig{RawHeader: true}})
+	fmsg = flowmessage.FlowMessage{}
+	assert.Nil(t, ParseSampledHeaderConfig(&fmsg, &sh, config.SFlow))
+	assert.Equal(t, sh.HeaderData, fmsg.RawHeader)
+	assert.Equal(t, uint32(1518), fmsg.RawHeaderFrameLength)
+	assert.Equal(t, uint32(4), fmsg.RawHeaderStripped)
+	assert.Equal(t, uint32(1), fmsg.RawHeaderProtocol)
+	assert.Equal(t, []byte{10, 0, 0, 1}, fmsg.SrcAddr)
+}
+
+func TestConvertNetFlowLegacyRecordTime(t *testing.T) {
+	record := netflowlegacy.RecordsNetFlowV5{
+		First: 0xffffff00, // before the wrap-around of the uptime
+		Last:  1000,
+	}
+	fmsg := ConvertNetFlowLegacyRecord(1700000000, 500000000, 1500, record)
+	assert.Equal(t, uint64(1700000000500-1500-256), fmsg.TimeFlowStartMs)
+	assert.Equal(t, uint64(1699999998), fmsg.TimeFlowStart)
+	assert.Equal(t, uint64(1700000000000), fmsg.TimeFlowEndMs)
+	assert.Equal(t, uint64(1700000000), fmsg.TimeFlowEnd)
+}
+
+func TestConvertNetFlowDataSetTime(t *testing.T) {
+	u32 := func(v uint32) []byte { return []byte{byte(v >> 24), byte(v >> 16), byte(v >> 8), byte(v)} }
+	u64 := func(v uint64) []byte { return append(u32(uint32(v>>32)), u32(uint32(v))...) }
+	// 2023-11-14T22:13:20.250Z in NTP format
+	ntp := uint64(1700000000+2208988800)<<32 | uint64(0x40000000)
+
+	tests := []struct {
+		name         string
+		version      uint16
+		uptime       uint32
+		systemInitMs uint64
+		record       []netflow.DataField
+		startMs      uint64
+		endMs        uint64
+	}{
+		{
+			name:    "v9 uptime",
+			version: 9,
+			uptime:  10000,
+			record: []netflow.DataField{
+				{Type: netflow.NFV9_FIELD_FIRST_SWITCHED, Value: u32(4000)},
+				{Type: netflow.NFV9_FIELD_LAST_SWITCHED, Value: u32(9000)},
+			},
+			startMs: 1700000000000 - 6000,
+			endMs:   1700000000000 - 1000,
+		},
+		{
+			name:    "seconds",
+			version: 10,
+			record: []netflow.DataField{
+				{Type: netflow.IPFIX_FIELD_flowStartSeconds, Value: u32(1699999990)},
+				{Type: netflow.IPFIX_FIELD_flowEndSeconds, Value: u32(1699999995)},
+			},
+			startMs: 1699999990000,
+			endMs:   1699999995000,
+		},
+		{
+			name:    "milliseconds",
+			version: 10,
+			record: []netflow.DataField{
+				{Type: netflow.IPFIX_FIELD_flowStartMilliseconds, Value: u64(1699999990123)},
+				{Type: netflow.IPFIX_FIELD_flowEndMilliseconds, Value: u64(1699999995456)},
+			},
+			startMs: 1699999990123,
+			endMs:   1699999995456,
+		},
+		{
+			name:    "ntp microseconds and nanoseconds",
+			version: 10,
+			record: []netflow.DataField{
+				{Type: netflow.IPFIX_FIELD_flowStartMicroseconds, Value: u64(ntp)},
+				{Type: netflow.IPFIX_FIELD_flowEndNanoseconds, Value: u64(ntp + 1<<32)},
+			},
+			startMs: 1700000000250,
+			endMs:   1700000001250,
+		},
+		{
+			name:    "unix microseconds and nanoseconds",
+			version: 10,
+			record: []netflow.DataField{
+				{Type: netflow.IPFIX_FIELD_flowStartMicroseconds, Value: u64(1699999990123456)},
+				{Type: netflow.IPFIX_FIELD_flowEndNanoseconds, Value: u64(1699999995456789012)},
+			},
+			startMs: 1699999990123,
+			endMs:   1699999995456,
+		},
+		{
+			name:    "delta microseconds",
+			version: 10,
+			record: []netflow.DataField{
+				{Type: netflow.IPFIX_FIELD_flowStartDeltaMicroseconds, Value: u32(5000000)},
+				{Type: netflow.IPFIX_FIELD_flowEndDeltaMicroseconds, Value: u32(1500000)},
+			},
+			startMs: 1699999995000,
+			endMs:   1699999998500,
+		},
+		{
+			name:         "sysuptime with options init time",
+			version:      10,
+			systemInitMs: 1699990000000,
+			record: []netflow.DataField{
+				{Type: netflow.IPFIX_FIELD_flowStartSysUpTime, Value: u32(4000)},
+				{Type: netflow.IPFIX_FIELD_flowEndSysUpTime, Value: u32(9000)},
+			},
+			startMs: 1699990004000,
+			endMs:   1699990009000,
+		},
+		{
+			name:         "sysuptime with record init time",
+			version:      10,
+			systemInitMs: 1699990000000,
+			record: []netflow.DataField{
+				{Type: netflow.IPFIX_FIELD_flowStartSysUpTime, Value: u32(4000)},
+				{Type: netflow.IPFIX_FIELD_flowEndSysUpTime, Value: u32(9000)},
+				{Type: netflow.IPFIX_FIELD_systemInitTimeMilliseconds, Value: u64(1699980000000)},
+			},
+			startMs: 1699980004000,
+			endMs:   1699980009000,
+		},
+		{
+			name:    "sysuptime without init time",
+			version: 10,
+			record: []netflow.DataField{
+				{Type: netflow.IPFIX_FIELD_flowStartSysUpTime, Value: u32(4000)},
+			},
+		},
+		{
+			name:    "duration",
+			version: 10,
+			record: []netflow.DataField{
+				{Type: netflow.IPFIX_FIELD_flowDurationMilliseconds, Value: u32(2500)},
+				{Type: netflow.IPFIX_FIELD_flowEndMilliseconds, Value: u64(1699999995000)},
+			},
+			startMs: 1699999992500,
+			endMs:   1699999995000,
+		},
+		{
+			name:    "observation time",
+			version: 10,
+			record: []netflow.DataField{
+				{Type: netflow.IPFIX_FIELD_observationTimeMilliseconds, Value: u64(1699999990123)},
+			},
+			startMs: 1699999990123,
+			endMs:   1699999990123,
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			fmsg := ConvertNetFlowDataSet(test.version, 1700000000, test.uptime, test.systemInitMs, test.record, nil, nil)
+			assert.Equal(t, test.startMs, fmsg.TimeFlowStartMs)
+			assert.Equal(t, test.startMs/1000, fmsg.TimeFlowStart)
+			assert.Equal(t, test.endMs, fmsg.TimeFlowEndMs)
+			assert.Equal(t, test.endMs/1000, fmsg.TimeFlowEnd)
+		})
+	}
+}
+
+func TestProcessMessageNetFlowSystemInitTime(t *testing.T) {
+	samplingRateSys := CreateSamplingSystem()
+	options := netflow.OptionsDataFlowSet{
+		Records: []netflow.OptionsDataRecord{
+			{
+				OptionsValues: []netflow.DataField{
+					{Type: netflow.IPFIX_FIELD_systemInitTimeMilliseconds, Value: []byte{0, 0, 1, 0x8b, 0xcf, 0xe5, 0x68, 0}},
+				},
+			},
+		},
+	}
+	data := netflow.DataFlowSet{
+		Records: []netflow.DataRecord{
+			{
+				Values: []netflow.DataField{
+					{Type: netflow.IPFIX_FIELD_flowStartSysUpTime, Value: []byte{0, 0, 0x03, 0xe8}},
+				},
+			},
+		},
+	}
+
+	// the initialization time is kept for the following packets of the exporter
+	_, err := ProcessMessageNetFlowConfig(netflow.IPFIXPacket{ObservationDomainId: 1, FlowSets: []interface{}{options}}, samplingRateSys, nil)
+	assert.Nil(t, err)
+	fmsgs, err := ProcessMessageNetFlowConfig(netflow.IPFIXPacket{ObservationDomainId: 1, FlowSets: []interface{}{data}}, samplingRateSys, nil)
+	assert.Nil(t, err)
+	if assert.Len(t, fmsgs, 1) {
+		assert.Equal(t, uint64(0x18bcfe56800+1000), fmsgs[0].TimeFlowStartMs)
+	}
+
+	fmsgs, err = ProcessMessageNetFlowConfig(netflow.IPFIXPacket{ObservationDomainId: 2, FlowSets: []interface{}{data}}, samplingRateSys, nil)
+	assert.Nil(t, err)
+	if assert.Len(t, fmsgs, 1) {
+		assert.Equal(t, uint64(0), fmsgs[0].TimeFlowStartMs)
+	}
+}
+
+func TestConvertNetFlowDataSetBiFlow(t *testing.T) {
+	records := []netflow.DataRecord{
+		{
+			Values: []netflow.DataField{
+				{Type: netflow.IPFIX_FIELD_sourceIPv4Address, Value: []byte{10, 0, 0, 1}},
+				{Type: netflow.IPFIX_FIELD_destinationIPv4Address, Value: []byte{10, 0, 0, 2}},
+				{Type: netflow.IPFIX_FIELD_sourceTransportPort, Value: []byte{0xc0, 0x00}},
+				{Type: netflow.IPFIX_FIELD_destinationTransportPort, Value: []byte{0, 80}},
+				{Type: netflow.IPFIX_FIELD_ingressInterface, Value: []byte{0, 0, 0, 1}},
+				{Type: netflow.IPFIX_FIELD_egressInterface, Value: []byte{0, 0, 0, 2}},
+				{Type: netflow.IPFIX_FIELD_octetDeltaCount, Value: []byte{0, 0, 0, 100}},
+				{Type: netflow.IPFIX_FIELD_packetDeltaCount, Value: []byte{0, 0, 0, 2}},
+				{Type: netflow.IPFIX_FIELD_tcpControlBits, Value: []byte{0, 0x02}},
+				{PenProvided: true, Pen: netflow.IPFIX_PEN_REVERSE, Type: netflow.IPFIX_FIELD_octetDeltaCount, Value: []byte{0, 0, 0x03, 0xe8}},
+				{PenProvided: true, Pen: netflow.IPFIX_PEN_REVERSE, Type: netflow.IPFIX_FIELD_packetDeltaCount, Value: []byte{0, 0, 0, 3}},
+				{PenProvided: true, Pen: netflow.IPFIX_PEN_REVERSE, Type: netflow.IPFIX_FIELD_tcpControlBits, Value: []byte{0, 0x12}},
+			},
+		},
+		{
+			Values: []netflow.DataField{
+				{Type: netflow.IPFIX_FIELD_octetDeltaCount, Value: []byte{0, 0, 0, 100}},
+				{PenProvided: true, Pen: netflow.IPFIX_PEN_REVERSE, Type: netflow.IPFIX_FIELD_octetDeltaCount, Value: []byte{0, 0, 0, 0}},
+			},
+		},
+	}
+
+	fmsgs := SearchNetFlowDataSetsRecords(10, 0, 0, 0, records, nil, nil)
+	if assert.Len(t, fmsgs, 2) {
+		assert.Equal(t, uint64(100), fmsgs[0].Bytes)
+		assert.Equal(t, uint64(1000), fmsgs[0].ReverseBytes)
+		assert.Equal(t, uint64(3), fmsgs[0].ReversePackets)
+		assert.Equal(t, uint32(0x12), fmsgs[0].ReverseTcpFlags)
+	}
+
+	mapped := NewProducerConfigMapped(&ProducerConfig{
+		IPFIX: IPFIXProducerConfig{BiFlow: BIFLOW_SPLIT, AllFields: true},
+	})
+	fmsgs = SearchNetFlowDataSetsRecords(10, 0, 0, 0, records, mapped.IPFIX, mapped.SFlow)
+	if assert.Len(t, fmsgs, 3) {
+		forward, reverse := fmsgs[0], fmsgs[1]
+		assert.Equal(t, uint64(100), forward.Bytes)
+		assert.Equal(t, uint64(2), forward.Packets)
+		assert.Equal(t, uint32(0x02), forward.TcpFlags)
+		assert.Equal(t, uint64(0), forward.ReverseBytes)
+
+		assert.Equal(t, []byte{10, 0, 0, 2}, reverse.SrcAddr)
+		assert.Equal(t, []byte{10, 0, 0, 1}, reverse.DstAddr)
+		assert.Equal(t, uint32(80), reverse.SrcPort)
+		assert.Equal(t, uint32(0xc000), reverse.DstPort)
+		assert.Equal(t, uint32(2), reverse.InIf)
+		assert.Equal(t, uint32(1), reverse.OutIf)
+		assert.Equal(t, uint64(1000), reverse.Bytes)
+		assert.Equal(t, uint64(3), reverse.Packets)
+		assert.Equal(t, uint32(0x12), reverse.TcpFlags)
+		assert.Equal(t, uint64(0), reverse.ReverseBytes)
+		assert.Equal(t, "1000", reverse.AllFields["reverseOctetDeltaCount"])
+
+		// no reverse message when no packet was observed in the reverse direction
+		assert.Equal(t, uint64(100), fmsgs[2].Bytes)
+	}
+}
+
+func TestConvertNetFlowDataSetBiFlowDirection(t *testing.T) {
+	// the destination initiated the connection
+	records := []netflow.DataRecord{
+		{
+			Values: []netflow.DataField{
+				{Type: netflow.IPFIX_FIELD_sourceIPv4Address, Value: []byte{10, 0, 0, 2}},
+				{Type: netflow.IPFIX_FIELD_destinationIPv4Address, Value: []byte{10, 0, 0, 1}},
+				{Type: netflow.IPFIX_FIELD_sourceTransportPort, Value: []byte{0, 80}},
+				{Type: netflow.IPFIX_FIELD_destinationTransportPort, Value: []byte{0xc0, 0x00}},
+				{Type: netflow.IPFIX_FIELD_ipNextHopIPv4Address, Value: []byte{10, 0, 0, 254}},
+				{Type: netflow.IPFIX_FIELD_octetDeltaCount, Value: []byte{0, 0, 0x03, 0xe8}},
+				{Type: netflow.IPFIX_FIELD_ipClassOfService, Value: []byte{0x20}},
+				{Type: netflow.IPFIX_FIELD_flowStartMilliseconds, Value: []byte{0, 0, 0x01, 0x86, 0x9b, 0x1a, 0x14, 0x00}},
+				{Type: netflow.IPFIX_FIELD_flowEndMilliseconds, Value: []byte{0, 0, 0x01, 0x86, 0x9b, 0x1a, 0x17, 0xe8}},
+				{Type: netflow.IPFIX_FIELD_biflowDirection, Value: []byte{BIFLOW_DIRECTION_REVERSE_INITIATOR}},
+				{PenProvided: true, Pen: netflow.IPFIX_PEN_REVERSE, Type: netflow.IPFIX_FIELD_octetDeltaCount, Value: []byte{0, 0, 0, 100}},
+				{PenProvided: true, Pen: netflow.IPFIX_PEN_REVERSE, Type: netflow.IPFIX_FIELD_ipClassOfService, Value: []byte{0x10}},
+				{PenProvided: true, Pen: netflow.IPFIX_PEN_REVERSE, Type: netflow.IPFIX_FIELD_minimumTTL, Value: []byte{64}},
+				{PenProvided: true, Pen: netflow.IPFIX_PEN_REVERSE, Type: netflow.IPFIX_FIELD_flowStartMilliseconds, Value: []byte{0, 0, 0x01, 0x86, 0x9b, 0x1a, 0x13, 0xff}},
+			},
+		},
+	}
+
+	fmsgs := SearchNetFlowDataSetsRecords(10, 0, 0, 0, records, nil, nil)
+	if assert.Len(t, fmsgs, 1) {
+		fmsg := fmsgs[0]
+		assert.Equal(t, uint32(BIFLOW_DIRECTION_INITIATOR), fmsg.BiFlowDirection)
+		assert.Equal(t, []byte{10, 0, 0, 1}, fmsg.SrcAddr)
+		assert.Equal(t, uint32(0xc000), fmsg.SrcPort)
+		assert.Nil(t, fmsg.NextHop)
+		assert.Equal(t, uint64(100), fmsg.Bytes)
+		assert.Equal(t, uint32(0x10), fmsg.IpTos)
+		assert.Equal(t, uint32(64), fmsg.IpTtl)
+		assert.Equal(t, uint64(1677639422975), fmsg.TimeFlowStartMs)
+		assert.Equal(t, uint64(1677639423976), fmsg.TimeFlowEndMs, "end of the record")
+		assert.Equal(t, uint64(1000), fmsg.ReverseBytes)
+		assert.Equal(t, uint32(0x20), fmsg.ReverseIpTos)
+		assert.Equal(t, uint64(1677639422976), fmsg.ReverseTimeFlowStartMs)
+	}
+
+	mapped := NewProducerConfigMapped(&ProducerConfig{
+		IPFIX: IPFIXProducerConfig{BiFlow: BIFLOW_SPLIT},
+	})
+	fmsgs = SearchNetFlowDataSetsRecords(10, 0, 0, 0, records, mapped.IPFIX, mapped.SFlow)
+	if assert.Len(t, fmsgs, 2) {
+		initiator, responder := fmsgs[0], fmsgs[1]
+		assert.Equal(t, []byte{10, 0, 0, 1}, initiator.SrcAddr)
+		assert.Equal(t, uint64(100), initiator.Bytes)
+		assert.Equal(t, uint64(0), initiator.ReverseBytes)
+		assert.Equal(t, uint64(0), initiator.ReverseTimeFlowStartMs)
+		assert.Equal(t, []byte{10, 0, 0, 2}, responder.SrcAddr)
+		assert.Equal(t, uint64(1000), responder.Bytes)
+		assert.Equal(t, uint32(0x20), responder.IpTos)
+		assert.Equal(t, uint64(1677639422976), responder.TimeFlowStartMs)
+		assert.Equal(t, uint32(0), responder.ReverseIpTos)
+	}
+}
+
+func TestProducerConfigValidate(t *testing.T) {
+	assert.NoError(t, (&ProducerConfig{IPFIX: IPFIXProducerConfig{BiFlow: BIFLOW_SPLIT}}).Validate())
+	assert.Error(t, (&ProducerConfig{IPFIX: IPFIXProducerConfig{BiFlow: "splt"}}).Validate())
+}
+
+func TestConvertNetFlowDataSetNAT(t *testing.T) {
+	// Cisco ASA NSEL record (NetFlow v9)
+	record := []netflow.DataField{
+		{Type: netflow.NFV9_FIELD_IPV4_SRC_ADDR, Value: []byte{10, 0, 0, 1}},
+		{Type: netflow.IPFIX_FIELD_postNATSourceIPv4Address, Value: []byte{192, 0, 2, 1}},
+		{Type: netflow.IPFIX_FIELD_postNATDestinationIPv4Address, Value: []byte{198, 51, 100, 1}},
+		{Type: netflow.IPFIX_FIELD_postNAPTSourceTransportPort, Value: []byte{0xc0, 0x01}},
+		{Type: netflow.IPFIX_FIELD_postNAPTDestinationTransportPort, Value: []byte{0x01, 0xbb}},
+		{Type: netflow.IPFIX_FIELD_flowId, Value: []byte{0, 0, 0x30, 0x39}},
+		{Type: netflow.IPFIX_FIELD_firewallEvent, Value: []byte{2}},
+		{Type: netflow.NFV9_FIELD_NSEL_FW_EXT_EVENT, Value: []byte{0x07, 0xd1}},
+		{Type: netflow.NFV9_FIELD_NSEL_INGRESS_ACL_ID, Value: []byte{0, 0, 0, 1, 0, 0, 0, 2, 0, 0, 0, 0}},
+		{Type: netflow.NFV9_FIELD_NSEL_EGRESS_ACL_ID, Value: []byte{0, 0, 0, 3, 0, 0, 0, 4, 0, 0, 0, 0}},
+		{Type: netflow.NFV9_FIELD_NSEL_USERNAME, Value: []byte{'a', 'l', 'i', 'c', 'e', 0, 0, 0}},
+		{Type: netflow.IPFIX_FIELD_initiatorOctets, Value: []byte{0, 0, 0, 100}},
+		{Type: netflow.IPFIX_FIELD_responderOctets, Value: []byte{0, 0, 0x03, 0xe8}},
+	}
+	fmsg := ConvertNetFlowDataSet(9, 0, 0, 0, record, nil, nil)
+	assert.Equal(t, []byte{10, 0, 0, 1}, fmsg.SrcAddr)
+	assert.Equal(t, []byte{192, 0, 2, 1}, fmsg.PostNatSrcAddr)
+	assert.Equal(t, []byte{198, 51, 100, 1}, fmsg.PostNatDstAddr)
+	assert.Equal(t, uint32(0xc001), fmsg.PostNatSrcPort)
+	assert.Equal(t, uint32(443), fmsg.PostNatDstPort)
+	assert.Equal(t, uint64(12345), fmsg.ConnId)
+	assert.Equal(t, uint32(2), fmsg.FirewallEvent)
+	assert.Equal(t, uint32(2001), fmsg.FirewallExtEvent)
+	assert.Equal(t, uint32(1), fmsg.IngressAclId)
+	assert.Equal(t, uint32(2), fmsg.IngressAceId)
+	assert.Equal(t, uint32(3), fmsg.EgressAclId)
+	assert.Equal(t, uint32(4), fmsg.EgressAceId)
+	assert.Equal(t, "alice", fmsg.UserName)
+	assert.Equal(t, uint64(100), fmsg.Bytes)
+	assert.Equal(t, uint64(1000), fmsg.ReverseBytes)
+
+	// IPFIX NAT logging (RFC 8158)
+	record = []netflow.DataField{
+		{Type: netflow.IPFIX_FIELD_natEvent, Value: []byte{1}},
+		{Type: netflow.IPFIX_FIELD_natPoolId, Value: []byte{0, 0, 0, 5}},
+		{Type: netflow.IPFIX_FIELD_natPoolName, Value: []byte("pool1")},
+		{Type: netflow.IPFIX_FIELD_postNATSourceIPv6Address, Value: []byte{0x20, 0x01, 0x0d, 0xb8, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 1}},
+	}
+	fmsg = ConvertNetFlowDataSet(10, 0, 0, 0, record, nil, nil)
+	assert.Equal(t, uint32(1), fmsg.NatEvent)
+	assert.Equal(t, uint32(5), fmsg.NatPoolId)
+	assert.Equal(t, "pool1", fmsg.NatPoolName)
+	assert.Equal(t, []byte{0x20, 0x01, 0x0d, 0xb8, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 1}, fmsg.PostNatSrcAddr)
+
+	// legacy IDs of the ASA releases before 8.4(2)
+	record = []netflow.DataField{
+		{Type: netflow.NFV9_FIELD_NSEL_XLATE_SRC_ADDR_84, Value: []byte{192, 0, 2, 1}},
+		{Type: netflow.NFV9_FIELD_NSEL_XLATE_DST_ADDR_84, Value: []byte{198, 51, 100, 1}},
+		{Type: netflow.NFV9_FIELD_NSEL_XLATE_SRC_PORT_84, Value: []byte{0xc0, 0x01}},
+		{Type: netflow.NFV9_FIELD_NSEL_XLATE_DST_PORT_84, Value: []byte{0x01, 0xbb}},
+		{Type: netflow.NFV9_FIELD_NSEL_FW_EVENT_84, Value: []byte{3}},
+	}
+	fmsg = ConvertNetFlowDataSet(9, 0, 0, 0, record, nil, nil)
+	assert.Equal(t, []byte{192, 0, 2, 1}, fmsg.PostNatSrcAddr)
+	assert.Equal(t, []byte{198, 51, 100, 1}, fmsg.PostNatDstAddr)
+	assert.Equal(t, uint32(0xc001), fmsg.PostNatSrcPort)
+	assert.Equal(t, uint32(443), fmsg.PostNatDstPort)
+	assert.Equal(t, uint32(3), fmsg.FirewallEvent)
+}
+
+func TestProcessMessageNetFlowNames(t *testing.T) {
+	samplingRateSys := CreateSamplingSystem()
+	options := netflow.OptionsDataFlowSet{
+		Records: []netflow.OptionsDataRecord{
+			{
+				ScopesValues:  []netflow.DataField{{Type: netflow.IPFIX_FIELD_ingressInterface, Value: []byte{0, 0, 0, 1}}},
+				OptionsValues: []netflow.DataField{{Type: netflow.IPFIX_FIELD_interfaceName, Value: []byte("eth0\x00\x00")}},
+			},
+			{
+				ScopesValues: []netflow.DataField{{Type: netflow.IPFIX_FIELD_ingressInterface, Value: []byte{0, 0, 0, 2}}},
+				OptionsValues: []netflow.DataField{
+					{Type: netflow.IPFIX_FIELD_interfaceName, Value: []byte{}},
+					{Type: netflow.IPFIX_FIELD_interfaceDescription, Value: []byte("uplink")},
+				},
+			},
+			{
+				ScopesValues:  []netflow.DataField{{Type: netflow.IPFIX_FIELD_applicationId, Value: []byte{3, 0, 0, 0, 80}}},
+				OptionsValues: []netflow.DataField{{Type: netflow.IPFIX_FIELD_applicationName, Value: []byte("http")}},
+			},
+			{
+				ScopesValues:  []netflow.DataField{{Type: netflow.IPFIX_FIELD_ingressVRFID, Value: []byte{0, 0, 0, 7}}},
+				OptionsValues: []netflow.DataField{{Type: netflow.IPFIX_FIELD_VRFname, Value: []byte("blue")}},
+			},
+		},
+	}
+	data := netflow.DataFlowSet{
+		Records: []netflow.DataRecord{
+			{
+				Values: []netflow.DataField{
+					{Type: netflow.IPFIX_FIELD_ingressInterface, Value: []byte{0, 0, 0, 1}},
+					{Type: netflow.IPFIX_FIELD_egressInterface, Value: []byte{0, 0, 0, 2}},
+					{Type: netflow.IPFIX_FIELD_applicationId, Value: []byte{3, 0, 0, 0, 80}},
+					{Type: netflow.IPFIX_FIELD_ingressVRFID, Value: []byte{0, 0, 0, 7}},
+				},
+			},
+		},
+	}
+
+	_, err := ProcessMessageNetFlowConfig(netflow.IPFIXPacket{ObservationDomainId: 1, FlowSets: []interface{}{options}}, samplingRateSys, nil)
+	assert.Nil(t, err)
+	fmsgs, err := ProcessMessageNetFlowConfig(netflow.IPFIXPacket{ObservationDomainId: 1, FlowSets: []interface{}{data}}, samplingRateSys, nil)
+	assert.Nil(t, err)
+	if assert.Len(t, fmsgs, 1) {
+		assert.Equal(t, "eth0", fmsgs[0].InIfName)
+		assert.Equal(t, "uplink", fmsgs[0].OutIfName)
+		assert.Equal(t, "http", fmsgs[0].ApplicationName)
+		assert.Equal(t, "blue", fmsgs[0].IngressVrfName)
+		assert.Equal(t, "", fmsgs[0].EgressVrfName)
+	}
+
+	// the tables are kept per exporter and observation domain
+	fmsgs, err = ProcessMessageNetFlowConfig(netflow.IPFIXPacket{ObservationDomainId: 2, FlowSets: []interface{}{data}}, samplingRateSys, nil)
+	assert.Nil(t, err)
+	if assert.Len(t, fmsgs, 1) {
+		assert.Equal(t, "", fmsgs[0].InIfName)
+	}
+
+	// NetFlow v9 interface scope
+	pktnf9 := netflow.NFv9Packet{
+		SourceId: 1,
+		FlowSets: []interface{}{
+			netflow.OptionsDataFlowSet{
+				Records: []netflow.OptionsDataRecord{
+					{
+						ScopesValues:  []netflow.DataField{{Type: NFV9_SCOPE_INTERFACE, Value: []byte{0, 0, 0, 1}}},
+						OptionsValues: []netflow.DataField{{Type: netflow.NFV9_FIELD_IF_NAME, Value: []byte("Gi0/0")}},
+					},
+				},
+			},
+			netflow.DataFlowSet{
+				Records: []netflow.DataRecord{
+					{Values: []netflow.DataField{{Type: netflow.NFV9_FIELD_INPUT_SNMP, Value: []byte{0, 1}}}},
+				},
+			},
+		},
+	}
+	fmsgs, err = ProcessMessageNetFlowConfig(pktnf9, samplingRateSys, nil)
+	assert.Nil(t, err)
+	if assert.Len(t, fmsgs, 1) {
+		assert.Equal(t, "Gi0/0", fmsgs[0].InIfName)
+	}
+}
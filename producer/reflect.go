@@ -1,6 +1,7 @@
 package producer
 
 import (
+	"errors"
 	"fmt"
 	"reflect"
 
@@ -129,13 +130,23 @@ type NetFlowMapField struct {
 	//DestinationLength uint8  `json:"dlen"` // could be used if populating a slice of uint16 that aren't in protobuf
 }
 
+const (
+	BIFLOW_MERGE = "merge" // one message with the reverse counters (default)
+	BIFLOW_SPLIT = "split" // one message per direction
+)
+
 type IPFIXProducerConfig struct {
-	Mapping []NetFlowMapField `json:"mapping"`
+	Mapping   []NetFlowMapField `json:"mapping"`
+	AllFields bool              `json:"allfields" yaml:"allfields"` // populate AllFields with every decoded field
+	Options   bool              `json:"options" yaml:"options"`     // produce a message for every options data record
+	BiFlow    string            `json:"biflow" yaml:"biflow"`       // output of bidirectional flows (RFC 5103): merge or split
 	//PacketMapping []SFlowMapField   `json:"packet-mapping"` // for embedded frames: use sFlow configuration
 }
 
 type NetFlowV9ProducerConfig struct {
-	Mapping []NetFlowMapField `json:"mapping"`
+	Mapping   []NetFlowMapField `json:"mapping"`
+	AllFields bool              `json:"allfields" yaml:"allfields"`
+	Options   bool              `json:"options" yaml:"options"`
 }
 
 type SFlowMapField struct {
@@ -148,8 +159,19 @@ type SFlowMapField struct {
 	//DestinationLength uint8  `json:"dlen"`
 }
 
+// Application-layer dissectors run on the payload of sampled headers
+type SFlowDissectorConfig struct {
+	DNS  bool `json:"dns" yaml:"dns"`   // query name
+	TLS  bool `json:"tls" yaml:"tls"`   // ClientHello server name
+	HTTP bool `json:"http" yaml:"http"` // request method and Host header
+	QUIC bool `json:"quic" yaml:"quic"` // version of long header packets
+}
+
 type SFlowProducerConfig struct {
-	Mapping []SFlowMapField `json:"mapping"`
+	Mapping    []SFlowMapField      `json:"mapping"`
+	DecapDepth int                  `json:"decapdepth" yaml:"decapdepth"` // amount of tunnel headers to decapsulate (0: disabled)
+	Dissectors SFlowDissectorConfig `json:"dissectors" yaml:"dissectors"`
+	RawHeader  bool                 `json:"rawheader" yaml:"rawheader"` // copy the sampled header into RawHeader
 }
 
 type ProducerConfig struct {
@@ -160,13 +182,47 @@ type ProducerConfig struct {
 	// should do a rename map list for when printing
 }
 
+// Returns an error when a value of the configuration does not exist
+func (c *ProducerConfig) Validate() error {
+	switch c.IPFIX.BiFlow {
+	case "", BIFLOW_MERGE, BIFLOW_SPLIT:
+	default:
+		return errors.New(fmt.Sprintf("ipfix: biflow %s does not exist (%s or %s)", c.IPFIX.BiFlow, BIFLOW_MERGE, BIFLOW_SPLIT))
+	}
+	return nil
+}
+
 type DataMap struct {
 	Destination string
 	Endian      EndianType
 }
 
 type NetFlowMapper struct {
-	data map[string]DataMap // maps field to destination
+	data        map[string]DataMap // maps field to destination
+	allFields   bool
+	options     bool
+	biFlowSplit bool
+}
+
+func (m *NetFlowMapper) AllFields() bool {
+	if m == nil {
+		return false
+	}
+	return m.allFields
+}
+
+func (m *NetFlowMapper) Options() bool {
+	if m == nil {
+		return false
+	}
+	return m.options
+}
+
+func (m *NetFlowMapper) BiFlowSplit() bool {
+	if m == nil {
+		return false
+	}
+	return m.biFlowSplit
 }
 
 func (m *NetFlowMapper) Map(field netflow.DataField) (DataMap, bool) {
@@ -179,7 +235,7 @@ func MapFieldsNetFlow(fields []NetFlowMapField) *NetFlowMapper {
 	for _, field := range fields {
 		ret[fmt.Sprintf("%v-%d-%d", field.PenProvided, field.Pen, field.Type)] = DataMap{Destination: field.Destination, Endian: field.Endian}
 	}
-	return &NetFlowMapper{ret}
+	return &NetFlowMapper{data: ret}
 }
 
 type DataMapLayer struct {
@@ -190,7 +246,31 @@ type DataMapLayer struct {
 }
 
 type SFlowMapper struct {
-	data map[int][]DataMapLayer // map layer to list of offsets
+	data       map[int][]DataMapLayer // map layer to list of offsets
+	decapDepth int
+	dissectors SFlowDissectorConfig
+	rawHeader  bool
+}
+
+func (m *SFlowMapper) DecapDepth() int {
+	if m == nil {
+		return 0
+	}
+	return m.decapDepth
+}
+
+func (m *SFlowMapper) Dissectors() SFlowDissectorConfig {
+	if m == nil {
+		return SFlowDissectorConfig{}
+	}
+	return m.dissectors
+}
+
+func (m *SFlowMapper) RawHeader() bool {
+	if m == nil {
+		return false
+	}
+	return m.rawHeader
 }
 
 func GetSFlowConfigLayer(m *SFlowMapper, layer int) []DataMapLayer {
@@ -213,7 +293,7 @@ func MapFieldsSFlow(fields []SFlowMapField) *SFlowMapper {
 		retLayer = append(retLayer, retLayerEntry)
 		ret[field.Layer] = retLayer
 	}
-	return &SFlowMapper{ret}
+	return &SFlowMapper{data: ret}
 }
 
 type ProducerConfigMapped struct {
@@ -226,8 +306,16 @@ func NewProducerConfigMapped(config *ProducerConfig) *ProducerConfigMapped {
 	newCfg := &ProducerConfigMapped{}
 	if config != nil {
 		newCfg.IPFIX = MapFieldsNetFlow(config.IPFIX.Mapping)
+		newCfg.IPFIX.allFields = config.IPFIX.AllFields
+		newCfg.IPFIX.options = config.IPFIX.Options
+		newCfg.IPFIX.biFlowSplit = config.IPFIX.BiFlow == BIFLOW_SPLIT
 		newCfg.NetFlowV9 = MapFieldsNetFlow(config.NetFlowV9.Mapping)
+		newCfg.NetFlowV9.allFields = config.NetFlowV9.AllFields
+		newCfg.NetFlowV9.options = config.NetFlowV9.Options
 		newCfg.SFlow = MapFieldsSFlow(config.SFlow.Mapping)
+		newCfg.SFlow.decapDepth = config.SFlow.DecapDepth
+		newCfg.SFlow.dissectors = config.SFlow.Dissectors
+		newCfg.SFlow.rawHeader = config.SFlow.RawHeader
 	}
 	return newCfg
 }
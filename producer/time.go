@@ -0,0 +1,185 @@
+package producer
+
+import (
+	"time"
+
+	"github.com/netsampler/goflow2/decoders/netflow"
+	flowmessage "github.com/netsampler/goflow2/pb"
+)
+
+// Seconds between the NTP epoch (1900) and the Unix epoch (1970)
+const ntpEpochOffset = 2208988800
+
+// Converts a 64-bit NTP timestamp (RFC 5905) into a Unix time
+func ntpToTime(v uint64) time.Time {
+	secs := int64(v>>32) - ntpEpochOffset
+	nsecs := int64(((v & 0xffffffff) * 1000000000) >> 32)
+	return time.Unix(secs, nsecs)
+}
+
+// Returns the time in milliseconds of an event timestamped with the exporter's uptime.
+// The difference is computed on 32 bits to handle the wrap-around of the uptime (49.7 days).
+func uptimeToMs(baseTimeMs uint64, uptime uint32, eventUptime uint32) uint64 {
+	return baseTimeMs - uint64(uptime-eventUptime)
+}
+
+// Sets the start of the flow in seconds and milliseconds from a time in milliseconds
+func setFlowStartMs(flowMessage *flowmessage.FlowMessage, ms uint64) {
+	flowMessage.TimeFlowStartMs = ms
+	flowMessage.TimeFlowStart = ms / 1000
+}
+
+// Sets the end of the flow in seconds and milliseconds from a time in milliseconds
+func setFlowEndMs(flowMessage *flowmessage.FlowMessage, ms uint64) {
+	flowMessage.TimeFlowEndMs = ms
+	flowMessage.TimeFlowEnd = ms / 1000
+}
+
+// Converts a dateTimeMicroseconds or dateTimeNanoseconds value (NTP format) into milliseconds.
+// Some exporters send the number of micro/nanoseconds since the Unix epoch instead:
+// these values are before the Unix epoch in NTP format and are divided by unitsPerMs.
+func ntpFieldToMs(v uint64, unitsPerMs uint64, micro bool) uint64 {
+	if v>>32 < ntpEpochOffset {
+		return v / unitsPerMs
+	}
+	if micro {
+		v &= 0xfffffffffffff800 // the last 11 bits are not significant (RFC 7011, section 6.1.9)
+	}
+	return uint64(ntpToTime(v).UnixMilli())
+}
+
+// Time fields of a NetFlow v9/IPFIX record. As they may appear in any order,
+// they are collected first and resolved once the whole record is read.
+type netFlowTimes struct {
+	startMs, endMs   uint64
+	hasStart, hasEnd bool
+	startUptime      uint32 // flowStartSysUpTime or FIRST_SWITCHED
+	endUptime        uint32 // flowEndSysUpTime or LAST_SWITCHED
+	hasStartUptime   bool
+	hasEndUptime     bool
+	durationMs       uint64
+	hasDuration      bool
+	observationMs    uint64
+	hasObservation   bool
+	systemInitMs     uint64
+	hasSystemInit    bool
+}
+
+// Decodes a time field, returns false when the field does not carry a time
+func (t *netFlowTimes) decode(typeId uint16, v []byte, exportTimeMs uint64) bool {
+	var value uint64
+	if err := DecodeUNumber(v, &value); err != nil {
+		return false
+	}
+
+	switch typeId {
+	case netflow.IPFIX_FIELD_flowStartSysUpTime:
+		t.startUptime, t.hasStartUptime = uint32(value), true
+	case netflow.IPFIX_FIELD_flowEndSysUpTime:
+		t.endUptime, t.hasEndUptime = uint32(value), true
+
+	case netflow.IPFIX_FIELD_flowStartSeconds:
+		t.startMs, t.hasStart = value*1000, true
+	case netflow.IPFIX_FIELD_flowStartMilliseconds:
+		t.startMs, t.hasStart = value, true
+	case netflow.IPFIX_FIELD_flowStartMicroseconds:
+		t.startMs, t.hasStart = ntpFieldToMs(value, 1000, true), true
+	case netflow.IPFIX_FIELD_flowStartNanoseconds:
+		t.startMs, t.hasStart = ntpFieldToMs(value, 1000000, false), true
+	case netflow.IPFIX_FIELD_flowStartDeltaMicroseconds:
+		t.startMs, t.hasStart = exportTimeMs-value/1000, true
+
+	case netflow.IPFIX_FIELD_flowEndSeconds:
+		t.endMs, t.hasEnd = value*1000, true
+	case netflow.IPFIX_FIELD_flowEndMilliseconds:
+		t.endMs, t.hasEnd = value, true
+	case netflow.IPFIX_FIELD_flowEndMicroseconds:
+		t.endMs, t.hasEnd = ntpFieldToMs(value, 1000, true), true
+	case netflow.IPFIX_FIELD_flowEndNanoseconds:
+		t.endMs, t.hasEnd = ntpFieldToMs(value, 1000000, false), true
+	case netflow.IPFIX_FIELD_flowEndDeltaMicroseconds:
+		t.endMs, t.hasEnd = exportTimeMs-value/1000, true
+
+	case netflow.IPFIX_FIELD_flowDurationMilliseconds:
+		t.durationMs, t.hasDuration = value, true
+	case netflow.IPFIX_FIELD_flowDurationMicroseconds:
+		t.durationMs, t.hasDuration = value/1000, true
+
+	case netflow.IPFIX_FIELD_observationTimeSeconds:
+		t.observationMs, t.hasObservation = value*1000, true
+	case netflow.IPFIX_FIELD_observationTimeMilliseconds:
+		t.observationMs, t.hasObservation = value, true
+	case netflow.IPFIX_FIELD_observationTimeMicroseconds:
+		t.observationMs, t.hasObservation = ntpFieldToMs(value, 1000, true), true
+	case netflow.IPFIX_FIELD_observationTimeNanoseconds:
+		t.observationMs, t.hasObservation = ntpFieldToMs(value, 1000000, false), true
+
+	case netflow.IPFIX_FIELD_systemInitTimeMilliseconds:
+		t.systemInitMs, t.hasSystemInit = value, true
+
+	default:
+		return false
+	}
+	return true
+}
+
+// Sets the start and end of the flow from the collected fields.
+// Uptime-based fields use the uptime of the NetFlow v9 header or, for IPFIX,
+// the initialization time of the exporter (from the record or from an options record, 0 when unknown).
+func (t *netFlowTimes) resolve(flowMessage *flowmessage.FlowMessage, version uint16, exportTimeMs uint64, uptime uint32, systemInitMs uint64) {
+	t.complete(version, exportTimeMs, uptime, systemInitMs)
+	if t.hasStart {
+		setFlowStartMs(flowMessage, t.startMs)
+	}
+	if t.hasEnd {
+		setFlowEndMs(flowMessage, t.endMs)
+	}
+}
+
+// Sets the start and end of the reverse direction of a biflow from the collected reverse fields
+func (t *netFlowTimes) resolveReverse(flowMessage *flowmessage.FlowMessage, version uint16, exportTimeMs uint64, uptime uint32, systemInitMs uint64) {
+	t.complete(version, exportTimeMs, uptime, systemInitMs)
+	if t.hasStart {
+		flowMessage.ReverseTimeFlowStartMs = t.startMs
+	}
+	if t.hasEnd {
+		flowMessage.ReverseTimeFlowEndMs = t.endMs
+	}
+}
+
+// Computes the start and end of the flow from the uptimes, the duration or the observation time
+func (t *netFlowTimes) complete(version uint16, exportTimeMs uint64, uptime uint32, systemInitMs uint64) {
+	if t.hasSystemInit {
+		systemInitMs = t.systemInitMs
+	}
+
+	if version == 9 {
+		if !t.hasStart && t.hasStartUptime {
+			t.startMs, t.hasStart = uptimeToMs(exportTimeMs, uptime, t.startUptime), true
+		}
+		if !t.hasEnd && t.hasEndUptime {
+			t.endMs, t.hasEnd = uptimeToMs(exportTimeMs, uptime, t.endUptime), true
+		}
+	} else if systemInitMs > 0 {
+		if !t.hasStart && t.hasStartUptime {
+			t.startMs, t.hasStart = systemInitMs+uint64(t.startUptime), true
+		}
+		if !t.hasEnd && t.hasEndUptime {
+			t.endMs, t.hasEnd = systemInitMs+uint64(t.endUptime), true
+		}
+	}
+
+	if t.hasDuration {
+		if t.hasStart && !t.hasEnd {
+			t.endMs, t.hasEnd = t.startMs+t.durationMs, true
+		} else if t.hasEnd && !t.hasStart {
+			t.startMs, t.hasStart = t.endMs-t.durationMs, true
+		}
+	}
+
+	// packet reports (PSAMP) only have an observation time
+	if t.hasObservation && !t.hasStart && !t.hasEnd {
+		t.startMs, t.hasStart = t.observationMs, true
+		t.endMs, t.hasEnd = t.observationMs, true
+	}
+}
@@ -1,11 +1,10 @@
 package file
 
 import (
+	"bufio"
 	"context"
 	"flag"
-	"fmt"
 	"github.com/netsampler/goflow2/transport"
-	"io"
 	"os"
 	"os/signal"
 	"sync"
@@ -15,15 +14,17 @@ import (
 type FileDriver struct {
 	fileDestination string
 	lineSeparator   string
-	w               io.Writer
+	bufferSize      int
+	w               *bufio.Writer
 	file            *os.File
-	lock            *sync.RWMutex
+	lock            *sync.Mutex
 	q               chan bool
 }
 
 func (d *FileDriver) Prepare() error {
 	flag.StringVar(&d.fileDestination, "transport.file", "", "File/console output (empty for stdout)")
 	flag.StringVar(&d.lineSeparator, "transport.file.sep", "\n", "Line separator")
+	flag.IntVar(&d.bufferSize, "transport.file.buffer", 65536, "Size of the write buffer, flushed after each batch of messages")
 	// idea: add terminal coloring based on key partitioning (if any)
 	return nil
 }
@@ -34,7 +35,7 @@ func (d *FileDriver) openFile() error {
 		return err
 	}
 	d.file = file
-	d.w = d.file
+	d.w = bufio.NewWriterSize(d.file, d.bufferSize)
 	return err
 }
 
@@ -42,7 +43,7 @@ func (d *FileDriver) Init(context.Context) error {
 	d.q = make(chan bool, 1)
 
 	if d.fileDestination == "" {
-		d.w = os.Stdout
+		d.w = bufio.NewWriterSize(os.Stdout, d.bufferSize)
 	} else {
 		var err error
 
@@ -60,10 +61,15 @@ func (d *FileDriver) Init(context.Context) error {
 				select {
 				case <-c:
 					d.lock.Lock()
-					d.file.Close()
-					d.openFile()
+					d.w.Flush()
+					file, w := d.file, d.w
+					if err := d.openFile(); err != nil {
+						// keeps using the old file
+						d.file, d.w = file, w
+					} else {
+						file.Close()
+					}
 					d.lock.Unlock()
-					// if there is an error, keeps using the old file
 				case <-d.q:
 					return
 				}
@@ -74,28 +80,56 @@ func (d *FileDriver) Init(context.Context) error {
 	return nil
 }
 
-func (d *FileDriver) Send(key, data []byte) error {
-	d.lock.RLock()
-	w := d.w
-	d.lock.RUnlock()
-	_, err := fmt.Fprint(w, string(data)+d.lineSeparator)
+func (d *FileDriver) write(data []byte) error {
+	if _, err := d.w.Write(data); err != nil {
+		return err
+	}
+	_, err := d.w.WriteString(d.lineSeparator)
 	return err
 }
 
+func (d *FileDriver) Send(key, data []byte) error {
+	d.lock.Lock()
+	defer d.lock.Unlock()
+	if err := d.write(data); err != nil {
+		return err
+	}
+	return d.w.Flush()
+}
+
+// Writes the messages into the buffer: they are written to the file when the buffer is full or on Flush
+func (d *FileDriver) SendBatch(msgs []transport.Message) error {
+	d.lock.Lock()
+	defer d.lock.Unlock()
+	for _, msg := range msgs {
+		if err := d.write(msg.Data); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (d *FileDriver) Flush() error {
+	d.lock.Lock()
+	defer d.lock.Unlock()
+	return d.w.Flush()
+}
+
 func (d *FileDriver) Close(context.Context) error {
+	d.lock.Lock()
+	err := d.w.Flush()
 	if d.fileDestination != "" {
-		d.lock.Lock()
 		d.file.Close()
-		d.lock.Unlock()
 		signal.Ignore(syscall.SIGHUP)
 	}
+	d.lock.Unlock()
 	close(d.q)
-	return nil
+	return err
 }
 
 func init() {
 	d := &FileDriver{
-		lock: &sync.RWMutex{},
+		lock: &sync.Mutex{},
 	}
 	transport.RegisterTransportDriver("file", d)
 }
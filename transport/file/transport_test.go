@@ -0,0 +1,45 @@
+package file
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"sync"
+	"testing"
+
+	"github.com/netsampler/goflow2/transport"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestFileDriverBatch(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "flows.txt")
+	d := &FileDriver{
+		fileDestination: path,
+		lineSeparator:   "\n",
+		bufferSize:      4096,
+		lock:            &sync.Mutex{},
+	}
+	require.NoError(t, d.Init(context.Background()))
+
+	assert.NoError(t, d.SendBatch([]transport.Message{
+		{Data: []byte("flow1")},
+		{Data: []byte("flow2")},
+	}))
+	content, err := os.ReadFile(path)
+	require.NoError(t, err)
+	assert.Empty(t, content, "batch written before flush")
+
+	assert.NoError(t, d.Flush())
+	content, err = os.ReadFile(path)
+	require.NoError(t, err)
+	assert.Equal(t, "flow1\nflow2\n", string(content))
+
+	// single messages and pending batches are written by Send and Close
+	assert.NoError(t, d.Send(nil, []byte("flow3")))
+	assert.NoError(t, d.SendBatch([]transport.Message{{Data: []byte("flow4")}}))
+	assert.NoError(t, d.Close(context.Background()))
+	content, err = os.ReadFile(path)
+	require.NoError(t, err)
+	assert.Equal(t, "flow1\nflow2\nflow3\nflow4\n", string(content))
+}
@@ -9,11 +9,14 @@ import (
 	"fmt"
 	"os"
 	"strings"
+	"sync"
+	"text/template"
 	"time"
 
 	sarama "github.com/Shopify/sarama"
 	"github.com/netsampler/goflow2/transport"
 	"github.com/netsampler/goflow2/utils"
+	"github.com/prometheus/client_golang/prometheus"
 
 	log "github.com/sirupsen/logrus"
 )
@@ -23,6 +26,8 @@ type KafkaDriver struct {
 	kafkaSASL           string
 	kafkaSCRAM          string
 	kafkaTopic          string
+	kafkaTopicOptions   string
+	kafkaTopicDefault   string
 	kafkaSrv            string
 	kafkaBrk            string
 	kafkaMaxMsgBytes    int
@@ -35,9 +40,29 @@ type KafkaDriver struct {
 	kafkaVersion          string
 	kafkaCompressionCodec string
 
-	producer sarama.AsyncProducer
-
-	q chan bool
+	kafkaAcks       string
+	kafkaIdempotent bool
+	kafkaSync       bool
+	kafkaSpillDir   string
+	kafkaSpillMax   int64
+	kafkaSpillRetry time.Duration
+
+	kafkaKey     string
+	kafkaHeaders string
+
+	producer     sarama.AsyncProducer
+	syncProducer sarama.SyncProducer // synchronous mode
+	spill        *spillBuffer
+
+	topicTemplate *template.Template // nil for a static topic
+	keyFields     []string
+	headers       []string
+	format        string
+	hostname      string
+
+	done chan bool // results of the asynchronous producer processed
+	q    chan bool
+	wg   *sync.WaitGroup
 }
 
 type KafkaSASLAlgorithm string
@@ -58,6 +83,12 @@ var (
 		strings.ToLower(sarama.CompressionZSTD.String()):   sarama.CompressionZSTD,
 	}
 
+	requiredAcks = map[string]sarama.RequiredAcks{
+		"none":  sarama.NoResponse,
+		"local": sarama.WaitForLocal,
+		"all":   sarama.WaitForAll,
+	}
+
 	saslAlgorithms = map[KafkaSASLAlgorithm]bool{
 		KAFKA_SASL_PLAIN:        true,
 		KAFKA_SASL_SCRAM_SHA256: true,
@@ -78,7 +109,9 @@ func (d *KafkaDriver) Prepare() error {
 			"Use SASL to connect to Kafka, available settings: %s (TLS is recommended and the environment variables KAFKA_SASL_USER and KAFKA_SASL_PASS need to be set)",
 			strings.Join(saslAlgorithmsList, ", ")))
 
-	flag.StringVar(&d.kafkaTopic, "transport.kafka.topic", "flow-messages", "Kafka topic to produce to")
+	flag.StringVar(&d.kafkaTopic, "transport.kafka.topic", "flow-messages", "Kafka topic to produce to, can be a template using the fields of the flows (eg: flows-{{.Type}})")
+	flag.StringVar(&d.kafkaTopicOptions, "transport.kafka.topic.options", "", "Kafka topic to produce NetFlow/IPFIX options data to (defaults to transport.kafka.topic)")
+	flag.StringVar(&d.kafkaTopicDefault, "transport.kafka.topic.default", "flow-messages", "Kafka topic of the messages without flow (eg: options data) when transport.kafka.topic is a template")
 	flag.StringVar(&d.kafkaSrv, "transport.kafka.srv", "", "SRV record containing a list of Kafka brokers (or use brokers)")
 	flag.StringVar(&d.kafkaBrk, "transport.kafka.brokers", "127.0.0.1:9092,[::1]:9092", "Kafka brokers list separated by commas")
 	flag.IntVar(&d.kafkaMaxMsgBytes, "transport.kafka.maxmsgbytes", 1000000, "Kafka max message bytes")
@@ -88,10 +121,18 @@ func (d *KafkaDriver) Prepare() error {
 	flag.BoolVar(&d.kafkaLogErrors, "transport.kafka.log.err", false, "Log Kafka errors")
 	flag.BoolVar(&d.kafkaHashing, "transport.kafka.hashing", false, "Enable partition hashing")
 
-	//flag.StringVar(&d.kafkaKeying, "transport.kafka.key", "SamplerAddress,DstAS", "Kafka list of fields to do hashing on (partition) separated by commas")
+	flag.StringVar(&d.kafkaKey, "transport.kafka.key", "", "List of fields of the flows to build the key of the messages (partition), separated by commas (defaults to the key of the format)")
+	flag.StringVar(&d.kafkaHeaders, "transport.kafka.headers", "", fmt.Sprintf("List of headers added to the messages, separated by commas (available: %s, %s, %s, %s)", KAFKA_HEADER_FORMAT, KAFKA_HEADER_SCHEMA, KAFKA_HEADER_SAMPLER, KAFKA_HEADER_HOSTNAME))
 	flag.StringVar(&d.kafkaVersion, "transport.kafka.version", "2.8.0", "Kafka version")
 	flag.StringVar(&d.kafkaCompressionCodec, "transport.kafka.compression", "", "Kafka default compression")
 
+	flag.StringVar(&d.kafkaAcks, "transport.kafka.acks", "local", "Acknowledgements required from the brokers: none, local (leader) or all (in-sync replicas)")
+	flag.BoolVar(&d.kafkaIdempotent, "transport.kafka.idempotent", false, "Idempotent producer, avoids duplicates on retries (requires acks=all)")
+	flag.BoolVar(&d.kafkaSync, "transport.kafka.sync", false, "Wait for the acknowledgement of each batch of messages (for low-rate streams)")
+	flag.StringVar(&d.kafkaSpillDir, "transport.kafka.spill.dir", "", "Directory where undelivered messages are stored and retried from (disabled if empty)")
+	flag.Int64Var(&d.kafkaSpillMax, "transport.kafka.spill.max", 1<<30, "Maximum size of the spill directory in bytes (0 for unlimited)")
+	flag.DurationVar(&d.kafkaSpillRetry, "transport.kafka.spill.retry", time.Second*30, "Interval between retries of the spilled messages")
+
 	return nil
 }
 
@@ -101,13 +142,36 @@ func (d *KafkaDriver) Init(context.Context) error {
 		return err
 	}
 
+	if err := d.initRouting(); err != nil {
+		return err
+	}
+	if len(d.headers) > 0 && !kafkaConfigVersion.IsAtLeast(sarama.V0_11_0_0) {
+		return errors.New("Kafka headers require version >= 0.11.0")
+	}
+
 	kafkaConfig := sarama.NewConfig()
 	kafkaConfig.Version = kafkaConfigVersion
-	kafkaConfig.Producer.Return.Successes = false
-	kafkaConfig.Producer.Return.Errors = d.kafkaLogErrors
+	kafkaConfig.Producer.Return.Successes = true
+	kafkaConfig.Producer.Return.Errors = true
 	kafkaConfig.Producer.MaxMessageBytes = d.kafkaMaxMsgBytes
-	kafkaConfig.Producer.Flush.Bytes = d.kafkaFlushBytes
-	kafkaConfig.Producer.Flush.Frequency = d.kafkaFlushFrequency
+	if !d.kafkaSync {
+		// a synchronous send would wait for the flush
+		kafkaConfig.Producer.Flush.Bytes = d.kafkaFlushBytes
+		kafkaConfig.Producer.Flush.Frequency = d.kafkaFlushFrequency
+	}
+
+	if acks, ok := requiredAcks[strings.ToLower(d.kafkaAcks)]; !ok {
+		return errors.New(fmt.Sprintf("Kafka acks %s does not exist", d.kafkaAcks))
+	} else {
+		kafkaConfig.Producer.RequiredAcks = acks
+	}
+	if d.kafkaIdempotent {
+		if kafkaConfig.Producer.RequiredAcks != sarama.WaitForAll {
+			return errors.New("Kafka idempotent producer requires acks=all")
+		}
+		kafkaConfig.Producer.Idempotent = true
+		kafkaConfig.Net.MaxOpenRequests = 1
+	}
 
 	if d.kafkaCompressionCodec != "" {
 		/*
@@ -179,45 +243,322 @@ func (d *KafkaDriver) Init(context.Context) error {
 		addrs = strings.Split(d.kafkaBrk, ",")
 	}
 
-	kafkaProducer, err := sarama.NewAsyncProducer(addrs, kafkaConfig)
-	if err != nil {
-		return err
+	if d.kafkaSpillDir != "" {
+		if d.spill, err = newSpillBuffer(d.kafkaSpillDir, d.kafkaSpillMax); err != nil {
+			return err
+		}
+	}
+
+	if d.kafkaSync {
+		if d.syncProducer, err = sarama.NewSyncProducer(addrs, kafkaConfig); err != nil {
+			return err
+		}
+	} else {
+		if d.producer, err = sarama.NewAsyncProducer(addrs, kafkaConfig); err != nil {
+			return err
+		}
 	}
-	d.producer = kafkaProducer
+	d.start()
+	return nil
+}
 
+// Starts processing the delivery results and retrying the spilled messages
+func (d *KafkaDriver) start() {
 	d.q = make(chan bool)
+	d.wg = &sync.WaitGroup{}
+	d.done = make(chan bool)
 
-	if d.kafkaLogErrors {
+	if d.producer != nil {
+		go d.results()
+	} else {
+		close(d.done)
+	}
+
+	if d.spill != nil {
+		d.wg.Add(1)
 		go func() {
+			defer d.wg.Done()
+			ticker := time.NewTicker(d.kafkaSpillRetry)
+			defer ticker.Stop()
 			for {
 				select {
-				case msg := <-kafkaProducer.Errors():
-					//if log != nil {
-					log.Error(msg)
-					//}
+				case <-ticker.C:
+					if _, err := d.spill.Retry(d.resend); err != nil && d.kafkaLogErrors {
+						log.Errorf("Kafka spill retry: %v", err)
+					}
 				case <-d.q:
 					return
 				}
 			}
 		}()
 	}
+}
 
-	return err
+// Counts the messages delivered by the asynchronous producer and handles the failed ones
+func (d *KafkaDriver) results() {
+	successes, errs := d.producer.Successes(), d.producer.Errors()
+	for successes != nil || errs != nil {
+		select {
+		case msg, ok := <-successes:
+			if !ok {
+				successes = nil
+				continue
+			}
+			utils.KafkaDeliverySuccess.With(
+				prometheus.Labels{
+					"topic": msg.Topic,
+				}).
+				Inc()
+			if retry, ok := msg.Metadata.(*spillRetry); ok {
+				retry.done(nil)
+			}
+		case perr, ok := <-errs:
+			if !ok {
+				errs = nil
+				continue
+			}
+			// the failures of a retry stay in their segment
+			if retry, ok := perr.Msg.Metadata.(*spillRetry); ok {
+				d.deliveryError(perr.Msg, perr.Err)
+				retry.done(perr.Err)
+				continue
+			}
+			d.failed(perr.Msg, perr.Err)
+		}
+	}
+	close(d.done)
+}
+
+// Counts an undelivered message
+func (d *KafkaDriver) deliveryError(msg *sarama.ProducerMessage, err error) {
+	utils.KafkaDeliveryErrors.With(
+		prometheus.Labels{
+			"topic": msg.Topic,
+		}).
+		Inc()
+	if d.kafkaLogErrors {
+		log.Errorf("Kafka delivery to %s: %v", msg.Topic, err)
+	}
+}
+
+// Counts an undelivered message and stores it in the spill buffer.
+// Returns false if the message is lost.
+func (d *KafkaDriver) failed(msg *sarama.ProducerMessage, err error) bool {
+	d.deliveryError(msg, err)
+
+	// retrying would fail the same way
+	if d.spill == nil || errors.Is(err, sarama.ErrMessageSizeTooLarge) {
+		return false
+	}
+	var key, value []byte
+	if msg.Key != nil {
+		key, _ = msg.Key.Encode()
+	}
+	if msg.Value != nil {
+		value, _ = msg.Value.Encode()
+	}
+	action := "spilled"
+	if err := d.spill.Add(msg.Topic, key, value, msg.Headers); err != nil {
+		action = "dropped"
+		if d.kafkaLogErrors && err != ErrSpillFull {
+			log.Errorf("Kafka spill: %v", err)
+		}
+	}
+	utils.KafkaSpill.With(
+		prometheus.Labels{
+			"topic":  msg.Topic,
+			"action": action,
+		}).
+		Inc()
+	return action == "spilled"
+}
+
+// Results of the messages of a spill segment sent by the asynchronous producer
+type spillRetry struct {
+	wg   sync.WaitGroup
+	lock sync.Mutex
+	err  error // first failure
+}
+
+func (r *spillRetry) done(err error) {
+	r.lock.Lock()
+	// retrying would fail the same way
+	if r.err == nil && !errors.Is(err, sarama.ErrMessageSizeTooLarge) {
+		r.err = err
+	}
+	r.lock.Unlock()
+	r.wg.Done()
+}
+
+// Sends messages of the spill buffer again and waits for their acknowledgements.
+// Returns an error if a message is not delivered: the segment is kept and retried as a whole,
+// the messages already delivered are sent again.
+func (d *KafkaDriver) resend(spilled []spillMessage) error {
+	msgs := make([]*sarama.ProducerMessage, len(spilled))
+	for i, msg := range spilled {
+		msgs[i] = &sarama.ProducerMessage{
+			Topic:   msg.topic,
+			Value:   sarama.ByteEncoder(msg.value),
+			Headers: msg.headers,
+		}
+		if msg.key != nil {
+			msgs[i].Key = sarama.ByteEncoder(msg.key)
+		}
+		utils.KafkaSpill.With(
+			prometheus.Labels{
+				"topic":  msg.topic,
+				"action": "retried",
+			}).
+			Inc()
+	}
+
+	if d.syncProducer != nil {
+		perrs := producerErrors(msgs, d.syncProducer.SendMessages(msgs))
+		for _, perr := range perrs {
+			d.deliveryError(perr.Msg, perr.Err)
+		}
+		d.delivered(msgs, perrs)
+		for _, perr := range perrs {
+			if !errors.Is(perr.Err, sarama.ErrMessageSizeTooLarge) {
+				return perr.Err
+			}
+		}
+		return nil
+	}
+
+	retry := &spillRetry{}
+	retry.wg.Add(len(msgs))
+	for _, msg := range msgs {
+		msg.Metadata = retry
+	}
+	if err := d.produce(msgs); err != nil {
+		return err
+	}
+	retry.wg.Wait()
+	return retry.err
+}
+
+// Returns the failures of the messages sent by the synchronous producer
+func producerErrors(msgs []*sarama.ProducerMessage, err error) sarama.ProducerErrors {
+	var perrs sarama.ProducerErrors
+	switch errConv := err.(type) {
+	case nil:
+	case sarama.ProducerErrors:
+		perrs = errConv
+	default:
+		// not attributed to messages: all of them failed
+		for _, msg := range msgs {
+			perrs = append(perrs, &sarama.ProducerError{Msg: msg, Err: err})
+		}
+	}
+	return perrs
+}
+
+// Counts the messages sent by the synchronous producer which are not among the failures
+func (d *KafkaDriver) delivered(msgs []*sarama.ProducerMessage, perrs sarama.ProducerErrors) {
+	failed := make(map[*sarama.ProducerMessage]bool)
+	for _, perr := range perrs {
+		failed[perr.Msg] = true
+	}
+	for _, msg := range msgs {
+		if !failed[msg] {
+			utils.KafkaDeliverySuccess.With(
+				prometheus.Labels{
+					"topic": msg.Topic,
+				}).
+				Inc()
+		}
+	}
+}
+
+// Counts the messages sent by the synchronous producer and handles the failed ones.
+// Returns an error if messages are lost.
+func (d *KafkaDriver) sentSync(msgs []*sarama.ProducerMessage, err error) error {
+	perrs := producerErrors(msgs, err)
+	var lost int
+	for _, perr := range perrs {
+		if !d.failed(perr.Msg, perr.Err) {
+			lost++
+		}
+	}
+	d.delivered(msgs, perrs)
+	if lost > 0 {
+		return errors.New(fmt.Sprintf("Kafka: %d messages not delivered: %v", lost, perrs[0].Err))
+	}
+	return nil
+}
+
+// Produces messages: the asynchronous producer returns immediately and reports the results
+// through results(), the synchronous producer waits for the acknowledgements
+func (d *KafkaDriver) produce(msgs []*sarama.ProducerMessage) error {
+	if d.syncProducer != nil {
+		return d.sentSync(msgs, d.syncProducer.SendMessages(msgs))
+	}
+	for _, msg := range msgs {
+		d.producer.Input() <- msg
+	}
+	return nil
 }
 
 func (d *KafkaDriver) Send(key, data []byte) error {
-	d.producer.Input() <- &sarama.ProducerMessage{
-		Topic: d.kafkaTopic,
-		Key:   sarama.ByteEncoder(key),
-		Value: sarama.ByteEncoder(data),
+	topic, err := d.topic(nil)
+	if err != nil {
+		return err
+	}
+	return d.produce([]*sarama.ProducerMessage{d.message(topic, transport.Message{Key: key, Data: data})})
+}
+
+// Queues the messages one by one into the asynchronous producer, which groups them into produce requests
+// (see transport.kafka.flushbytes and transport.kafka.flushfreq). The synchronous producer sends them at once.
+func (d *KafkaDriver) SendBatch(msgs []transport.Message) error {
+	pmsgs := make([]*sarama.ProducerMessage, 0, len(msgs))
+	var errTopic error
+	for _, msg := range msgs {
+		topic, err := d.topic(msg.Source)
+		if err != nil {
+			errTopic = err
+			continue
+		}
+		pmsgs = append(pmsgs, d.message(topic, msg))
+	}
+	if err := d.produce(pmsgs); err != nil {
+		return err
 	}
+	return errTopic
+}
+
+// The asynchronous producer flushes on its own: the messages are sent at the latest after the flush frequency.
+// The synchronous producer returns once the messages are acknowledged.
+func (d *KafkaDriver) Flush() error {
 	return nil
 }
 
+func (d *KafkaDriver) SendOptions(key, data []byte) error {
+	if d.kafkaTopicOptions == "" {
+		return d.Send(key, data)
+	}
+	return d.produce([]*sarama.ProducerMessage{d.message(d.kafkaTopicOptions, transport.Message{Key: key, Data: data})})
+}
+
 func (d *KafkaDriver) Close(context.Context) error {
-	d.producer.Close()
 	close(d.q)
-	return nil
+	d.wg.Wait()
+
+	var err error
+	if d.syncProducer != nil {
+		err = d.syncProducer.Close()
+	} else {
+		// the failures while flushing are spilled
+		d.producer.AsyncClose()
+	}
+	<-d.done
+
+	if d.spill != nil {
+		if errSpill := d.spill.Close(); err == nil {
+			err = errSpill
+		}
+	}
+	return err
 }
 
 func init() {
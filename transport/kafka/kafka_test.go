@@ -0,0 +1,294 @@
+package kafka
+
+import (
+	"context"
+	"os"
+	"testing"
+	"time"
+
+	sarama "github.com/Shopify/sarama"
+	"github.com/Shopify/sarama/mocks"
+	flowmessage "github.com/netsampler/goflow2/pb"
+	"github.com/netsampler/goflow2/transport"
+	"github.com/netsampler/goflow2/utils"
+	"github.com/prometheus/client_golang/prometheus"
+	dto "github.com/prometheus/client_model/go"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func counterValue(t *testing.T, c *prometheus.CounterVec, labels prometheus.Labels) float64 {
+	var metric dto.Metric
+	require.NoError(t, c.With(labels).Write(&metric))
+	return metric.GetCounter().GetValue()
+}
+
+func spilledMessages(t *testing.T, b *spillBuffer) []spillMessage {
+	var received []spillMessage
+	_, err := b.Retry(func(msgs []spillMessage) error {
+		received = append(received, msgs...)
+		return nil
+	})
+	require.NoError(t, err)
+	return received
+}
+
+func TestKafkaAsyncDelivery(t *testing.T) {
+	config := sarama.NewConfig()
+	config.Producer.Return.Successes = true
+	producer := mocks.NewAsyncProducer(t, config)
+	producer.ExpectInputAndSucceed()
+	producer.ExpectInputAndFail(sarama.ErrOutOfBrokers)
+	producer.ExpectInputAndFail(sarama.ErrMessageSizeTooLarge)
+
+	spill, err := newSpillBuffer(t.TempDir(), 0)
+	require.NoError(t, err)
+	d := &KafkaDriver{
+		kafkaTopic:      "test-async",
+		kafkaSpillRetry: time.Hour,
+		producer:        producer,
+		spill:           spill,
+	}
+	d.start()
+
+	assert.NoError(t, d.SendBatch([]transport.Message{
+		{Key: []byte("key1"), Data: []byte("data1")},
+		{Key: []byte("key2"), Data: []byte("data2")},
+		{Key: []byte("key3"), Data: []byte("data3")},
+	}))
+	assert.NoError(t, d.Close(context.Background()))
+
+	labels := prometheus.Labels{"topic": "test-async"}
+	assert.Equal(t, float64(1), counterValue(t, utils.KafkaDeliverySuccess, labels))
+	assert.Equal(t, float64(2), counterValue(t, utils.KafkaDeliveryErrors, labels))
+	assert.Equal(t, float64(1), counterValue(t, utils.KafkaSpill, prometheus.Labels{"topic": "test-async", "action": "spilled"}))
+
+	// a message too large is not retried
+	assert.Equal(t, []spillMessage{
+		{topic: "test-async", key: []byte("key2"), value: []byte("data2")},
+	}, spilledMessages(t, spill))
+}
+
+func TestKafkaSyncDelivery(t *testing.T) {
+	producer := mocks.NewSyncProducer(t, nil)
+	d := &KafkaDriver{
+		kafkaTopic:   "test-sync",
+		syncProducer: producer,
+	}
+	d.start()
+
+	producer.ExpectSendMessageAndSucceed()
+	assert.NoError(t, d.Send([]byte("key"), []byte("data")))
+
+	// without spill buffer, the failures are returned
+	producer.ExpectSendMessageAndFail(sarama.ErrOutOfBrokers)
+	assert.Error(t, d.Send([]byte("key"), []byte("data")))
+
+	labels := prometheus.Labels{"topic": "test-sync"}
+	assert.Equal(t, float64(1), counterValue(t, utils.KafkaDeliverySuccess, labels))
+	assert.Equal(t, float64(1), counterValue(t, utils.KafkaDeliveryErrors, labels))
+	assert.NoError(t, d.Close(context.Background()))
+}
+
+func TestKafkaSyncSpillRetry(t *testing.T) {
+	producer := mocks.NewSyncProducer(t, nil)
+	spill, err := newSpillBuffer(t.TempDir(), 0)
+	require.NoError(t, err)
+	d := &KafkaDriver{
+		kafkaTopic:      "test-sync-retry",
+		kafkaSpillRetry: time.Hour,
+		syncProducer:    producer,
+		spill:           spill,
+	}
+	d.start()
+
+	// spilled messages are not lost
+	producer.ExpectSendMessageAndFail(sarama.ErrOutOfBrokers)
+	producer.ExpectSendMessageAndFail(sarama.ErrOutOfBrokers)
+	assert.NoError(t, d.SendBatch([]transport.Message{
+		{Key: []byte("key1"), Data: []byte("data1")},
+		{Key: []byte("key2"), Data: []byte("data2")},
+	}))
+
+	// brokers still unavailable: the messages stay in the buffer
+	producer.ExpectSendMessageAndFail(sarama.ErrOutOfBrokers)
+	producer.ExpectSendMessageAndFail(sarama.ErrOutOfBrokers)
+	_, err = spill.Retry(d.resend)
+	assert.Error(t, err)
+
+	var values []string
+	producer.ExpectSendMessageWithMessageCheckerFunctionAndSucceed(func(msg *sarama.ProducerMessage) error {
+		value, _ := msg.Value.Encode()
+		values = append(values, string(value))
+		return nil
+	})
+	producer.ExpectSendMessageWithMessageCheckerFunctionAndSucceed(func(msg *sarama.ProducerMessage) error {
+		value, _ := msg.Value.Encode()
+		values = append(values, string(value))
+		return nil
+	})
+	sent, err := spill.Retry(d.resend)
+	require.NoError(t, err)
+	assert.Equal(t, 2, sent)
+	assert.Equal(t, []string{"data1", "data2"}, values)
+	assert.Empty(t, spilledMessages(t, spill))
+
+	assert.Equal(t, float64(2), counterValue(t, utils.KafkaDeliverySuccess, prometheus.Labels{"topic": "test-sync-retry"}))
+	assert.Equal(t, float64(4), counterValue(t, utils.KafkaSpill, prometheus.Labels{"topic": "test-sync-retry", "action": "retried"}))
+	assert.NoError(t, d.Close(context.Background()))
+}
+
+func TestKafkaSyncSpillRetryPartial(t *testing.T) {
+	producer := mocks.NewSyncProducer(t, nil)
+	spill, err := newSpillBuffer(t.TempDir(), 0)
+	require.NoError(t, err)
+	d := &KafkaDriver{
+		kafkaTopic:      "test-sync-partial",
+		kafkaSpillRetry: time.Hour,
+		syncProducer:    producer,
+		spill:           spill,
+	}
+	d.start()
+	require.NoError(t, spill.Add("test-sync-partial", nil, []byte("data1"), nil))
+	require.NoError(t, spill.Add("test-sync-partial", nil, []byte("data2"), nil))
+
+	// the segment is kept as a whole: the failures are not spilled again after the next messages
+	producer.ExpectSendMessageAndSucceed()
+	producer.ExpectSendMessageAndFail(sarama.ErrOutOfBrokers)
+	_, err = spill.Retry(d.resend)
+	assert.Equal(t, sarama.ErrOutOfBrokers, err)
+	assert.Equal(t, float64(0), counterValue(t, utils.KafkaSpill, prometheus.Labels{"topic": "test-sync-partial", "action": "spilled"}))
+	assert.Equal(t, []spillMessage{
+		{topic: "test-sync-partial", value: []byte("data1")},
+		{topic: "test-sync-partial", value: []byte("data2")},
+	}, spilledMessages(t, spill))
+	assert.NoError(t, d.Close(context.Background()))
+}
+
+func TestKafkaAsyncSpillRetry(t *testing.T) {
+	config := sarama.NewConfig()
+	config.Producer.Return.Successes = true
+	producer := mocks.NewAsyncProducer(t, config)
+	spill, err := newSpillBuffer(t.TempDir(), 0)
+	require.NoError(t, err)
+	d := &KafkaDriver{
+		kafkaTopic:      "test-async-retry",
+		kafkaSpillRetry: time.Hour,
+		producer:        producer,
+		spill:           spill,
+	}
+	d.start()
+	require.NoError(t, spill.Add("test-async-retry", nil, []byte("data1"), nil))
+	require.NoError(t, spill.Add("test-async-retry", nil, []byte("data2"), nil))
+
+	// the segment is removed once its messages are acknowledged
+	producer.ExpectInputAndSucceed()
+	producer.ExpectInputAndFail(sarama.ErrOutOfBrokers)
+	_, err = spill.Retry(d.resend)
+	assert.Equal(t, sarama.ErrOutOfBrokers, err)
+	assert.Equal(t, float64(0), counterValue(t, utils.KafkaSpill, prometheus.Labels{"topic": "test-async-retry", "action": "spilled"}))
+
+	producer.ExpectInputAndSucceed()
+	producer.ExpectInputAndSucceed()
+	sent, err := spill.Retry(d.resend)
+	require.NoError(t, err)
+	assert.Equal(t, 2, sent)
+	assert.Empty(t, spilledMessages(t, spill))
+	assert.Equal(t, float64(3), counterValue(t, utils.KafkaDeliverySuccess, prometheus.Labels{"topic": "test-async-retry"}))
+	assert.NoError(t, d.Close(context.Background()))
+}
+
+func TestKafkaRouting(t *testing.T) {
+	d := &KafkaDriver{
+		kafkaTopic:        "flows-{{.Type}}-{{ip .SamplerAddress}}",
+		kafkaTopicDefault: "flows-default",
+		kafkaKey:          "SamplerAddress, InIf",
+		kafkaHeaders:      "format, schema,sampler,hostname",
+	}
+	require.NoError(t, d.initRouting())
+	d.SetFormat("pb")
+
+	source := &flowmessage.FlowMessage{
+		Type:           flowmessage.FlowMessage_IPFIX,
+		SamplerAddress: []byte{0x20, 0x01, 0x0d, 0xb8, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 1},
+		InIf:           10,
+	}
+	topic, err := d.topic(source)
+	require.NoError(t, err)
+	assert.Equal(t, "flows-IPFIX-2001_db8__1", topic)
+
+	topic, err = d.topic(&flowmessage.FlowMessage{})
+	require.NoError(t, err)
+	assert.Equal(t, "flows-FLOWUNKNOWN-", topic)
+
+	// without source (eg: options data)
+	topic, err = d.topic(nil)
+	require.NoError(t, err)
+	assert.Equal(t, "flows-default", topic)
+
+	msg := d.message(topic, transport.Message{Key: []byte("format-key"), Data: []byte("data"), Source: source})
+	key, _ := msg.Key.Encode()
+	assert.Equal(t, "[32 1 13 184 0 0 0 0 0 0 0 0 0 0 0 1]-10-", string(key))
+	hostname, _ := os.Hostname()
+	assert.Equal(t, []sarama.RecordHeader{
+		{Key: []byte("format"), Value: []byte("pb")},
+		{Key: []byte("schema"), Value: []byte(schemaVersion)},
+		{Key: []byte("sampler"), Value: []byte("2001:db8::1")},
+		{Key: []byte("hostname"), Value: []byte(hostname)},
+	}, msg.Headers)
+	assert.Len(t, schemaVersion, 16)
+
+	// without source, the key of the format is kept
+	msg = d.message(topic, transport.Message{Key: []byte("format-key"), Data: []byte("data")})
+	key, _ = msg.Key.Encode()
+	assert.Equal(t, "format-key", string(key))
+
+	d.kafkaTopicDefault = ""
+	assert.Error(t, d.initRouting())
+	d.kafkaTopicDefault = "flows-default"
+	d.kafkaTopic = "flows-{{.Unknown}}"
+	assert.Error(t, d.initRouting())
+	d.kafkaTopic = "flows-{{"
+	assert.Error(t, d.initRouting())
+	d.kafkaTopic = "flows"
+	d.kafkaHeaders = "format,unknown"
+	assert.Error(t, d.initRouting())
+	d.kafkaHeaders = ""
+	d.kafkaKey = "SamplerAddress,InIff"
+	assert.Error(t, d.initRouting())
+	d.kafkaKey = "SamplerAddress,state"
+	assert.Error(t, d.initRouting())
+}
+
+func TestKafkaTopicTemplate(t *testing.T) {
+	config := sarama.NewConfig()
+	config.Producer.Return.Successes = true
+	producer := mocks.NewAsyncProducer(t, config)
+	var topics []string
+	for i := 0; i < 5; i++ {
+		producer.ExpectInputWithMessageCheckerFunctionAndSucceed(func(msg *sarama.ProducerMessage) error {
+			topics = append(topics, msg.Topic)
+			return nil
+		})
+	}
+
+	d := &KafkaDriver{
+		kafkaTopic:        "test-{{.Type}}",
+		kafkaTopicOptions: "test-options",
+		kafkaTopicDefault: "test-default",
+		producer:          producer,
+	}
+	require.NoError(t, d.initRouting())
+	d.start()
+
+	assert.NoError(t, d.SendBatch([]transport.Message{
+		{Data: []byte("data1"), Source: &flowmessage.FlowMessage{Type: flowmessage.FlowMessage_SFLOW_5}},
+		{Data: []byte("data2"), Source: &flowmessage.FlowMessage{Type: flowmessage.FlowMessage_NETFLOW_V9}},
+	}))
+	assert.NoError(t, d.SendOptions(nil, []byte("options")))
+	assert.NoError(t, d.Send(nil, []byte("data3")))
+	d.kafkaTopicOptions = ""
+	assert.NoError(t, d.SendOptions(nil, []byte("options")))
+	assert.NoError(t, d.Close(context.Background()))
+	assert.Equal(t, []string{"test-SFLOW_5", "test-NETFLOW_V9", "test-options", "test-default", "test-default"}, topics)
+}
@@ -0,0 +1,174 @@
+package kafka
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"os"
+	"reflect"
+	"regexp"
+	"strings"
+	"text/template"
+
+	sarama "github.com/Shopify/sarama"
+	"github.com/netsampler/goflow2/format/common"
+	flowmessage "github.com/netsampler/goflow2/pb"
+	"github.com/netsampler/goflow2/transport"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/reflect/protodesc"
+)
+
+// Record headers
+const (
+	KAFKA_HEADER_FORMAT   = "format"   // name of the format (eg: pb, json)
+	KAFKA_HEADER_SCHEMA   = "schema"   // version of the schema of the flow messages
+	KAFKA_HEADER_SAMPLER  = "sampler"  // address of the sampler of the message
+	KAFKA_HEADER_HOSTNAME = "hostname" // hostname of the collector
+)
+
+var (
+	kafkaHeaders = map[string]bool{
+		KAFKA_HEADER_FORMAT:   true,
+		KAFKA_HEADER_SCHEMA:   true,
+		KAFKA_HEADER_SAMPLER:  true,
+		KAFKA_HEADER_HOSTNAME: true,
+	}
+
+	topicInvalidChars = regexp.MustCompile(`[^a-zA-Z0-9._-]`)
+	topicFuncs        = template.FuncMap{
+		"ip": common.RenderIP,
+	}
+
+	schemaVersion = flowSchemaVersion()
+)
+
+// Version of the schema of the flow messages: a digest of pb/flow.proto, which changes with the fields
+func flowSchemaVersion() string {
+	data, err := proto.MarshalOptions{Deterministic: true}.Marshal(protodesc.ToFileDescriptorProto(flowmessage.File_pb_flow_proto))
+	if err != nil {
+		return ""
+	}
+	sum := sha256.Sum256(data)
+	return hex.EncodeToString(sum[:8])
+}
+
+func (d *KafkaDriver) initRouting() error {
+	d.topicTemplate = nil
+	if strings.Contains(d.kafkaTopic, "{{") {
+		topicTemplate, err := template.New("topic").Funcs(topicFuncs).Parse(d.kafkaTopic)
+		if err != nil {
+			return errors.New(fmt.Sprintf("Kafka topic template: %v", err))
+		}
+		d.topicTemplate = topicTemplate
+		if d.kafkaTopicDefault == "" {
+			return errors.New("Kafka topic template: transport.kafka.topic.default is required")
+		}
+		// checks the fields of the template
+		if _, err := d.topic(&flowmessage.FlowMessage{}); err != nil {
+			return err
+		}
+	}
+
+	d.keyFields = nil
+	if d.kafkaKey != "" {
+		flowType := reflect.TypeOf(flowmessage.FlowMessage{})
+		for _, name := range strings.Split(d.kafkaKey, ",") {
+			name = strings.TrimSpace(name)
+			if field, ok := flowType.FieldByName(name); !ok || !field.IsExported() {
+				return errors.New(fmt.Sprintf("Kafka key field %s does not exist", name))
+			}
+			d.keyFields = append(d.keyFields, name)
+		}
+	}
+
+	d.headers = nil
+	if d.kafkaHeaders != "" {
+		for _, name := range strings.Split(d.kafkaHeaders, ",") {
+			name = strings.ToLower(strings.TrimSpace(name))
+			if !kafkaHeaders[name] {
+				return errors.New(fmt.Sprintf("Kafka header %s does not exist", name))
+			}
+			d.headers = append(d.headers, name)
+			if name == KAFKA_HEADER_HOSTNAME {
+				hostname, err := os.Hostname()
+				if err != nil {
+					return err
+				}
+				d.hostname = hostname
+			}
+		}
+	}
+	return nil
+}
+
+func (d *KafkaDriver) SetFormat(name string) {
+	d.format = name
+}
+
+// Returns the topic of a message, rendered from the template with the message before formatting.
+// Messages without source (eg: options data, sent with Send) go to transport.kafka.topic.default.
+func (d *KafkaDriver) topic(source interface{}) (string, error) {
+	if d.topicTemplate == nil {
+		return d.kafkaTopic, nil
+	}
+	if source == nil {
+		return d.kafkaTopicDefault, nil
+	}
+	var b strings.Builder
+	if err := d.topicTemplate.Execute(&b, source); err != nil {
+		return "", errors.New(fmt.Sprintf("Kafka topic template: %v", err))
+	}
+	topic := topicInvalidChars.ReplaceAllString(b.String(), "_")
+	if topic == "" {
+		return "", errors.New("Kafka topic template: empty topic")
+	}
+	if len(topic) > 249 {
+		topic = topic[:249]
+	}
+	return topic, nil
+}
+
+// Returns the key of a message: the hash of the fields of transport.kafka.key if set, otherwise the key of the format.
+// Messages without source keep the key of the format.
+func (d *KafkaDriver) key(key []byte, source interface{}) []byte {
+	if len(d.keyFields) == 0 || source == nil {
+		return key
+	}
+	return []byte(common.HashProto(d.keyFields, source))
+}
+
+func (d *KafkaDriver) recordHeaders(source interface{}) []sarama.RecordHeader {
+	if len(d.headers) == 0 {
+		return nil
+	}
+	headers := make([]sarama.RecordHeader, 0, len(d.headers))
+	for _, name := range d.headers {
+		var value string
+		switch name {
+		case KAFKA_HEADER_FORMAT:
+			value = d.format
+		case KAFKA_HEADER_SCHEMA:
+			value = schemaVersion
+		case KAFKA_HEADER_HOSTNAME:
+			value = d.hostname
+		case KAFKA_HEADER_SAMPLER:
+			if msg, ok := source.(interface{ GetSamplerAddress() []byte }); ok {
+				value = common.RenderIP(msg.GetSamplerAddress())
+			}
+		}
+		if value != "" {
+			headers = append(headers, sarama.RecordHeader{Key: []byte(name), Value: []byte(value)})
+		}
+	}
+	return headers
+}
+
+func (d *KafkaDriver) message(topic string, msg transport.Message) *sarama.ProducerMessage {
+	return &sarama.ProducerMessage{
+		Topic:   topic,
+		Key:     sarama.ByteEncoder(d.key(msg.Key, msg.Source)),
+		Value:   sarama.ByteEncoder(msg.Data),
+		Headers: d.recordHeaders(msg.Source),
+	}
+}
@@ -0,0 +1,264 @@
+package kafka
+
+import (
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	sarama "github.com/Shopify/sarama"
+	"github.com/netsampler/goflow2/utils"
+)
+
+const (
+	SPILL_SEGMENT_SUFFIX = ".spill"
+	SPILL_SEGMENT_MAX    = 64 << 20 // size after which a new segment is started
+)
+
+var ErrSpillFull = errors.New("Kafka spill buffer is full")
+
+type spillMessage struct {
+	topic   string
+	key     []byte
+	value   []byte
+	headers []sarama.RecordHeader
+}
+
+// Buffer on disk of the messages which could not be delivered to Kafka.
+// Messages are appended to segment files which are retried from the oldest.
+// Segments left by a previous run are retried as well.
+type spillBuffer struct {
+	dir      string
+	maxBytes int64 // 0 for unlimited
+
+	lock        sync.Mutex
+	file        *os.File // current segment
+	fileSize    int64
+	size        int64 // of all the segments
+	lastSegment int64
+}
+
+func newSpillBuffer(dir string, maxBytes int64) (*spillBuffer, error) {
+	if err := os.MkdirAll(dir, 0755); err != nil {
+		return nil, err
+	}
+	b := &spillBuffer{
+		dir:      dir,
+		maxBytes: maxBytes,
+	}
+	segments, err := b.segments()
+	if err != nil {
+		return nil, err
+	}
+	for _, segment := range segments {
+		if info, err := os.Stat(segment); err == nil {
+			b.size += info.Size()
+		}
+	}
+	utils.KafkaSpillBytes.Set(float64(b.size))
+	return b, nil
+}
+
+// Returns the segment files, oldest first
+func (b *spillBuffer) segments() ([]string, error) {
+	entries, err := os.ReadDir(b.dir)
+	if err != nil {
+		return nil, err
+	}
+	var segments []string
+	for _, entry := range entries {
+		if !entry.IsDir() && strings.HasSuffix(entry.Name(), SPILL_SEGMENT_SUFFIX) {
+			segments = append(segments, filepath.Join(b.dir, entry.Name()))
+		}
+	}
+	sort.Strings(segments)
+	return segments, nil
+}
+
+func (b *spillBuffer) openSegment() error {
+	// names are sorted by creation, even when created in the same nanosecond
+	name := time.Now().UnixNano()
+	if name <= b.lastSegment {
+		name = b.lastSegment + 1
+	}
+	b.lastSegment = name
+	file, err := os.OpenFile(filepath.Join(b.dir, fmt.Sprintf("%020d%s", name, SPILL_SEGMENT_SUFFIX)), os.O_CREATE|os.O_EXCL|os.O_WRONLY, 0644)
+	if err != nil {
+		return err
+	}
+	b.file = file
+	b.fileSize = 0
+	return nil
+}
+
+// Closes the current segment so it can be retried
+func (b *spillBuffer) rotate() error {
+	if b.file == nil {
+		return nil
+	}
+	err := b.file.Close()
+	b.file = nil
+	return err
+}
+
+// Appends a message to the buffer, returns ErrSpillFull when the maximum size is reached
+func (b *spillBuffer) Add(topic string, key, value []byte, headers []sarama.RecordHeader) error {
+	var headersBlock []byte
+	fieldLen := make([]byte, 4)
+	for _, header := range headers {
+		for _, field := range [][]byte{header.Key, header.Value} {
+			binary.BigEndian.PutUint32(fieldLen, uint32(len(field)))
+			headersBlock = append(headersBlock, fieldLen...)
+			headersBlock = append(headersBlock, field...)
+		}
+	}
+
+	record := make([]byte, 16, 16+len(topic)+len(key)+len(value)+len(headersBlock))
+	binary.BigEndian.PutUint32(record[0:], uint32(len(topic)))
+	binary.BigEndian.PutUint32(record[4:], uint32(len(key)))
+	binary.BigEndian.PutUint32(record[8:], uint32(len(value)))
+	binary.BigEndian.PutUint32(record[12:], uint32(len(headersBlock)))
+	record = append(record, topic...)
+	record = append(record, key...)
+	record = append(record, value...)
+	record = append(record, headersBlock...)
+
+	b.lock.Lock()
+	defer b.lock.Unlock()
+	if b.maxBytes > 0 && b.size+int64(len(record)) > b.maxBytes {
+		return ErrSpillFull
+	}
+	if b.file != nil && b.fileSize >= SPILL_SEGMENT_MAX {
+		if err := b.rotate(); err != nil {
+			return err
+		}
+	}
+	if b.file == nil {
+		if err := b.openSegment(); err != nil {
+			return err
+		}
+	}
+	n, err := b.file.Write(record)
+	b.fileSize += int64(n)
+	b.size += int64(n)
+	utils.KafkaSpillBytes.Set(float64(b.size))
+	return err
+}
+
+// Decodes the headers of a message, returns false if they are truncated
+func readHeaders(data []byte) ([]sarama.RecordHeader, bool) {
+	var headers []sarama.RecordHeader
+	for len(data) > 0 {
+		var header sarama.RecordHeader
+		for _, field := range []*[]byte{&header.Key, &header.Value} {
+			if len(data) < 4 {
+				return nil, false
+			}
+			fieldLen := binary.BigEndian.Uint32(data)
+			data = data[4:]
+			if uint64(fieldLen) > uint64(len(data)) {
+				return nil, false
+			}
+			*field = data[:fieldLen]
+			data = data[fieldLen:]
+		}
+		headers = append(headers, header)
+	}
+	return headers, true
+}
+
+// Reads the messages of a segment. A truncated message at the end (eg: crash while writing) is ignored.
+func readSegment(segment string) ([]spillMessage, error) {
+	data, err := os.ReadFile(segment)
+	if err != nil {
+		return nil, err
+	}
+	var msgs []spillMessage
+	for len(data) >= 16 {
+		topicLen := uint64(binary.BigEndian.Uint32(data[0:]))
+		keyLen := uint64(binary.BigEndian.Uint32(data[4:]))
+		valueLen := uint64(binary.BigEndian.Uint32(data[8:]))
+		headersLen := uint64(binary.BigEndian.Uint32(data[12:]))
+		data = data[16:]
+		if topicLen+keyLen+valueLen+headersLen > uint64(len(data)) {
+			break
+		}
+		headers, ok := readHeaders(data[topicLen+keyLen+valueLen : topicLen+keyLen+valueLen+headersLen])
+		if !ok {
+			break
+		}
+		msg := spillMessage{
+			topic:   string(data[:topicLen]),
+			value:   data[topicLen+keyLen : topicLen+keyLen+valueLen],
+			headers: headers,
+		}
+		if keyLen > 0 {
+			msg.key = data[topicLen : topicLen+keyLen]
+		}
+		msgs = append(msgs, msg)
+		data = data[topicLen+keyLen+valueLen+headersLen:]
+	}
+	return msgs, nil
+}
+
+// Sends the spilled messages, oldest segment first. A segment is removed once send returns,
+// which waits for the acknowledgements: on error, the retry stops and the segment is kept for the next one.
+// Returns the number of messages sent.
+func (b *spillBuffer) Retry(send func([]spillMessage) error) (int, error) {
+	b.lock.Lock()
+	err := b.rotate()
+	b.lock.Unlock()
+	if err != nil {
+		return 0, err
+	}
+	segments, err := b.segments()
+	if err != nil {
+		return 0, err
+	}
+
+	var sent int
+	for _, segment := range segments {
+		b.lock.Lock()
+		current := b.file != nil && b.file.Name() == segment
+		b.lock.Unlock()
+		if current {
+			// created after the rotation
+			break
+		}
+
+		info, err := os.Stat(segment)
+		if err != nil {
+			return sent, err
+		}
+		msgs, err := readSegment(segment)
+		if err != nil {
+			return sent, err
+		}
+		if len(msgs) > 0 {
+			if err := send(msgs); err != nil {
+				return sent, err
+			}
+		}
+		sent += len(msgs)
+		if err := os.Remove(segment); err != nil {
+			return sent, err
+		}
+
+		b.lock.Lock()
+		b.size -= info.Size()
+		utils.KafkaSpillBytes.Set(float64(b.size))
+		b.lock.Unlock()
+	}
+	return sent, nil
+}
+
+func (b *spillBuffer) Close() error {
+	b.lock.Lock()
+	defer b.lock.Unlock()
+	return b.rotate()
+}
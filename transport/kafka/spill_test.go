@@ -0,0 +1,95 @@
+package kafka
+
+import (
+	"errors"
+	"os"
+	"testing"
+
+	sarama "github.com/Shopify/sarama"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSpillBuffer(t *testing.T) {
+	dir := t.TempDir()
+	b, err := newSpillBuffer(dir, 0)
+	require.NoError(t, err)
+
+	require.NoError(t, b.Add("flows", []byte("key"), []byte("value1"), []sarama.RecordHeader{{Key: []byte("format"), Value: []byte("pb")}}))
+	require.NoError(t, b.Add("options", nil, []byte("value2"), nil))
+
+	// segment kept when sending fails
+	_, err = b.Retry(func(msgs []spillMessage) error {
+		return errors.New("brokers unavailable")
+	})
+	assert.Error(t, err)
+	segments, err := b.segments()
+	require.NoError(t, err)
+	assert.Len(t, segments, 1)
+
+	// messages added during the failure are sent after the previous ones
+	require.NoError(t, b.Add("flows", []byte("key"), []byte("value3"), nil))
+
+	var received []spillMessage
+	sent, err := b.Retry(func(msgs []spillMessage) error {
+		received = append(received, msgs...)
+		return nil
+	})
+	require.NoError(t, err)
+	assert.Equal(t, 3, sent)
+	assert.Equal(t, []spillMessage{
+		{topic: "flows", key: []byte("key"), value: []byte("value1"), headers: []sarama.RecordHeader{{Key: []byte("format"), Value: []byte("pb")}}},
+		{topic: "options", value: []byte("value2")},
+		{topic: "flows", key: []byte("key"), value: []byte("value3")},
+	}, received)
+
+	segments, err = b.segments()
+	require.NoError(t, err)
+	assert.Empty(t, segments)
+	assert.Equal(t, int64(0), b.size)
+	assert.NoError(t, b.Close())
+}
+
+func TestSpillBufferFull(t *testing.T) {
+	b, err := newSpillBuffer(t.TempDir(), 40)
+	require.NoError(t, err)
+	defer b.Close()
+
+	assert.NoError(t, b.Add("flows", nil, []byte("0123456789"), nil))
+	assert.Equal(t, ErrSpillFull, b.Add("flows", nil, []byte("0123456789"), nil))
+}
+
+func TestSpillBufferRestart(t *testing.T) {
+	dir := t.TempDir()
+	b, err := newSpillBuffer(dir, 0)
+	require.NoError(t, err)
+	require.NoError(t, b.Add("flows", nil, []byte("value1"), nil))
+	require.NoError(t, b.Add("flows", nil, []byte("value2"), nil))
+	require.NoError(t, b.Close())
+
+	// crash while writing a message
+	segments, err := b.segments()
+	require.NoError(t, err)
+	require.Len(t, segments, 1)
+	f, err := os.OpenFile(segments[0], os.O_APPEND|os.O_WRONLY, 0644)
+	require.NoError(t, err)
+	_, err = f.Write([]byte{0, 0, 0, 5, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 1, 0, 'f'})
+	require.NoError(t, err)
+	require.NoError(t, f.Close())
+
+	b, err = newSpillBuffer(dir, 0)
+	require.NoError(t, err)
+	assert.Equal(t, int64(2*(16+5+6)+17), b.size)
+
+	var received []spillMessage
+	_, err = b.Retry(func(msgs []spillMessage) error {
+		received = append(received, msgs...)
+		return nil
+	})
+	require.NoError(t, err)
+	assert.Equal(t, []spillMessage{
+		{topic: "flows", value: []byte("value1")},
+		{topic: "flows", value: []byte("value2")},
+	}, received)
+	assert.Equal(t, int64(0), b.size)
+}
@@ -0,0 +1,311 @@
+package parquet
+
+import (
+	"context"
+	"errors"
+	"flag"
+	"fmt"
+	"os"
+	"os/signal"
+	"path/filepath"
+	"sync"
+	"syscall"
+	"time"
+
+	goparquet "github.com/fraugster/parquet-go"
+	parquetformat "github.com/fraugster/parquet-go/parquet"
+	"github.com/fraugster/parquet-go/parquetschema"
+	"github.com/netsampler/goflow2/format/common"
+	flowmessage "github.com/netsampler/goflow2/pb"
+	"github.com/netsampler/goflow2/transport"
+	"github.com/netsampler/goflow2/utils"
+	"github.com/prometheus/client_golang/prometheus"
+	log "github.com/sirupsen/logrus"
+)
+
+const (
+	FILE_PREFIX    = "flows-"
+	FILE_EXTENSION = ".parquet"
+	TEMP_PREFIX    = "." // hidden until closed, ignored by most readers
+)
+
+var (
+	compressionCodecs = map[string]parquetformat.CompressionCodec{
+		"none":   parquetformat.CompressionCodec_UNCOMPRESSED,
+		"snappy": parquetformat.CompressionCodec_SNAPPY,
+		"gzip":   parquetformat.CompressionCodec_GZIP,
+	}
+
+	ErrClosed   = errors.New("Parquet transport is closed")
+	ErrNoSource = errors.New("Parquet: the rows are written from the flows before formatting, use -format=parquet")
+)
+
+// Writes the flows sent with the parquet format into Parquet files,
+// rotated by size and time into time-partitioned directories.
+// The rows are built from the flows (transport.Message.Source), not from the formatted data.
+type ParquetDriver struct {
+	dir          string
+	partition    string
+	maxSize      int64
+	interval     time.Duration
+	rowGroupSize int64
+	compression  string
+
+	codec  *common.ParquetCodec
+	schema *parquetschema.SchemaDefinition
+	now    func() time.Time
+
+	lock    *sync.Mutex
+	file    *os.File
+	w       *goparquet.FileWriter
+	path    string // of the current file once closed
+	opened  time.Time
+	current string // partition of the current file
+	closed  bool
+	q       chan bool
+	done    chan bool
+	stop    *sync.Once
+
+	signals chan os.Signal
+	kill    func(os.Signal) // terminates the process once the files are closed
+}
+
+func (d *ParquetDriver) Prepare() error {
+	common.SelectorFlag()
+	flag.StringVar(&d.dir, "transport.parquet.dir", "", "Directory of the Parquet files, use with -format=parquet")
+	flag.StringVar(&d.partition, "transport.parquet.partition", "dt=2006-01-02/hour=15", "Subdirectories of the files, as a Go time layout (UTC)")
+	flag.Int64Var(&d.maxSize, "transport.parquet.size", 128<<20, "Size in bytes after which a file is closed (checked after each row group)")
+	flag.DurationVar(&d.interval, "transport.parquet.interval", time.Minute*10, "Maximum duration of a file")
+	flag.Int64Var(&d.rowGroupSize, "transport.parquet.rowgroup", 32<<20, "Size in bytes of the rows kept in memory before writing a row group (uncompressed estimate)")
+	flag.StringVar(&d.compression, "transport.parquet.compression", "snappy", "Compression of the columns (none, snappy or gzip)")
+	return nil
+}
+
+func (d *ParquetDriver) Init(context.Context) error {
+	if d.dir == "" {
+		return errors.New("Parquet: -transport.parquet.dir is required")
+	}
+	if _, ok := compressionCodecs[d.compression]; !ok {
+		return errors.New(fmt.Sprintf("Parquet: compression %s does not exist", d.compression))
+	}
+	if d.interval <= 0 {
+		return errors.New("Parquet: -transport.parquet.interval must be positive")
+	}
+	if err := common.ManualSelectorInit(); err != nil {
+		return err
+	}
+	var err error
+	if d.codec, err = common.NewParquetCodec(&flowmessage.FlowMessage{}); err != nil {
+		return err
+	}
+	if d.schema, err = parquetschema.ParseSchemaDefinition(d.codec.Schema()); err != nil {
+		return err
+	}
+	if err := os.MkdirAll(d.dir, 0755); err != nil {
+		return err
+	}
+
+	d.q = make(chan bool)
+	d.done = make(chan bool)
+	d.stop = &sync.Once{}
+	// the files are closed before exiting, their footer would be missing otherwise
+	d.signals = make(chan os.Signal, 1)
+	signal.Notify(d.signals, os.Interrupt, syscall.SIGTERM)
+	go d.rotateRoutine()
+	return nil
+}
+
+// Terminates the process with a signal as without the transport
+func kill(sig os.Signal) {
+	if p, err := os.FindProcess(os.Getpid()); err == nil && p.Signal(sig) == nil {
+		return
+	}
+	os.Exit(1)
+}
+
+// Closes the file if it reached its maximum duration or if its partition changed
+func (d *ParquetDriver) rotate() error {
+	d.lock.Lock()
+	defer d.lock.Unlock()
+	if d.w == nil {
+		return nil
+	}
+	now := d.now().UTC()
+	if now.Sub(d.opened) >= d.interval || now.Format(d.partition) != d.current {
+		return d.closeFile()
+	}
+	return nil
+}
+
+func (d *ParquetDriver) rotateRoutine() {
+	defer close(d.done)
+	ticker := time.NewTicker(time.Second)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ticker.C:
+			if err := d.rotate(); err != nil {
+				log.Errorf("Parquet rotation: %v", err)
+			}
+		case sig := <-d.signals:
+			if err := d.closeFiles(); err != nil {
+				log.Errorf("Parquet: %v", err)
+			}
+			signal.Stop(d.signals)
+			d.kill(sig)
+			return
+		case <-d.q:
+			return
+		}
+	}
+}
+
+func (d *ParquetDriver) openFile() error {
+	now := d.now().UTC()
+	partition := now.Format(d.partition)
+	dir := filepath.Join(d.dir, partition)
+	if err := os.MkdirAll(dir, 0755); err != nil {
+		return err
+	}
+
+	// several files can be opened in the same second
+	base := FILE_PREFIX + now.Format("20060102T150405Z")
+	for i := 0; ; i++ {
+		name := base + FILE_EXTENSION
+		if i > 0 {
+			name = fmt.Sprintf("%s-%d%s", base, i, FILE_EXTENSION)
+		}
+		path := filepath.Join(dir, name)
+		if _, err := os.Stat(path); err == nil {
+			continue
+		}
+		file, err := os.OpenFile(filepath.Join(dir, TEMP_PREFIX+name), os.O_CREATE|os.O_EXCL|os.O_WRONLY, 0644)
+		if os.IsExist(err) {
+			continue
+		} else if err != nil {
+			return err
+		}
+		d.file = file
+		d.path = path
+		break
+	}
+
+	d.w = goparquet.NewFileWriter(d.file,
+		goparquet.WithSchemaDefinition(d.schema),
+		goparquet.WithCompressionCodec(compressionCodecs[d.compression]),
+		goparquet.WithMaxRowGroupSize(d.rowGroupSize),
+		goparquet.WithCreator("goflow2"),
+	)
+	d.opened = now
+	d.current = partition
+	return nil
+}
+
+// Writes the footer and renames the file: a file is visible once complete
+func (d *ParquetDriver) closeFile() error {
+	if d.w == nil {
+		return nil
+	}
+	err := d.w.Close()
+	if err == nil {
+		err = d.file.Sync()
+	}
+	if errClose := d.file.Close(); err == nil {
+		err = errClose
+	}
+	if err == nil {
+		err = os.Rename(d.file.Name(), d.path)
+	}
+	d.w = nil
+	d.file = nil
+	return err
+}
+
+func (d *ParquetDriver) write(msg *flowmessage.FlowMessage) error {
+	if d.closed {
+		return ErrClosed
+	}
+	if d.w == nil {
+		if err := d.openFile(); err != nil {
+			return err
+		}
+	}
+	if err := d.w.AddData(d.codec.Row(msg)); err != nil {
+		return err
+	}
+	if d.w.CurrentFileSize() >= d.maxSize {
+		return d.closeFile()
+	}
+	return nil
+}
+
+// Options data are skipped: they are not formatted by the parquet format
+func (d *ParquetDriver) Send(key, data []byte) error {
+	if len(data) == 0 {
+		return nil
+	}
+	utils.ParquetErrors.With(prometheus.Labels{"reason": "source"}).Inc()
+	return ErrNoSource
+}
+
+// Adds the flows to the current row group, written once full.
+// The messages which cannot be written are skipped and counted, the last error is returned.
+func (d *ParquetDriver) SendBatch(msgs []transport.Message) error {
+	d.lock.Lock()
+	defer d.lock.Unlock()
+	var errMsg error
+	for _, msg := range msgs {
+		flowMessage, ok := msg.Source.(*flowmessage.FlowMessage)
+		if !ok {
+			if msg.Source != nil || len(msg.Data) > 0 {
+				utils.ParquetErrors.With(prometheus.Labels{"reason": "source"}).Inc()
+				errMsg = ErrNoSource
+			}
+			continue
+		}
+		if err := d.write(flowMessage); err == ErrClosed {
+			return err
+		} else if err != nil {
+			utils.ParquetErrors.With(prometheus.Labels{"reason": "write"}).Inc()
+			errMsg = err
+		}
+	}
+	return errMsg
+}
+
+// The rows are written by row groups, when the size of a group or of a file is reached
+func (d *ParquetDriver) Flush() error {
+	return nil
+}
+
+func (d *ParquetDriver) closeFiles() error {
+	d.lock.Lock()
+	defer d.lock.Unlock()
+	if d.closed {
+		return nil
+	}
+	d.closed = true
+	return d.closeFile()
+}
+
+func (d *ParquetDriver) Close(context.Context) error {
+	if d.q == nil {
+		return nil
+	}
+	err := d.closeFiles()
+	signal.Stop(d.signals)
+	d.stop.Do(func() {
+		close(d.q)
+	})
+	<-d.done
+	return err
+}
+
+func init() {
+	d := &ParquetDriver{
+		lock: &sync.Mutex{},
+		now:  time.Now,
+		kill: kill,
+	}
+	transport.RegisterTransportDriver("parquet", d)
+}
@@ -0,0 +1,233 @@
+package parquet
+
+import (
+	"context"
+	"flag"
+	"io"
+	"os"
+	"path/filepath"
+	"sync"
+	"syscall"
+	"testing"
+	"time"
+
+	goparquet "github.com/fraugster/parquet-go"
+	flowmessage "github.com/netsampler/goflow2/pb"
+	"github.com/netsampler/goflow2/transport"
+	"github.com/netsampler/goflow2/utils"
+	"github.com/prometheus/client_golang/prometheus"
+	dto "github.com/prometheus/client_model/go"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type testClock struct {
+	lock *sync.Mutex
+	t    time.Time
+}
+
+func (c *testClock) Now() time.Time {
+	c.lock.Lock()
+	defer c.lock.Unlock()
+	return c.t
+}
+
+func (c *testClock) Add(d time.Duration) {
+	c.lock.Lock()
+	c.t = c.t.Add(d)
+	c.lock.Unlock()
+}
+
+func newTestDriver(t *testing.T, now time.Time) (*ParquetDriver, *testClock) {
+	clock := &testClock{
+		lock: &sync.Mutex{},
+		t:    now,
+	}
+	d := &ParquetDriver{
+		dir:          t.TempDir(),
+		partition:    "dt=2006-01-02/hour=15",
+		maxSize:      128 << 20,
+		interval:     time.Minute * 10,
+		rowGroupSize: 32 << 20,
+		compression:  "snappy",
+		lock:         &sync.Mutex{},
+		now:          clock.Now,
+		kill:         func(os.Signal) {},
+	}
+	require.NoError(t, d.Init(context.Background()))
+	return d, clock
+}
+
+// Message of a flow sent with the parquet format
+func flow(bytes uint64) transport.Message {
+	return transport.Message{
+		Source: &flowmessage.FlowMessage{
+			Type:           flowmessage.FlowMessage_SFLOW_5,
+			SamplerAddress: []byte{192, 0, 2, 1},
+			Bytes:          bytes,
+		},
+	}
+}
+
+func errorCount(t *testing.T, reason string) float64 {
+	var metric dto.Metric
+	require.NoError(t, utils.ParquetErrors.With(prometheus.Labels{"reason": reason}).Write(&metric))
+	return metric.GetCounter().GetValue()
+}
+
+// Returns the files of a directory, relative to it
+func listFiles(t *testing.T, dir string) []string {
+	var files []string
+	require.NoError(t, filepath.Walk(dir, func(path string, info os.FileInfo, err error) error {
+		if err == nil && !info.IsDir() {
+			rel, _ := filepath.Rel(dir, path)
+			files = append(files, filepath.ToSlash(rel))
+		}
+		return err
+	}))
+	return files
+}
+
+func readRows(t *testing.T, path string) []map[string]interface{} {
+	file, err := os.Open(path)
+	require.NoError(t, err)
+	defer file.Close()
+	r, err := goparquet.NewFileReader(file)
+	require.NoError(t, err)
+	var rows []map[string]interface{}
+	for {
+		row, err := r.NextRow()
+		if err == io.EOF {
+			break
+		}
+		require.NoError(t, err)
+		rows = append(rows, row)
+	}
+	return rows
+}
+
+func TestParquetRotation(t *testing.T) {
+	d, clock := newTestDriver(t, time.Date(2023, 3, 1, 10, 55, 0, 0, time.UTC))
+	defer d.Close(context.Background())
+
+	assert.Empty(t, listFiles(t, d.dir), "no file before the first row")
+	require.NoError(t, d.SendBatch([]transport.Message{flow(100)}))
+	require.NoError(t, d.SendBatch([]transport.Message{flow(200), flow(300)}))
+	require.NoError(t, d.Send(nil, nil)) // options data
+	require.NoError(t, d.Flush())
+	assert.Equal(t, []string{"dt=2023-03-01/hour=10/.flows-20230301T105500Z.parquet"}, listFiles(t, d.dir),
+		"hidden until closed")
+
+	// partition change before the end of the interval
+	clock.Add(time.Minute * 5)
+	require.NoError(t, d.rotate())
+	assert.Equal(t, []string{"dt=2023-03-01/hour=10/flows-20230301T105500Z.parquet"}, listFiles(t, d.dir))
+	rows := readRows(t, filepath.Join(d.dir, "dt=2023-03-01/hour=10/flows-20230301T105500Z.parquet"))
+	require.Len(t, rows, 3)
+	assert.Equal(t, []byte("SFLOW_5"), rows[0]["type"])
+	assert.Equal(t, []byte{192, 0, 2, 1}, rows[0]["sampler_address"])
+	assert.Equal(t, int64(300), rows[2]["bytes"])
+
+	// interval
+	require.NoError(t, d.SendBatch([]transport.Message{flow(400)}))
+	clock.Add(time.Minute * 9)
+	require.NoError(t, d.rotate())
+	assert.Len(t, listFiles(t, d.dir), 2, "still open")
+	clock.Add(time.Minute)
+	require.NoError(t, d.rotate())
+	assert.Contains(t, listFiles(t, d.dir), "dt=2023-03-01/hour=11/flows-20230301T110000Z.parquet")
+
+	// size, checked after each row group: one file per row
+	d.maxSize = 1
+	d.rowGroupSize = 1
+	require.NoError(t, d.SendBatch([]transport.Message{flow(500), flow(600)}))
+	assert.Equal(t, []string{
+		"dt=2023-03-01/hour=10/flows-20230301T105500Z.parquet",
+		"dt=2023-03-01/hour=11/flows-20230301T110000Z.parquet",
+		"dt=2023-03-01/hour=11/flows-20230301T111000Z-1.parquet",
+		"dt=2023-03-01/hour=11/flows-20230301T111000Z.parquet",
+	}, listFiles(t, d.dir))
+	assert.Len(t, readRows(t, filepath.Join(d.dir, "dt=2023-03-01/hour=11/flows-20230301T111000Z-1.parquet")), 1)
+}
+
+func TestParquetInvalidMessages(t *testing.T) {
+	d, _ := newTestDriver(t, time.Date(2023, 3, 1, 10, 55, 0, 0, time.UTC))
+	errors := errorCount(t, "source")
+
+	// the other messages of the batch are written
+	assert.Equal(t, ErrNoSource, d.SendBatch([]transport.Message{
+		flow(100),
+		{Data: []byte("formatted"), Source: &flowmessage.OptionsMessage{}},
+		{Data: []byte("formatted")},
+		flow(200),
+	}))
+	assert.Equal(t, errors+2, errorCount(t, "source"))
+	assert.Equal(t, ErrNoSource, d.Send(nil, []byte("formatted")))
+	assert.Equal(t, errors+3, errorCount(t, "source"))
+	require.NoError(t, d.Close(context.Background()))
+
+	assert.Len(t, readRows(t, filepath.Join(d.dir, "dt=2023-03-01/hour=10/flows-20230301T105500Z.parquet")), 2)
+}
+
+func TestParquetClose(t *testing.T) {
+	d, _ := newTestDriver(t, time.Date(2023, 3, 1, 10, 55, 0, 0, time.UTC))
+	require.NoError(t, d.SendBatch([]transport.Message{flow(100)}))
+	require.NoError(t, d.Close(context.Background()))
+
+	assert.Equal(t, []string{"dt=2023-03-01/hour=10/flows-20230301T105500Z.parquet"}, listFiles(t, d.dir))
+	assert.Len(t, readRows(t, filepath.Join(d.dir, "dt=2023-03-01/hour=10/flows-20230301T105500Z.parquet")), 1)
+	assert.Equal(t, ErrClosed, d.SendBatch([]transport.Message{flow(200)}))
+	assert.NoError(t, d.Close(context.Background()))
+}
+
+func TestParquetSignal(t *testing.T) {
+	d, _ := newTestDriver(t, time.Date(2023, 3, 1, 10, 55, 0, 0, time.UTC))
+	killed := make(chan os.Signal, 1)
+	d.kill = func(sig os.Signal) {
+		killed <- sig
+	}
+	require.NoError(t, d.SendBatch([]transport.Message{flow(100)}))
+
+	d.signals <- syscall.SIGTERM
+	assert.Equal(t, syscall.SIGTERM, <-killed, "terminated once the files are closed")
+	assert.Equal(t, []string{"dt=2023-03-01/hour=10/flows-20230301T105500Z.parquet"}, listFiles(t, d.dir))
+	assert.Len(t, readRows(t, filepath.Join(d.dir, "dt=2023-03-01/hour=10/flows-20230301T105500Z.parquet")), 1)
+	assert.NoError(t, d.Close(context.Background()))
+}
+
+func TestParquetReusedBuffer(t *testing.T) {
+	d, _ := newTestDriver(t, time.Date(2023, 3, 1, 10, 55, 0, 0, time.UTC))
+
+	// the fields point into the received packet, reused once the batch is sent
+	packet := []byte{192, 0, 2, 1, 198, 51, 100, 1, 0x20, 0x01, 0x0d, 0xb8}
+	require.NoError(t, d.SendBatch([]transport.Message{{
+		Source: &flowmessage.FlowMessage{
+			SrcAddr:      packet[0:4],
+			DstAddr:      packet[4:8],
+			Srv6Segments: [][]byte{packet[8:12]},
+		},
+	}}))
+	for i := range packet {
+		packet[i] = 0xff
+	}
+	require.NoError(t, d.Close(context.Background()))
+
+	rows := readRows(t, filepath.Join(d.dir, "dt=2023-03-01/hour=10/flows-20230301T105500Z.parquet"))
+	require.Len(t, rows, 1)
+	assert.Equal(t, []byte{192, 0, 2, 1}, rows[0]["src_addr"])
+	assert.Equal(t, []byte{198, 51, 100, 1}, rows[0]["dst_addr"])
+	assert.Equal(t, map[string]interface{}{
+		"list": []map[string]interface{}{{"element": []byte{0x20, 0x01, 0x0d, 0xb8}}},
+	}, rows[0]["srv6_segments"])
+}
+
+func TestParquetSelector(t *testing.T) {
+	require.NoError(t, flag.Set("format.selector", "SamplerAddress,Bytes"))
+	d, _ := newTestDriver(t, time.Date(2023, 3, 1, 10, 55, 0, 0, time.UTC))
+	require.NoError(t, d.SendBatch([]transport.Message{flow(100)}))
+	require.NoError(t, d.Close(context.Background()))
+
+	assert.Equal(t, []map[string]interface{}{
+		{"sampler_address": []byte{192, 0, 2, 1}, "bytes": int64(100)},
+	}, readRows(t, filepath.Join(d.dir, "dt=2023-03-01/hour=10/flows-20230301T105500Z.parquet")))
+}
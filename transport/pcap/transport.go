@@ -0,0 +1,134 @@
+package pcap
+
+import (
+	"context"
+	"encoding/binary"
+	"flag"
+	"io"
+	"os"
+	"os/signal"
+	"sync"
+	"syscall"
+
+	"github.com/netsampler/goflow2/transport"
+)
+
+const (
+	PCAP_MAGIC        = 0xa1b2c3d4 // microsecond timestamps
+	PCAP_SNAPLEN      = 65535
+	LINKTYPE_ETHERNET = 1
+)
+
+// Writes the records produced by the pcap format into a pcap file
+type PcapDriver struct {
+	fileDestination string
+	w               io.Writer
+	file            *os.File
+	lock            *sync.RWMutex
+	q               chan bool
+}
+
+func (d *PcapDriver) Prepare() error {
+	flag.StringVar(&d.fileDestination, "transport.pcap", "", "pcap file output (empty for stdout), use with -format=pcap")
+	return nil
+}
+
+func FileHeader() []byte {
+	header := make([]byte, 24)
+	binary.LittleEndian.PutUint32(header[0:4], PCAP_MAGIC)
+	binary.LittleEndian.PutUint16(header[4:6], 2) // version 2.4
+	binary.LittleEndian.PutUint16(header[6:8], 4)
+	binary.LittleEndian.PutUint32(header[16:20], PCAP_SNAPLEN)
+	binary.LittleEndian.PutUint32(header[20:24], LINKTYPE_ETHERNET)
+	return header
+}
+
+func (d *PcapDriver) openFile() error {
+	file, err := os.OpenFile(d.fileDestination, os.O_APPEND|os.O_CREATE|os.O_WRONLY, 0644)
+	if err != nil {
+		return err
+	}
+	// appending to an existing capture keeps its header
+	stat, err := file.Stat()
+	if err == nil && stat.Size() == 0 {
+		_, err = file.Write(FileHeader())
+	}
+	if err != nil {
+		file.Close()
+		return err
+	}
+	d.file = file
+	d.w = d.file
+	return nil
+}
+
+// Opens the file again (eg: after a rotation), the old file is closed once the new one is open
+func (d *PcapDriver) reopen() error {
+	d.lock.Lock()
+	defer d.lock.Unlock()
+	file := d.file
+	if err := d.openFile(); err != nil {
+		// keeps using the old file
+		return err
+	}
+	return file.Close()
+}
+
+func (d *PcapDriver) Init(context.Context) error {
+	d.q = make(chan bool, 1)
+
+	if d.fileDestination == "" {
+		d.w = os.Stdout
+		_, err := d.w.Write(FileHeader())
+		return err
+	}
+
+	d.lock.Lock()
+	err := d.openFile()
+	d.lock.Unlock()
+	if err != nil {
+		return err
+	}
+
+	c := make(chan os.Signal, 1)
+	signal.Notify(c, syscall.SIGHUP)
+	go func() {
+		for {
+			select {
+			case <-c:
+				d.reopen()
+			case <-d.q:
+				return
+			}
+		}
+	}()
+	return nil
+}
+
+func (d *PcapDriver) Send(key, data []byte) error {
+	if len(data) == 0 {
+		return nil // message without a sampled header
+	}
+	d.lock.Lock()
+	defer d.lock.Unlock()
+	_, err := d.w.Write(data)
+	return err
+}
+
+func (d *PcapDriver) Close(context.Context) error {
+	if d.fileDestination != "" {
+		d.lock.Lock()
+		d.file.Close()
+		d.lock.Unlock()
+		signal.Ignore(syscall.SIGHUP)
+	}
+	close(d.q)
+	return nil
+}
+
+func init() {
+	d := &PcapDriver{
+		lock: &sync.RWMutex{},
+	}
+	transport.RegisterTransportDriver("pcap", d)
+}
@@ -0,0 +1,37 @@
+package pcap
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"sync"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestPcapReopen(t *testing.T) {
+	dir := filepath.Join(t.TempDir(), "captures")
+	require.NoError(t, os.Mkdir(dir, 0755))
+	d := &PcapDriver{
+		fileDestination: filepath.Join(dir, "samples.pcap"),
+		lock:            &sync.RWMutex{},
+	}
+	require.NoError(t, d.Init(context.Background()))
+	defer d.Close(context.Background())
+	require.NoError(t, d.Send(nil, []byte("record 1")))
+
+	// rotated: a new file is written
+	require.NoError(t, os.Rename(d.fileDestination, d.fileDestination+".1"))
+	require.NoError(t, d.reopen())
+	require.NoError(t, d.Send(nil, []byte("record 2")))
+	data, err := os.ReadFile(d.fileDestination)
+	require.NoError(t, err)
+	assert.Equal(t, append(FileHeader(), "record 2"...), data)
+
+	// the new file cannot be opened: the old one is kept
+	require.NoError(t, os.RemoveAll(dir))
+	assert.Error(t, d.reopen())
+	assert.NoError(t, d.Send(nil, []byte("record 3")))
+}
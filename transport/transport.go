@@ -22,6 +22,32 @@ type TransportInterface interface {
 	Send(key, data []byte) error
 }
 
+// A formatted message
+type Message struct {
+	Key    []byte
+	Data   []byte
+	Source interface{} // message before formatting (eg: *flowmessage.FlowMessage), optional
+}
+
+// Optional: drivers receiving the messages of a packet at once (eg: buffered writes).
+// The messages are formatted one by one before, Key and Data may be kept by the driver until Flush is called.
+// Source must not be kept after SendBatch returns: its bytes (eg: addresses, sampled headers)
+// point into the received packet, whose buffer is reused once decoded. Copy what is kept.
+type BatchTransport interface {
+	SendBatch(msgs []Message) error // Send formatted messages
+	Flush() error                   // Write the pending messages
+}
+
+// Optional: drivers sending NetFlow/IPFIX options data to a different destination (eg: Kafka topic)
+type TransportOptionsDriver interface {
+	SendOptions(key, data []byte) error
+}
+
+// Optional: drivers using the name of the format of the messages (eg: Kafka headers)
+type TransportFormatDriver interface {
+	SetFormat(name string)
+}
+
 type Transport struct {
 	driver TransportDriver
 }
@@ -33,6 +59,36 @@ func (t *Transport) Send(key, data []byte) error {
 	return t.driver.Send(key, data)
 }
 
+// Sends the messages at once if the driver supports batches, otherwise one by one
+func (t *Transport) SendBatch(msgs []Message) error {
+	if d, ok := t.driver.(BatchTransport); ok {
+		return d.SendBatch(msgs)
+	}
+	for _, msg := range msgs {
+		if err := t.driver.Send(msg.Key, msg.Data); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+func (t *Transport) Flush() error {
+	if d, ok := t.driver.(BatchTransport); ok {
+		return d.Flush()
+	}
+	return nil
+}
+func (t *Transport) SetFormat(name string) {
+	if d, ok := t.driver.(TransportFormatDriver); ok {
+		d.SetFormat(name)
+	}
+}
+func (t *Transport) SendOptions(key, data []byte) error {
+	if d, ok := t.driver.(TransportOptionsDriver); ok {
+		return d.SendOptions(key, data)
+	}
+	return t.driver.Send(key, data)
+}
+
 func RegisterTransportDriver(name string, t TransportDriver) {
 	lock.Lock()
 	transportDrivers[name] = t
@@ -30,6 +30,34 @@ var (
 		},
 		[]string{"remote_ip", "local_ip", "local_port", "type"},
 	)
+	MetricQueueDepth = prometheus.NewGaugeVec(
+		prometheus.GaugeOpts{
+			Name: "flow_queue_depth",
+			Help: "Datagrams waiting to be decoded.",
+		},
+		[]string{"local_ip", "local_port", "type"},
+	)
+	MetricQueueDrops = prometheus.NewCounterVec(
+		prometheus.CounterOpts{
+			Name: "flow_queue_drops_count",
+			Help: "Datagrams dropped because the decoding queue was full.",
+		},
+		[]string{"local_ip", "local_port", "type"},
+	)
+	MetricKernelDrops = prometheus.NewCounterVec(
+		prometheus.CounterOpts{
+			Name: "flow_socket_drops_count",
+			Help: "Datagrams dropped by the kernel because the socket buffer was full.",
+		},
+		[]string{"local_ip", "local_port", "type"},
+	)
+	MetricQuarantine = prometheus.NewCounterVec(
+		prometheus.CounterOpts{
+			Name: "flow_quarantine_count",
+			Help: "Datagrams which could not be decoded written to the quarantine directory.",
+		},
+		[]string{"type"},
+	)
 	DecoderStats = prometheus.NewCounterVec(
 		prometheus.CounterOpts{
 			Name: "flow_decoder_count",
@@ -131,12 +159,71 @@ var (
 		},
 		[]string{"router", "agent", "version", "type"}, // data-template, data, opts...
 	)
+	ExporterLostSum = prometheus.NewCounterVec(
+		prometheus.CounterOpts{
+			Name: "flow_exporter_lost_sum",
+			Help: "Estimated packets, records or samples lost by exporter (sequence number gaps).",
+		},
+		[]string{"router", "version", "domain", "type"}, // packets, records, flow_samples, counter_samples, drop_samples
+	)
+	ExporterReorderedSum = prometheus.NewCounterVec(
+		prometheus.CounterOpts{
+			Name: "flow_exporter_reordered_sum",
+			Help: "Packets, records or samples received out of order by exporter (previously counted as lost).",
+		},
+		[]string{"router", "version", "domain", "type"},
+	)
+	ExporterRestartsCount = prometheus.NewCounterVec(
+		prometheus.CounterOpts{
+			Name: "flow_exporter_restarts_count",
+			Help: "Exporter restarts (sequence number resets).",
+		},
+		[]string{"router", "version", "domain", "type"},
+	)
+	KafkaDeliverySuccess = prometheus.NewCounterVec(
+		prometheus.CounterOpts{
+			Name: "flow_transport_kafka_success_count",
+			Help: "Messages acknowledged by Kafka.",
+		},
+		[]string{"topic"},
+	)
+	KafkaDeliveryErrors = prometheus.NewCounterVec(
+		prometheus.CounterOpts{
+			Name: "flow_transport_kafka_error_count",
+			Help: "Messages which failed to be delivered to Kafka.",
+		},
+		[]string{"topic"},
+	)
+	KafkaSpill = prometheus.NewCounterVec(
+		prometheus.CounterOpts{
+			Name: "flow_transport_kafka_spill_count",
+			Help: "Undelivered messages written to, retried from or dropped by the spill buffer.",
+		},
+		[]string{"topic", "action"}, // spilled, retried, dropped
+	)
+	KafkaSpillBytes = prometheus.NewGauge(
+		prometheus.GaugeOpts{
+			Name: "flow_transport_kafka_spill_bytes",
+			Help: "Size of the spill buffer on disk.",
+		},
+	)
+	ParquetErrors = prometheus.NewCounterVec(
+		prometheus.CounterOpts{
+			Name: "flow_transport_parquet_error_count",
+			Help: "Messages which could not be written into Parquet files.",
+		},
+		[]string{"reason"}, // source, write
+	)
 )
 
 func init() {
 	prometheus.MustRegister(MetricTrafficBytes)
 	prometheus.MustRegister(MetricTrafficPackets)
 	prometheus.MustRegister(MetricPacketSizeSum)
+	prometheus.MustRegister(MetricQueueDepth)
+	prometheus.MustRegister(MetricQueueDrops)
+	prometheus.MustRegister(MetricKernelDrops)
+	prometheus.MustRegister(MetricQuarantine)
 
 	prometheus.MustRegister(DecoderStats)
 	prometheus.MustRegister(DecoderErrors)
@@ -154,6 +241,16 @@ func init() {
 	prometheus.MustRegister(SFlowErrors)
 	prometheus.MustRegister(SFlowSampleStatsSum)
 	prometheus.MustRegister(SFlowSampleRecordsStatsSum)
+
+	prometheus.MustRegister(ExporterLostSum)
+	prometheus.MustRegister(ExporterReorderedSum)
+	prometheus.MustRegister(ExporterRestartsCount)
+
+	prometheus.MustRegister(KafkaDeliverySuccess)
+	prometheus.MustRegister(KafkaDeliveryErrors)
+	prometheus.MustRegister(KafkaSpill)
+	prometheus.MustRegister(KafkaSpillBytes)
+	prometheus.MustRegister(ParquetErrors)
 }
 
 func DefaultAccountCallback(name string, id int, start, end time.Time) {
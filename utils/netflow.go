@@ -3,6 +3,7 @@ package utils
 import (
 	"bytes"
 	"context"
+	"strconv"
 	"sync"
 	"time"
 
@@ -54,9 +55,10 @@ func (s *TemplateSystem) GetTemplate(version uint16, obsDomainId uint32, templat
 type StateNetFlow struct {
 	stopper
 
-	Format    format.FormatInterface
-	Transport transport.TransportInterface
-	Logger    Logger
+	Format     format.FormatInterface
+	Transport  transport.TransportInterface
+	Logger     Logger
+	UDPOptions UDPOptions
 	/*templateslock *sync.RWMutex
 	templates     map[string]*TemplateSystem*/
 
@@ -68,6 +70,8 @@ type StateNetFlow struct {
 
 	TemplateSystem templates.TemplateInterface
 
+	sequence *SequenceTracker
+
 	ctx context.Context
 }
 
@@ -76,6 +80,7 @@ func NewStateNetFlow() *StateNetFlow {
 		ctx:          context.Background(),
 		samplinglock: &sync.RWMutex{},
 		sampling:     make(map[string]producer.SamplingRateSystem),
+		sequence:     NewSequenceTracker(),
 	}
 }
 
@@ -99,13 +104,15 @@ func (s *StateNetFlow) DecodeFlow(msg interface{}) error {
 		s.samplinglock.Unlock()
 	}
 
-	ts := uint64(time.Now().UTC().Unix())
+	recvTime := time.Now().UTC()
 	if pkt.SetTime {
-		ts = uint64(pkt.RecvTime.UTC().Unix())
+		recvTime = pkt.RecvTime.UTC()
 	}
+	ts := uint64(recvTime.Unix())
+	tsNs := uint64(recvTime.UnixNano())
 
 	timeTrackStart := time.Now()
-	msgDec, err := netflow.DecodeMessageContext(s.ctx, buf, key, netflow.TemplateWrapper{s.ctx, key, s.TemplateSystem})
+	msgDec, err := netflow.DecodeMessageContext(s.ctx, buf, key, netflow.TemplateWrapper{Ctx: s.ctx, Key: key, Inner: s.TemplateSystem})
 	if err != nil {
 		switch err.(type) {
 		case *netflow.ErrorTemplateNotFound:
@@ -137,6 +144,13 @@ func (s *StateNetFlow) DecodeFlow(msg interface{}) error {
 			}).
 			Inc()
 
+		s.sequence.Account(SequenceKey{
+			Router:  key,
+			Version: "9",
+			Domain:  strconv.Itoa(int(msgDecConv.SourceId)),
+			Type:    "packets",
+		}, msgDecConv.SequenceNumber, 1, SequenceWindowPackets)
+
 		for _, fs := range msgDecConv.FlowSets {
 			switch fsConv := fs.(type) {
 			case netflow.TemplateFlowSet:
@@ -211,6 +225,7 @@ func (s *StateNetFlow) DecodeFlow(msg interface{}) error {
 
 		for _, fmsg := range flowMessageSet {
 			fmsg.TimeReceived = ts
+			fmsg.TimeReceivedNs = tsNs
 			fmsg.SamplerAddress = samplerAddress
 			timeDiff := fmsg.TimeReceived - fmsg.TimeFlowEnd
 			NetFlowTimeStatsSum.With(
@@ -228,6 +243,7 @@ func (s *StateNetFlow) DecodeFlow(msg interface{}) error {
 			}).
 			Inc()
 
+		var dataRecords int
 		for _, fs := range msgDecConv.FlowSets {
 			switch fsConv := fs.(type) {
 			case netflow.TemplateFlowSet:
@@ -281,6 +297,7 @@ func (s *StateNetFlow) DecodeFlow(msg interface{}) error {
 						"type":    "OptionsDataFlowSet",
 					}).
 					Add(float64(len(fsConv.Records)))
+				dataRecords += len(fsConv.Records)
 
 			case netflow.DataFlowSet:
 				NetFlowSetStatsSum.With(
@@ -298,12 +315,22 @@ func (s *StateNetFlow) DecodeFlow(msg interface{}) error {
 						"type":    "DataFlowSet",
 					}).
 					Add(float64(len(fsConv.Records)))
+				dataRecords += len(fsConv.Records)
 			}
 		}
+
+		s.sequence.Account(SequenceKey{
+			Router:  key,
+			Version: "10",
+			Domain:  strconv.Itoa(int(msgDecConv.ObservationDomainId)),
+			Type:    "records",
+		}, msgDecConv.SequenceNumber, uint32(dataRecords), SequenceWindowRecords)
+
 		flowMessageSet, err = producer.ProcessMessageNetFlowConfig(msgDecConv, sampling, s.configMapped)
 
 		for _, fmsg := range flowMessageSet {
 			fmsg.TimeReceived = ts
+			fmsg.TimeReceivedNs = tsNs
 			fmsg.SamplerAddress = samplerAddress
 			timeDiff := fmsg.TimeReceived - fmsg.TimeFlowEnd
 			NetFlowTimeStatsSum.With(
@@ -315,6 +342,13 @@ func (s *StateNetFlow) DecodeFlow(msg interface{}) error {
 		}
 	}
 
+	optionsMessageSet, _ := producer.ProcessMessageNetFlowOptionsConfig(msgDec, s.configMapped)
+	for _, omsg := range optionsMessageSet {
+		omsg.TimeReceived = ts
+		omsg.TimeReceivedNs = tsNs
+		omsg.SamplerAddress = samplerAddress
+	}
+
 	timeTrackStop := time.Now()
 	DecoderTime.With(
 		prometheus.Labels{
@@ -322,16 +356,22 @@ func (s *StateNetFlow) DecodeFlow(msg interface{}) error {
 		}).
 		Observe(float64((timeTrackStop.Sub(timeTrackStart)).Nanoseconds()) / 1000)
 
-	for _, fmsg := range flowMessageSet {
+	sendFlowMessages(s.Format, s.Transport, s.Logger, flowMessageSet)
+
+	for _, omsg := range optionsMessageSet {
 		if s.Format != nil {
-			key, data, err := s.Format.Format(fmsg)
+			key, data, err := s.Format.Format(omsg)
 
 			if err != nil && s.Logger != nil {
 				s.Logger.Error(err)
 			}
 			if err == nil && s.Transport != nil {
-				err = s.Transport.Send(key, data)
-				if err != nil {
+				if t, ok := s.Transport.(transport.TransportOptionsDriver); ok {
+					err = t.SendOptions(key, data)
+				} else {
+					err = s.Transport.Send(key, data)
+				}
+				if err != nil && s.Logger != nil {
 					s.Logger.Error(err)
 				}
 			}
@@ -371,7 +411,10 @@ func (s *StateNetFlow) FlowRoutine(workers int, addr string, port int, reuseport
 	}
 	//s.InitTemplates()
 	s.initConfig()
-	return UDPStoppableRoutine(s.stopCh, "NetFlow", s.DecodeFlow, workers, addr, port, reuseport, s.Logger)
+	if s.sequence == nil {
+		s.sequence = NewSequenceTracker()
+	}
+	return UDPStoppableRoutineOptions(s.stopCh, "NetFlow", s.DecodeFlow, workers, addr, port, reuseport, s.Logger, s.UDPOptions)
 }
 
 // FlowRoutineCtx?
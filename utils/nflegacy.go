@@ -2,6 +2,7 @@ package utils
 
 import (
 	"bytes"
+	"fmt"
 	"time"
 
 	"github.com/netsampler/goflow2/decoders/netflowlegacy"
@@ -15,13 +16,18 @@ import (
 type StateNFLegacy struct {
 	stopper
 
-	Format    format.FormatInterface
-	Transport transport.TransportInterface
-	Logger    Logger
+	Format     format.FormatInterface
+	Transport  transport.TransportInterface
+	Logger     Logger
+	UDPOptions UDPOptions
+
+	sequence *SequenceTracker
 }
 
 func NewStateNFLegacy() *StateNFLegacy {
-	return &StateNFLegacy{}
+	return &StateNFLegacy{
+		sequence: NewSequenceTracker(),
+	}
 }
 
 func (s *StateNFLegacy) DecodeFlow(msg interface{}) error {
@@ -33,10 +39,12 @@ func (s *StateNFLegacy) DecodeFlow(msg interface{}) error {
 		samplerAddress = samplerAddress.To4()
 	}
 
-	ts := uint64(time.Now().UTC().Unix())
+	recvTime := time.Now().UTC()
 	if pkt.SetTime {
-		ts = uint64(pkt.RecvTime.UTC().Unix())
+		recvTime = pkt.RecvTime.UTC()
 	}
+	ts := uint64(recvTime.Unix())
+	tsNs := uint64(recvTime.UnixNano())
 
 	timeTrackStart := time.Now()
 	msgDec, err := netflowlegacy.DecodeMessage(buf)
@@ -69,6 +77,14 @@ func (s *StateNFLegacy) DecodeFlow(msg interface{}) error {
 				"type":    "DataFlowSet",
 			}).
 			Add(float64(msgDecConv.Count))
+
+		// the sequence number counts flows
+		s.sequence.Account(SequenceKey{
+			Router:  key,
+			Version: "5",
+			Domain:  fmt.Sprintf("%d/%d", msgDecConv.EngineType, msgDecConv.EngineId),
+			Type:    "records",
+		}, msgDecConv.FlowSequence, uint32(msgDecConv.Count), SequenceWindowRecords)
 	}
 
 	var flowMessageSet []*flowmessage.FlowMessage
@@ -83,22 +99,10 @@ func (s *StateNFLegacy) DecodeFlow(msg interface{}) error {
 
 	for _, fmsg := range flowMessageSet {
 		fmsg.TimeReceived = ts
+		fmsg.TimeReceivedNs = tsNs
 		fmsg.SamplerAddress = samplerAddress
-
-		if s.Format != nil {
-			key, data, err := s.Format.Format(fmsg)
-
-			if err != nil && s.Logger != nil {
-				s.Logger.Error(err)
-			}
-			if err == nil && s.Transport != nil {
-				err = s.Transport.Send(key, data)
-				if err != nil {
-					s.Logger.Error(err)
-				}
-			}
-		}
 	}
+	sendFlowMessages(s.Format, s.Transport, s.Logger, flowMessageSet)
 
 	return nil
 }
@@ -107,5 +111,8 @@ func (s *StateNFLegacy) FlowRoutine(workers int, addr string, port int, reusepor
 	if err := s.start(); err != nil {
 		return err
 	}
-	return UDPStoppableRoutine(s.stopCh, "NetFlowV5", s.DecodeFlow, workers, addr, port, reuseport, s.Logger)
+	if s.sequence == nil {
+		s.sequence = NewSequenceTracker()
+	}
+	return UDPStoppableRoutineOptions(s.stopCh, "NetFlowV5", s.DecodeFlow, workers, addr, port, reuseport, s.Logger, s.UDPOptions)
 }
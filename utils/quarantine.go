@@ -0,0 +1,86 @@
+package utils
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/netsampler/goflow2/decoders/netflow"
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+// Quarantine writes the datagrams which could not be decoded (decoding errors and decoder panics)
+// into a directory for later analysis, for instance to add them to the fuzzing corpus.
+type Quarantine struct {
+	Dir      string
+	MaxFiles int // datagrams written at most, 0 for no limit
+	Logger   Logger
+
+	lock    sync.Mutex
+	written int
+}
+
+func NewQuarantine(dir string, maxFiles int, logger Logger) (*Quarantine, error) {
+	if err := os.MkdirAll(dir, 0755); err != nil {
+		return nil, err
+	}
+	return &Quarantine{
+		Dir:      dir,
+		MaxFiles: maxFiles,
+		Logger:   logger,
+	}, nil
+}
+
+// Returns true when the error is caused by the content of the datagram.
+// A missing template is expected until the exporter sends it.
+func isQuarantineError(err error) bool {
+	if err == nil {
+		return false
+	}
+	if _, ok := err.(*netflow.ErrorTemplateNotFound); ok {
+		return false
+	}
+	return true
+}
+
+// Writes the payload of a message which failed to decode.
+// Returns the path of the file, empty when the error is not quarantined or the limit is reached.
+func (q *Quarantine) Add(name string, msg BaseMessage, decodeErr error) (string, error) {
+	if !isQuarantineError(decodeErr) {
+		return "", nil
+	}
+	q.lock.Lock()
+	if q.MaxFiles > 0 && q.written >= q.MaxFiles {
+		q.lock.Unlock()
+		return "", nil
+	}
+	q.written++
+	id := q.written
+	q.lock.Unlock()
+
+	recvTime := msg.RecvTime
+	if !msg.SetTime {
+		recvTime = time.Now()
+	}
+	src := "unknown"
+	if msg.Src != nil {
+		src = strings.ReplaceAll(msg.Src.String(), ":", "_") // IPv6 addresses in file names
+	}
+	path := filepath.Join(q.Dir, fmt.Sprintf("%s-%s-%d-%d.bin", name, src, recvTime.UnixNano(), id))
+	if err := os.WriteFile(path, msg.Payload, 0644); err != nil {
+		return "", err
+	}
+
+	MetricQuarantine.With(
+		prometheus.Labels{
+			"type": name,
+		}).
+		Inc()
+	if q.Logger != nil {
+		q.Logger.Warnf("Quarantined datagram from %v in %s: %v", msg.Src, path, decodeErr)
+	}
+	return path, nil
+}
@@ -0,0 +1,128 @@
+package utils
+
+import (
+	"sync"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+const (
+	SEQUENCE_OK = iota
+	SEQUENCE_FIRST
+	SEQUENCE_GAP
+	SEQUENCE_REORDERED
+	SEQUENCE_RESET
+)
+
+const (
+	// Jumps larger than the window are considered as an exporter restart
+	SequenceWindowPackets = 1000
+	SequenceWindowRecords = 1000000
+
+	// Sequences of exporters which did not send anything for this duration are forgotten
+	SequenceTTL = time.Hour
+)
+
+type SequenceKey struct {
+	Router  string
+	Version string
+	Domain  string
+	Type    string // packets, records, flow_samples, counter_samples, drop_samples
+}
+
+type sequenceEntry struct {
+	next uint32
+	seen time.Time
+}
+
+type SequenceTracker struct {
+	lock   *sync.Mutex
+	next   map[SequenceKey]*sequenceEntry
+	ttl    time.Duration
+	now    func() time.Time
+	purged time.Time
+}
+
+func NewSequenceTracker() *SequenceTracker {
+	return &SequenceTracker{
+		lock: &sync.Mutex{},
+		next: make(map[SequenceKey]*sequenceEntry),
+		ttl:  SequenceTTL,
+		now:  time.Now,
+	}
+}
+
+// Removes the sequences not seen for the TTL, at most once per TTL
+func (t *SequenceTracker) purge(now time.Time) {
+	if now.Sub(t.purged) < t.ttl {
+		return
+	}
+	t.purged = now
+	for key, entry := range t.next {
+		if now.Sub(entry.seen) >= t.ttl {
+			delete(t.next, key)
+		}
+	}
+}
+
+// Checks a sequence number against the expected one and stores the next expected value.
+// The count is the amount of units (packets, records or samples) carried by the message.
+// Returns the status and the amount of missing units when a gap is detected.
+// An exporter which was not seen for the TTL is checked as a first message.
+func (t *SequenceTracker) Check(key SequenceKey, seq uint32, count uint32, window uint32) (int, uint32) {
+	t.lock.Lock()
+	defer t.lock.Unlock()
+
+	now := t.now()
+	t.purge(now)
+
+	entry, ok := t.next[key]
+	if !ok {
+		t.next[key] = &sequenceEntry{
+			next: seq + count,
+			seen: now,
+		}
+		return SEQUENCE_FIRST, 0
+	}
+	entry.seen = now
+
+	diff := int64(int32(seq - entry.next)) // wraps around at 2^32
+	switch {
+	case diff == 0:
+		entry.next = seq + count
+		return SEQUENCE_OK, 0
+	case diff > 0 && diff <= int64(window):
+		entry.next = seq + count
+		return SEQUENCE_GAP, uint32(diff)
+	case diff < 0 && -diff <= int64(window):
+		// late message: keep expecting the same sequence number
+		return SEQUENCE_REORDERED, 0
+	default:
+		entry.next = seq + count
+		return SEQUENCE_RESET, 0
+	}
+}
+
+// Checks the sequence number and updates the exporter metrics
+func (t *SequenceTracker) Account(key SequenceKey, seq uint32, count uint32, window uint32) int {
+	if t == nil {
+		return SEQUENCE_OK
+	}
+	status, lost := t.Check(key, seq, count, window)
+	labels := prometheus.Labels{
+		"router":  key.Router,
+		"version": key.Version,
+		"domain":  key.Domain,
+		"type":    key.Type,
+	}
+	switch status {
+	case SEQUENCE_GAP:
+		ExporterLostSum.With(labels).Add(float64(lost))
+	case SEQUENCE_REORDERED:
+		ExporterReorderedSum.With(labels).Add(float64(count))
+	case SEQUENCE_RESET:
+		ExporterRestartsCount.With(labels).Inc()
+	}
+	return status
+}
@@ -0,0 +1,76 @@
+package utils
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSequenceTracker(t *testing.T) {
+	tracker := NewSequenceTracker()
+	key := SequenceKey{Router: "127.0.0.1", Version: "10", Domain: "1", Type: "records"}
+
+	status, lost := tracker.Check(key, 100, 10, SequenceWindowPackets)
+	assert.Equal(t, SEQUENCE_FIRST, status)
+
+	status, lost = tracker.Check(key, 110, 5, SequenceWindowPackets)
+	assert.Equal(t, SEQUENCE_OK, status)
+	assert.Equal(t, uint32(0), lost)
+
+	// records 115 to 119 are missing
+	status, lost = tracker.Check(key, 120, 5, SequenceWindowPackets)
+	assert.Equal(t, SEQUENCE_GAP, status)
+	assert.Equal(t, uint32(5), lost)
+
+	status, _ = tracker.Check(key, 115, 5, SequenceWindowPackets)
+	assert.Equal(t, SEQUENCE_REORDERED, status)
+
+	status, _ = tracker.Check(key, 125, 5, SequenceWindowPackets)
+	assert.Equal(t, SEQUENCE_OK, status)
+
+	// exporter restarted
+	status, _ = tracker.Check(key, 100000, 5, SequenceWindowPackets)
+	assert.Equal(t, SEQUENCE_RESET, status)
+	status, _ = tracker.Check(key, 0, 5, SequenceWindowPackets)
+	assert.Equal(t, SEQUENCE_RESET, status)
+
+	status, _ = tracker.Check(key, 5, 5, SequenceWindowPackets)
+	assert.Equal(t, SEQUENCE_OK, status)
+
+	// wrap around
+	keyWrap := SequenceKey{Router: "127.0.0.1", Version: "9", Domain: "1", Type: "packets"}
+	tracker.Check(keyWrap, 0xffffffff, 1, SequenceWindowPackets)
+	status, _ = tracker.Check(keyWrap, 0, 1, SequenceWindowPackets)
+	assert.Equal(t, SEQUENCE_OK, status)
+	status, lost = tracker.Check(keyWrap, 3, 1, SequenceWindowPackets)
+	assert.Equal(t, SEQUENCE_GAP, status)
+	assert.Equal(t, uint32(2), lost)
+}
+
+func TestSequenceTrackerTTL(t *testing.T) {
+	now := time.Date(2023, 3, 1, 10, 0, 0, 0, time.UTC)
+	tracker := NewSequenceTracker()
+	tracker.now = func() time.Time {
+		return now
+	}
+	active := SequenceKey{Router: "127.0.0.1", Version: "10", Domain: "1", Type: "packets"}
+	idle := SequenceKey{Router: "127.0.0.2", Version: "10", Domain: "1", Type: "packets"}
+
+	status, _ := tracker.Check(active, 1, 1, SequenceWindowPackets)
+	assert.Equal(t, SEQUENCE_FIRST, status)
+	status, _ = tracker.Check(idle, 1, 1, SequenceWindowPackets)
+	assert.Equal(t, SEQUENCE_FIRST, status)
+
+	now = now.Add(SequenceTTL / 2)
+	status, _ = tracker.Check(active, 2, 1, SequenceWindowPackets)
+	assert.Equal(t, SEQUENCE_OK, status)
+
+	// the idle exporter is forgotten, the active one is kept
+	now = now.Add(SequenceTTL / 2)
+	status, _ = tracker.Check(active, 3, 1, SequenceWindowPackets)
+	assert.Equal(t, SEQUENCE_OK, status)
+	assert.Len(t, tracker.next, 1)
+	status, _ = tracker.Check(idle, 2, 1, SequenceWindowPackets)
+	assert.Equal(t, SEQUENCE_FIRST, status)
+}
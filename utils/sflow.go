@@ -2,6 +2,7 @@ package utils
 
 import (
 	"bytes"
+	"fmt"
 	"net"
 	"time"
 
@@ -16,16 +17,21 @@ import (
 type StateSFlow struct {
 	stopper
 
-	Format    format.FormatInterface
-	Transport transport.TransportInterface
-	Logger    Logger
+	Format     format.FormatInterface
+	Transport  transport.TransportInterface
+	Logger     Logger
+	UDPOptions UDPOptions
 
 	Config       *producer.ProducerConfig
 	configMapped *producer.ProducerConfigMapped
+
+	sequence *SequenceTracker
 }
 
 func NewStateSFlow() *StateSFlow {
-	return &StateSFlow{}
+	return &StateSFlow{
+		sequence: NewSequenceTracker(),
+	}
 }
 
 func (s *StateSFlow) DecodeFlow(msg interface{}) error {
@@ -33,10 +39,12 @@ func (s *StateSFlow) DecodeFlow(msg interface{}) error {
 	buf := bytes.NewBuffer(pkt.Payload)
 	key := pkt.Src.String()
 
-	ts := uint64(time.Now().UTC().Unix())
+	recvTime := time.Now().UTC()
 	if pkt.SetTime {
-		ts = uint64(pkt.RecvTime.UTC().Unix())
+		recvTime = pkt.RecvTime.UTC()
 	}
+	ts := uint64(recvTime.Unix())
+	tsNs := uint64(recvTime.UnixNano())
 
 	timeTrackStart := time.Now()
 	msgDec, err := sflow.DecodeMessage(buf)
@@ -86,22 +94,50 @@ func (s *StateSFlow) DecodeFlow(msg interface{}) error {
 			}).
 			Inc()
 
+		domain := fmt.Sprintf("%s/%d", agentStr, msgDecConv.SubAgentId)
+		s.sequence.Account(SequenceKey{
+			Router:  key,
+			Version: "5",
+			Domain:  domain,
+			Type:    "packets",
+		}, msgDecConv.SequenceNumber, 1, SequenceWindowPackets)
+
 		for _, samples := range msgDecConv.Samples {
 			typeStr := "unknown"
 			countRec := 0
+			var header *sflow.SampleHeader
+			seqType := "flow_samples"
 			switch samplesConv := samples.(type) {
 			case sflow.FlowSample:
 				typeStr = "FlowSample"
 				countRec = len(samplesConv.Records)
+				header = &samplesConv.Header
 			case sflow.CounterSample:
 				typeStr = "CounterSample"
 				if samplesConv.Header.Format == 4 {
 					typeStr = "Expanded" + typeStr
 				}
 				countRec = len(samplesConv.Records)
+				header = &samplesConv.Header
+				seqType = "counter_samples"
 			case sflow.ExpandedFlowSample:
 				typeStr = "ExpandedFlowSample"
 				countRec = len(samplesConv.Records)
+				header = &samplesConv.Header
+			case sflow.DropSample:
+				typeStr = "DropSample"
+				countRec = len(samplesConv.Records)
+				header = &samplesConv.Header
+				seqType = "drop_samples"
+			}
+			if header != nil {
+				// sequence numbers are kept per data source
+				s.sequence.Account(SequenceKey{
+					Router:  key,
+					Version: "5",
+					Domain:  fmt.Sprintf("%s/%d:%d", domain, header.SourceIdType, header.SourceIdValue),
+					Type:    seqType,
+				}, header.SampleSequenceNumber, 1, SequenceWindowPackets)
 			}
 			SFlowSampleStatsSum.With(
 				prometheus.Labels{
@@ -136,23 +172,13 @@ func (s *StateSFlow) DecodeFlow(msg interface{}) error {
 
 	for _, fmsg := range flowMessageSet {
 		fmsg.TimeReceived = ts
+		fmsg.TimeReceivedNs = tsNs
 		fmsg.TimeFlowStart = ts
 		fmsg.TimeFlowEnd = ts
-
-		if s.Format != nil {
-			key, data, err := s.Format.Format(fmsg)
-
-			if err != nil && s.Logger != nil {
-				s.Logger.Error(err)
-			}
-			if err == nil && s.Transport != nil {
-				err = s.Transport.Send(key, data)
-				if err != nil {
-					s.Logger.Error(err)
-				}
-			}
-		}
+		fmsg.TimeFlowStartMs = tsNs / 1000000
+		fmsg.TimeFlowEndMs = tsNs / 1000000
 	}
+	sendFlowMessages(s.Format, s.Transport, s.Logger, flowMessageSet)
 
 	return nil
 }
@@ -166,5 +192,8 @@ func (s *StateSFlow) FlowRoutine(workers int, addr string, port int, reuseport b
 		return err
 	}
 	s.initConfig()
-	return UDPStoppableRoutine(s.stopCh, "sFlow", s.DecodeFlow, workers, addr, port, reuseport, s.Logger)
+	if s.sequence == nil {
+		s.sequence = NewSequenceTracker()
+	}
+	return UDPStoppableRoutineOptions(s.stopCh, "sFlow", s.DecodeFlow, workers, addr, port, reuseport, s.Logger, s.UDPOptions)
 }
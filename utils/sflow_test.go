@@ -1,8 +1,12 @@
 package utils
 
 import (
-	"github.com/stretchr/testify/assert"
+	"errors"
 	"testing"
+
+	flowmessage "github.com/netsampler/goflow2/pb"
+	"github.com/netsampler/goflow2/transport"
+	"github.com/stretchr/testify/assert"
 )
 
 func TestDecodeFlowExpandedSFlow(t *testing.T) {
@@ -17,6 +21,66 @@ func TestDecodeFlowExpandedSFlow(t *testing.T) {
 	assert.Nil(t, s.DecodeFlow(msg))
 }
 
+type dummyFormat struct {
+	count int
+}
+
+func (d *dummyFormat) Format(data interface{}) ([]byte, []byte, error) {
+	d.count++
+	if d.count == 2 {
+		return nil, nil, errors.New("format error")
+	}
+	return []byte("key"), []byte("data"), nil
+}
+
+type dummyBatchTransport struct {
+	sent    int
+	batches [][]transport.Message
+	flushes int
+}
+
+func (d *dummyBatchTransport) Send(key, data []byte) error {
+	d.sent++
+	return nil
+}
+
+func (d *dummyBatchTransport) SendBatch(msgs []transport.Message) error {
+	d.batches = append(d.batches, msgs)
+	return nil
+}
+
+func (d *dummyBatchTransport) Flush() error {
+	d.flushes++
+	return nil
+}
+
+func TestDecodeFlowBatchSFlow(t *testing.T) {
+	msg := BaseMessage{
+		Src:     []byte{},
+		Port:    1,
+		Payload: getExpandedSFlowDecode(),
+	}
+
+	f := &dummyFormat{}
+	tr := &dummyBatchTransport{}
+	s := &StateSFlow{
+		Format:    f,
+		Transport: tr,
+	}
+	assert.Nil(t, s.DecodeFlow(msg))
+
+	// the flows of the packet are sent at once, without the one failing to format
+	assert.Greater(t, f.count, 2)
+	assert.Equal(t, 0, tr.sent)
+	if assert.Len(t, tr.batches, 1) {
+		assert.Len(t, tr.batches[0], f.count-1)
+		assert.Equal(t, []byte("key"), tr.batches[0][0].Key)
+		assert.Equal(t, []byte("data"), tr.batches[0][0].Data)
+		assert.IsType(t, &flowmessage.FlowMessage{}, tr.batches[0][0].Source)
+	}
+	assert.Equal(t, 1, tr.flushes)
+}
+
 func getExpandedSFlowDecode() []byte {
 	return []byte{
 		0, 0, 0, 5, 0, 0, 0, 1, 1, 2, 3, 4, 0, 0, 0, 0, 5, 167, 139, 219, 5, 118,
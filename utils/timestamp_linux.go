@@ -0,0 +1,63 @@
+//go:build linux
+
+package utils
+
+import (
+	"net"
+	"syscall"
+	"time"
+	"unsafe"
+)
+
+func setSocketOption(conn *net.UDPConn, option int) error {
+	rawConn, err := conn.SyscallConn()
+	if err != nil {
+		return err
+	}
+	var sockErr error
+	err = rawConn.Control(func(fd uintptr) {
+		sockErr = syscall.SetsockoptInt(int(fd), syscall.SOL_SOCKET, option, 1)
+	})
+	if err != nil {
+		return err
+	}
+	return sockErr
+}
+
+// Requests the kernel to timestamp the received datagrams (SO_TIMESTAMPNS)
+func enableKernelTimestamps(conn *net.UDPConn) error {
+	return setSocketOption(conn, syscall.SO_TIMESTAMPNS)
+}
+
+// Requests the kernel to send the amount of datagrams dropped by the socket (SO_RXQ_OVFL)
+func enableKernelDrops(conn *net.UDPConn) error {
+	return setSocketOption(conn, syscall.SO_RXQ_OVFL)
+}
+
+// Extracts the kernel timestamp and the drop counter from the control messages of a datagram
+func parseControlMessage(oob []byte) (cm controlMessage) {
+	msgs, err := syscall.ParseSocketControlMessage(oob)
+	if err != nil {
+		return cm
+	}
+	for _, msg := range msgs {
+		if msg.Header.Level != syscall.SOL_SOCKET {
+			continue
+		}
+		switch msg.Header.Type {
+		case syscall.SCM_TIMESTAMPNS:
+			var ts syscall.Timespec
+			if len(msg.Data) < int(unsafe.Sizeof(ts)) {
+				continue
+			}
+			ts = *(*syscall.Timespec)(unsafe.Pointer(&msg.Data[0]))
+			cm.recvTime, cm.hasTime = time.Unix(ts.Unix()), true
+		case syscall.SO_RXQ_OVFL:
+			if len(msg.Data) < 4 {
+				continue
+			}
+			cm.drops, cm.hasDrops = *(*uint32)(unsafe.Pointer(&msg.Data[0])), true
+		}
+	}
+	return cm
+}
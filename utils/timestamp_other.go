@@ -0,0 +1,20 @@
+//go:build !linux
+
+package utils
+
+import (
+	"errors"
+	"net"
+)
+
+func enableKernelTimestamps(conn *net.UDPConn) error {
+	return errors.New("kernel timestamps are only supported on Linux")
+}
+
+func enableKernelDrops(conn *net.UDPConn) error {
+	return errors.New("kernel drop counter is only supported on Linux")
+}
+
+func parseControlMessage(oob []byte) (cm controlMessage) {
+	return cm
+}
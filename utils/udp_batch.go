@@ -0,0 +1,102 @@
+package utils
+
+import (
+	"net"
+	"sync"
+	"time"
+
+	"golang.org/x/net/ipv4"
+	"golang.org/x/net/ipv6"
+)
+
+const udpBufferSize = 9000
+
+// Buffers of the batch receive path, given back once the datagram is decoded
+var udpBufferPool = sync.Pool{
+	New: func() interface{} {
+		b := make([]byte, udpBufferSize)
+		return &b
+	},
+}
+
+func releaseUDPBuffer(buffer *[]byte) {
+	if buffer != nil {
+		udpBufferPool.Put(buffer)
+	}
+}
+
+type batchConn interface {
+	ReadBatch(ms []ipv4.Message, flags int) (int, error)
+}
+
+// Reads several datagrams per system call (recvmmsg on Linux, one datagram per call on the other systems)
+type udpBatchReader struct {
+	conn     batchConn
+	messages []ipv4.Message
+	buffers  []*[]byte
+	oob      bool
+}
+
+func newUDPBatchReader(udpconn *net.UDPConn, size int, oob bool) *udpBatchReader {
+	var conn batchConn
+	if addr, ok := udpconn.LocalAddr().(*net.UDPAddr); ok && addr.IP.To4() != nil {
+		conn = ipv4.NewPacketConn(udpconn)
+	} else {
+		conn = ipv6.NewPacketConn(udpconn) // also receives IPv4 on dual-stack sockets
+	}
+	r := &udpBatchReader{
+		conn:     conn,
+		messages: make([]ipv4.Message, size),
+		buffers:  make([]*[]byte, size),
+		oob:      oob,
+	}
+	for i := range r.messages {
+		r.messages[i].Buffers = make([][]byte, 1)
+		if oob {
+			r.messages[i].OOB = make([]byte, 128)
+		}
+	}
+	return r
+}
+
+// Reads a batch of datagrams and passes them to handle.
+// The payloads are pooled buffers which must be released with releaseUDPBuffer.
+func (r *udpBatchReader) read(handle func(udpData)) error {
+	for i := range r.messages {
+		if r.buffers[i] == nil {
+			r.buffers[i] = udpBufferPool.Get().(*[]byte)
+		}
+		r.messages[i].Buffers[0] = *r.buffers[i]
+		r.messages[i].OOB = r.messages[i].OOB[:cap(r.messages[i].OOB)]
+	}
+
+	n, err := r.conn.ReadBatch(r.messages, 0)
+	if err != nil {
+		return err
+	}
+	recvTime := time.Now()
+
+	for i := 0; i < n; i++ {
+		msg := &r.messages[i]
+		pktAddr, ok := msg.Addr.(*net.UDPAddr)
+		if msg.N == 0 || !ok { // Ignore 0 byte packets, the buffer is kept for the next batch.
+			continue
+		}
+		u := udpData{
+			size:     msg.N,
+			pktAddr:  pktAddr,
+			payload:  (*r.buffers[i])[:msg.N],
+			buffer:   r.buffers[i],
+			recvTime: recvTime,
+		}
+		if r.oob {
+			u.control = parseControlMessage(msg.OOB[:msg.NN])
+			if u.control.hasTime {
+				u.recvTime = u.control.recvTime
+			}
+		}
+		r.buffers[i] = nil
+		handle(u)
+	}
+	return nil
+}
@@ -1,6 +1,7 @@
 package utils
 
 import (
+	"context"
 	"errors"
 	"fmt"
 	"io"
@@ -12,8 +13,10 @@ import (
 	reuseport "github.com/libp2p/go-reuseport"
 	decoder "github.com/netsampler/goflow2/decoders"
 	"github.com/netsampler/goflow2/decoders/netflow"
+	"github.com/netsampler/goflow2/format"
 	flowmessage "github.com/netsampler/goflow2/pb"
 	"github.com/netsampler/goflow2/producer"
+	"github.com/netsampler/goflow2/transport"
 	"github.com/prometheus/client_golang/prometheus"
 	"gopkg.in/yaml.v2"
 )
@@ -23,8 +26,10 @@ type ProducerConfig *producer.ProducerConfig
 func LoadMapping(f io.Reader) (ProducerConfig, error) {
 	config := &producer.ProducerConfig{}
 	dec := yaml.NewDecoder(f)
-	err := dec.Decode(config)
-	return config, err
+	if err := dec.Decode(config); err != nil {
+		return config, err
+	}
+	return config, config.Validate()
 }
 
 func GetServiceAddresses(srv string) (addrs []string, err error) {
@@ -57,6 +62,8 @@ type BaseMessage struct {
 
 	SetTime  bool
 	RecvTime time.Time
+
+	buffer *[]byte // pooled buffer of the payload (batch receive)
 }
 
 type Transport interface {
@@ -67,6 +74,44 @@ type Formatter interface {
 	Format([]*flowmessage.FlowMessage)
 }
 
+// Formats the flow messages of a packet and hands them to the transport at once
+// when it supports batches, otherwise one by one
+func sendFlowMessages(f format.FormatInterface, t transport.TransportInterface, logger Logger, flowMessageSet []*flowmessage.FlowMessage) {
+	if f == nil || len(flowMessageSet) == 0 {
+		return
+	}
+	msgs := make([]transport.Message, 0, len(flowMessageSet))
+	for _, fmsg := range flowMessageSet {
+		key, data, err := f.Format(fmsg)
+		if err != nil {
+			if logger != nil {
+				logger.Error(err)
+			}
+			continue
+		}
+		msgs = append(msgs, transport.Message{Key: key, Data: data, Source: fmsg})
+	}
+	if t == nil || len(msgs) == 0 {
+		return
+	}
+
+	if bt, ok := t.(transport.BatchTransport); ok {
+		err := bt.SendBatch(msgs)
+		if err == nil {
+			err = bt.Flush()
+		}
+		if err != nil && logger != nil {
+			logger.Error(err)
+		}
+		return
+	}
+	for _, msg := range msgs {
+		if err := t.Send(msg.Key, msg.Data); err != nil && logger != nil {
+			logger.Error(err)
+		}
+	}
+}
+
 /*
 type DefaultLogTransport struct {
 }
@@ -91,6 +136,12 @@ type DefaultErrorCallback struct {
 }
 
 func (cb *DefaultErrorCallback) Callback(name string, id int, start, end time.Time, err error) {
+	DecoderErrors.With(
+		prometheus.Labels{
+			"worker": strconv.Itoa(id),
+			"name":   name,
+		}).
+		Inc()
 	if _, ok := err.(*netflow.ErrorTemplateNotFound); ok {
 		return
 	}
@@ -99,12 +150,60 @@ func (cb *DefaultErrorCallback) Callback(name string, id int, start, end time.Ti
 	}
 }
 
+// Without a queue, nearly every datagram would be dropped while the decoders are busy
+var ErrDropPolicyQueue = errors.New("the drop policy requires a queue")
+
+const (
+	QUEUE_POLICY_BLOCK = "block" // the socket reader waits for the decoders (default)
+	QUEUE_POLICY_DROP  = "drop"  // the datagrams received when the queue is full are dropped
+)
+
+// Options of the UDP listeners
+type UDPOptions struct {
+	KernelTimestamps bool   // receive time from the kernel (SO_TIMESTAMPNS, Linux only) instead of the reading time
+	KernelDrops      bool   // datagrams dropped by the socket from the kernel (SO_RXQ_OVFL, Linux only)
+	BatchSize        int    // datagrams read per system call (recvmmsg on Linux), 0 or 1 to read one at a time
+	QueueSize        int    // datagrams buffered between the socket reader and the decoders
+	QueuePolicy      string // behavior when the queue is full: block or drop (requires a queue)
+	ReceiveBuffer    int    // size of the socket receive buffer (SO_RCVBUF), 0 for the system default
+
+	Quarantine *Quarantine // writes the datagrams which could not be decoded, disabled when nil
+}
+
+type udpData struct {
+	size     int
+	pktAddr  *net.UDPAddr
+	payload  []byte
+	buffer   *[]byte
+	recvTime time.Time
+	control  controlMessage
+}
+
+// Information from the control messages of a datagram
+type controlMessage struct {
+	recvTime time.Time
+	hasTime  bool
+	drops    uint32 // datagrams dropped by the socket since it was opened (SO_RXQ_OVFL)
+	hasDrops bool
+}
+
 func UDPRoutine(name string, decodeFunc decoder.DecoderFunc, workers int, addr string, port int, sockReuse bool, logger Logger) error {
 	return UDPStoppableRoutine(make(chan struct{}), name, decodeFunc, workers, addr, port, sockReuse, logger)
 }
 
 // UDPStoppableRoutine runs a UDPRoutine that can be stopped by closing the stopCh passed as argument
 func UDPStoppableRoutine(stopCh <-chan struct{}, name string, decodeFunc decoder.DecoderFunc, workers int, addr string, port int, sockReuse bool, logger Logger) error {
+	return UDPStoppableRoutineOptions(stopCh, name, decodeFunc, workers, addr, port, sockReuse, logger, UDPOptions{})
+}
+
+// UDPStoppableRoutineOptions runs a UDPStoppableRoutine with additional socket options.
+// With batches, the payload of the messages is a pooled buffer: it must not be kept after decodeFunc returns.
+// The flow messages point into the payload, the transports copy what they keep (see transport.BatchTransport).
+func UDPStoppableRoutineOptions(stopCh <-chan struct{}, name string, decodeFunc decoder.DecoderFunc, workers int, addr string, port int, sockReuse bool, logger Logger, options UDPOptions) error {
+	if options.QueuePolicy == QUEUE_POLICY_DROP && options.QueueSize <= 0 {
+		return ErrDropPolicyQueue
+	}
+
 	ecb := DefaultErrorCallback{
 		Logger: logger,
 	}
@@ -113,10 +212,42 @@ func UDPStoppableRoutine(stopCh <-chan struct{}, name string, decodeFunc decoder
 		DecoderFunc:   decodeFunc,
 		DoneCallback:  DefaultAccountCallback,
 		ErrorCallback: ecb.Callback,
+		// called once the message is decoded, its buffer can be reused
+		ResultCallback: func(result decoder.Result) {
+			baseMessage, ok := result.Message.(BaseMessage)
+			if !ok {
+				return
+			}
+			if result.Err != nil && options.Quarantine != nil {
+				if _, err := options.Quarantine.Add(name, baseMessage, result.Err); err != nil && logger != nil {
+					logger.Errorf("Error writing to the quarantine: %v", err)
+				}
+			}
+			releaseUDPBuffer(baseMessage.buffer)
+		},
+		// the packets of an exporter are decoded in order (templates and sequence numbers)
+		KeyFunc: func(msg decoder.Message) []byte {
+			if baseMessage, ok := msg.(BaseMessage); ok {
+				return baseMessage.Src
+			}
+			return nil
+		},
 	}
 
+	// the workers stop with the routine
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	go func() {
+		select {
+		case <-stopCh:
+			cancel()
+		case <-ctx.Done():
+		}
+	}()
+
 	processor := decoder.CreateProcessor(workers, decoderParams, name)
-	processor.Start()
+	processor.StartContext(ctx)
+	defer processor.Stop()
 
 	addrUDP := net.UDPAddr{
 		IP:   net.ParseIP(addr),
@@ -145,29 +276,107 @@ func UDPStoppableRoutine(stopCh <-chan struct{}, name string, decodeFunc decoder
 		defer udpconn.Close()
 	}
 
-	payload := make([]byte, 9000)
+	if options.ReceiveBuffer > 0 {
+		if err := udpconn.SetReadBuffer(options.ReceiveBuffer); err != nil {
+			return err
+		}
+	}
+
+	var oob []byte
+	if options.KernelTimestamps {
+		if err := enableKernelTimestamps(udpconn); err != nil {
+			return err
+		}
+		oob = make([]byte, 128)
+	}
+	if options.KernelDrops {
+		if err := enableKernelDrops(udpconn); err != nil {
+			return err
+		}
+		oob = make([]byte, 128)
+	}
+
+	payload := make([]byte, udpBufferSize)
 
 	localIP := addrUDP.IP.String()
 	if addrUDP.IP == nil {
 		localIP = ""
 	}
 
-	type udpData struct {
-		size    int
-		pktAddr *net.UDPAddr
-		payload []byte
+	listenerLabels := prometheus.Labels{
+		"local_ip":   localIP,
+		"local_port": strconv.Itoa(addrUDP.Port),
+		"type":       name,
 	}
+	queueDepth := MetricQueueDepth.With(listenerLabels)
+	queueDrops := MetricQueueDrops.With(listenerLabels)
+	kernelDrops := MetricKernelDrops.With(listenerLabels)
 
-	udpDataCh := make(chan udpData)
-	defer close(udpDataCh)
+	udpDataCh := make(chan udpData, options.QueueSize)
+
+	var lastDrops uint32
+	enqueue := func(u udpData) {
+		if u.control.hasDrops && u.control.drops != lastDrops {
+			kernelDrops.Add(float64(u.control.drops - lastDrops))
+			lastDrops = u.control.drops
+		}
+		if options.QueuePolicy == QUEUE_POLICY_DROP {
+			select {
+			case udpDataCh <- u:
+			default:
+				releaseUDPBuffer(u.buffer)
+				queueDrops.Inc()
+			}
+		} else {
+			select {
+			case udpDataCh <- u:
+			case <-stopCh:
+				releaseUDPBuffer(u.buffer)
+			}
+		}
+		queueDepth.Set(float64(len(udpDataCh)))
+	}
 
 	wg := &sync.WaitGroup{}
 	wg.Add(1)
 	go func() {
 		defer wg.Done()
+		if options.BatchSize > 1 {
+			reader := newUDPBatchReader(udpconn, options.BatchSize, oob != nil)
+			stopped := false
+			for !stopped {
+				err := reader.read(func(u udpData) {
+					select {
+					case <-stopCh:
+						releaseUDPBuffer(u.buffer)
+						stopped = true
+					default:
+						enqueue(u)
+					}
+				})
+				if errors.Is(err, net.ErrClosed) {
+					return
+				}
+			}
+			return
+		}
 		for {
 			u := udpData{}
-			u.size, u.pktAddr, _ = udpconn.ReadFromUDP(payload)
+			var err error
+			if oob != nil {
+				var oobn int
+				u.size, oobn, _, u.pktAddr, err = udpconn.ReadMsgUDP(payload, oob)
+				u.control = parseControlMessage(oob[:oobn])
+			} else {
+				u.size, u.pktAddr, err = udpconn.ReadFromUDP(payload)
+			}
+			if errors.Is(err, net.ErrClosed) {
+				return
+			}
+			u.recvTime = time.Now()
+			if u.control.hasTime {
+				u.recvTime = u.control.recvTime
+			}
 			if u.size == 0 { // Ignore 0 byte packets.
 				continue
 			}
@@ -177,7 +386,7 @@ func UDPStoppableRoutine(stopCh <-chan struct{}, name string, decodeFunc decoder
 			case <-stopCh:
 				return
 			default:
-				udpDataCh <- u
+				enqueue(u)
 			}
 		}
 	}()
@@ -185,27 +394,37 @@ func UDPStoppableRoutine(stopCh <-chan struct{}, name string, decodeFunc decoder
 		for {
 			select {
 			case u := <-udpDataCh:
-				process(u.size, u.payload, u.pktAddr, processor, localIP, addrUDP, name)
+				queueDepth.Set(float64(len(udpDataCh)))
+				process(u.size, u.payload, u.buffer, u.pktAddr, u.recvTime, processor, localIP, addrUDP, name)
 			case <-stopCh:
 				return
 			}
 		}
 	}()
 
-	for _ = range udpDataCh {
-		// drain
-	}
+	// unblocks the reader
+	udpconn.Close()
 	wg.Wait()
+	close(udpDataCh)
+	for u := range udpDataCh {
+		releaseUDPBuffer(u.buffer)
+	}
 	return nil
 }
 
-func process(size int, payload []byte, pktAddr *net.UDPAddr, processor decoder.Processor, localIP string, addrUDP net.UDPAddr, name string) {
+func process(size int, payload []byte, buffer *[]byte, pktAddr *net.UDPAddr, recvTime time.Time, processor *decoder.Processor, localIP string, addrUDP net.UDPAddr, name string) {
 	baseMessage := BaseMessage{
-		Src:     pktAddr.IP,
-		Port:    pktAddr.Port,
-		Payload: payload,
+		Src:      pktAddr.IP,
+		Port:     pktAddr.Port,
+		Payload:  payload,
+		SetTime:  true,
+		RecvTime: recvTime,
+		buffer:   buffer,
+	}
+	if !processor.ProcessMessage(baseMessage) {
+		releaseUDPBuffer(buffer) // stopped
+		return
 	}
-	processor.ProcessMessage(baseMessage)
 
 	MetricTrafficBytes.With(
 		prometheus.Labels{
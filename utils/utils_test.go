@@ -1,11 +1,21 @@
 package utils
 
 import (
+	"errors"
 	"fmt"
 	"net"
+	"os"
+	"path/filepath"
+	"runtime"
+	"strconv"
+	"sync/atomic"
 	"testing"
 	"time"
 
+	decoder "github.com/netsampler/goflow2/decoders"
+	"github.com/netsampler/goflow2/decoders/netflow"
+	"github.com/prometheus/client_golang/prometheus"
+	dto "github.com/prometheus/client_model/go"
 	"github.com/sirupsen/logrus"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
@@ -65,18 +75,311 @@ func TestCancelUDPRoutine(t *testing.T) {
 	}
 }
 
+func TestUDPRoutineKernelTimestamps(t *testing.T) {
+	if runtime.GOOS != "linux" {
+		t.Skip("kernel timestamps are only supported on Linux")
+	}
+	port, err := getFreeUDPPort()
+	require.NoError(t, err)
+	dp := dummyFlowProcessor{
+		options: UDPOptions{KernelTimestamps: true},
+	}
+	go func() {
+		require.NoError(t, dp.FlowRoutine("127.0.0.1", port))
+	}()
+	defer dp.Shutdown()
+
+	time.Sleep(100 * time.Millisecond)
+
+	before := time.Now()
+	conn, err := net.Dial("udp", fmt.Sprintf("127.0.0.1:%d", port))
+	require.NoError(t, err)
+	defer conn.Close()
+	_, err = conn.Write([]byte("message"))
+	require.NoError(t, err)
+
+	select {
+	case msg := <-dp.receivedMessages:
+		baseMessage := msg.(BaseMessage)
+		assert.True(t, baseMessage.SetTime)
+		assert.False(t, baseMessage.RecvTime.Before(before.Add(-time.Second)))
+		assert.False(t, baseMessage.RecvTime.After(time.Now()))
+	case <-time.After(10 * time.Second):
+		require.Fail(t, "test timed out while waiting for message")
+	}
+}
+
+func TestUDPRoutineBatch(t *testing.T) {
+	port, err := getFreeUDPPort()
+	require.NoError(t, err)
+	dp := dummyFlowProcessor{
+		options: UDPOptions{BatchSize: 8, QueueSize: 16},
+	}
+	go func() {
+		require.NoError(t, dp.FlowRoutine("127.0.0.1", port))
+	}()
+	defer dp.Shutdown()
+
+	time.Sleep(100 * time.Millisecond)
+
+	conn, err := net.Dial("udp", fmt.Sprintf("127.0.0.1:%d", port))
+	require.NoError(t, err)
+	defer conn.Close()
+	messages := []string{"message 1", "message 2", "message 3"}
+	for _, msg := range messages {
+		_, err = conn.Write([]byte(msg))
+		require.NoError(t, err)
+	}
+
+	for range messages {
+		select {
+		case msg := <-dp.receivedMessages:
+			baseMessage := msg.(BaseMessage)
+			assert.Contains(t, messages, string(baseMessage.Payload))
+			assert.Equal(t, net.IPv4(127, 0, 0, 1).To4(), baseMessage.Src.To4())
+			assert.True(t, baseMessage.SetTime)
+		case <-time.After(10 * time.Second):
+			require.Fail(t, "test timed out while waiting for message")
+		}
+	}
+}
+
+// Measures the time to receive and dispatch a datagram while a sender floods the listener
+func TestUDPRoutineQueueDrop(t *testing.T) {
+	port, err := getFreeUDPPort()
+	require.NoError(t, err)
+	dp := dummyFlowProcessor{
+		options: UDPOptions{QueueSize: 1, QueuePolicy: QUEUE_POLICY_DROP},
+	}
+	go func() {
+		require.NoError(t, dp.FlowRoutine("127.0.0.1", port))
+	}()
+	defer dp.Shutdown()
+
+	time.Sleep(100 * time.Millisecond)
+
+	conn, err := net.Dial("udp", fmt.Sprintf("127.0.0.1:%d", port))
+	require.NoError(t, err)
+	defer conn.Close()
+	// the decoders are blocked until the messages are read: the queue fills up
+	for i := 0; i < 10; i++ {
+		_, err = conn.Write([]byte("message"))
+		require.NoError(t, err)
+	}
+	time.Sleep(200 * time.Millisecond)
+
+	var metric dto.Metric
+	require.NoError(t, MetricQueueDrops.With(prometheus.Labels{
+		"local_ip":   "127.0.0.1",
+		"local_port": strconv.Itoa(port),
+		"type":       "test_udp",
+	}).Write(&metric))
+	drops := int(metric.GetCounter().GetValue())
+	assert.Greater(t, drops, 0)
+
+	// every message which was not dropped is decoded
+	for i := 0; i < 10-drops; i++ {
+		select {
+		case <-dp.receivedMessages:
+		case <-time.After(10 * time.Second):
+			require.Fail(t, "test timed out while waiting for message")
+		}
+	}
+}
+
+func TestUDPRoutineQueueDropWithoutQueue(t *testing.T) {
+	port, err := getFreeUDPPort()
+	require.NoError(t, err)
+	dp := dummyFlowProcessor{
+		options: UDPOptions{QueuePolicy: QUEUE_POLICY_DROP},
+	}
+	defer dp.Shutdown()
+	assert.Equal(t, ErrDropPolicyQueue, dp.FlowRoutine("127.0.0.1", port))
+}
+
+func TestUDPRoutineKernelDrops(t *testing.T) {
+	if runtime.GOOS != "linux" {
+		t.Skip("the kernel drop counter is only supported on Linux")
+	}
+	port, err := getFreeUDPPort()
+	require.NoError(t, err)
+	dp := dummyFlowProcessor{
+		options: UDPOptions{KernelDrops: true},
+	}
+	go func() {
+		require.NoError(t, dp.FlowRoutine("127.0.0.1", port))
+	}()
+	defer dp.Shutdown()
+
+	time.Sleep(100 * time.Millisecond)
+
+	conn, err := net.Dial("udp", fmt.Sprintf("127.0.0.1:%d", port))
+	require.NoError(t, err)
+	defer conn.Close()
+	_, err = conn.Write([]byte("message"))
+	require.NoError(t, err)
+
+	select {
+	case msg := <-dp.receivedMessages:
+		assert.Equal(t, "message", string(msg.(BaseMessage).Payload))
+	case <-time.After(10 * time.Second):
+		require.Fail(t, "test timed out while waiting for message")
+	}
+}
+
+func benchmarkUDPRoutine(b *testing.B, options UDPOptions) {
+	port, err := getFreeUDPPort()
+	require.NoError(b, err)
+
+	var received int64
+	stopCh := make(chan struct{})
+	defer close(stopCh)
+	go UDPStoppableRoutineOptions(stopCh, "bench_udp", func(msg interface{}) error {
+		atomic.AddInt64(&received, 1)
+		return nil
+	}, 4, "127.0.0.1", port, false, logrus.StandardLogger(), options)
+	time.Sleep(100 * time.Millisecond)
+
+	conn, err := net.Dial("udp", fmt.Sprintf("127.0.0.1:%d", port))
+	require.NoError(b, err)
+	defer conn.Close()
+	senderStopCh := make(chan struct{})
+	defer close(senderStopCh)
+	go func() {
+		payload := make([]byte, 1400)
+		for {
+			select {
+			case <-senderStopCh:
+				return
+			default:
+				conn.Write(payload)
+			}
+		}
+	}()
+
+	timeout := time.After(time.Minute)
+	b.ResetTimer()
+	startTime := time.Now()
+	start := atomic.LoadInt64(&received)
+	for atomic.LoadInt64(&received)-start < int64(b.N) {
+		select {
+		case <-timeout:
+			b.Fatal("timed out while receiving datagrams")
+		default:
+			runtime.Gosched()
+		}
+	}
+	b.StopTimer()
+	b.ReportMetric(float64(b.N)/time.Since(startTime).Seconds(), "pps")
+}
+
+func BenchmarkUDPRoutine(b *testing.B) {
+	b.Run("single", func(b *testing.B) {
+		benchmarkUDPRoutine(b, UDPOptions{})
+	})
+	b.Run("batch-32", func(b *testing.B) {
+		benchmarkUDPRoutine(b, UDPOptions{BatchSize: 32, QueueSize: 1024})
+	})
+	b.Run("batch-64", func(b *testing.B) {
+		benchmarkUDPRoutine(b, UDPOptions{BatchSize: 64, QueueSize: 4096})
+	})
+}
+
+func TestQuarantine(t *testing.T) {
+	quarantine, err := NewQuarantine(t.TempDir(), 2, nil)
+	require.NoError(t, err)
+
+	msg := BaseMessage{
+		Src:      net.ParseIP("2001:db8::1"),
+		Payload:  []byte{0x00, 0x0a, 0xff},
+		SetTime:  true,
+		RecvTime: time.Unix(1700000000, 0),
+	}
+	path, err := quarantine.Add("NetFlow", msg, netflow.NewErrorTemplateNotFound(10, 0, 256, "info"))
+	require.NoError(t, err)
+	assert.Empty(t, path, "template errors are not quarantined")
+
+	path, err = quarantine.Add("NetFlow", msg, &decoder.ErrorDecoderPanic{Value: "index out of range"})
+	require.NoError(t, err)
+	assert.Equal(t, filepath.Join(quarantine.Dir, "NetFlow-2001_db8__1-1700000000000000000-1.bin"), path)
+	data, err := os.ReadFile(path)
+	require.NoError(t, err)
+	assert.Equal(t, msg.Payload, data)
+
+	path, err = quarantine.Add("NetFlow", msg, errors.New("decoding error"))
+	require.NoError(t, err)
+	assert.NotEmpty(t, path)
+
+	path, err = quarantine.Add("NetFlow", msg, errors.New("decoding error"))
+	require.NoError(t, err)
+	assert.Empty(t, path, "the limit is reached")
+}
+
+func TestUDPRoutineQuarantine(t *testing.T) {
+	port, err := getFreeUDPPort()
+	require.NoError(t, err)
+	quarantine, err := NewQuarantine(t.TempDir(), 0, nil)
+	require.NoError(t, err)
+
+	decoded := make(chan string, 2)
+	stopCh := make(chan struct{})
+	defer close(stopCh)
+	go func() {
+		require.NoError(t, UDPStoppableRoutineOptions(stopCh, "test_udp", func(msg interface{}) error {
+			payload := string(msg.(BaseMessage).Payload)
+			defer func() { decoded <- payload }()
+			if payload == "malformed" {
+				var values []byte
+				_ = values[len(payload)] // decoder bug
+			}
+			return nil
+		}, 1, "127.0.0.1", port, false, nil, UDPOptions{BatchSize: 4, Quarantine: quarantine}))
+	}()
+
+	time.Sleep(100 * time.Millisecond)
+
+	conn, err := net.Dial("udp", fmt.Sprintf("127.0.0.1:%d", port))
+	require.NoError(t, err)
+	defer conn.Close()
+	for _, msg := range []string{"malformed", "valid"} {
+		_, err = conn.Write([]byte(msg))
+		require.NoError(t, err)
+	}
+	for i := 0; i < 2; i++ {
+		select {
+		case <-decoded:
+		case <-time.After(10 * time.Second):
+			require.Fail(t, "test timed out while waiting for message")
+		}
+	}
+
+	// the file is written after the decoding
+	var files []string
+	require.Eventually(t, func() bool {
+		files, _ = filepath.Glob(filepath.Join(quarantine.Dir, "test_udp-127.0.0.1-*.bin"))
+		return len(files) == 1
+	}, 5*time.Second, 10*time.Millisecond)
+	data, err := os.ReadFile(files[0])
+	require.NoError(t, err)
+	assert.Equal(t, "malformed", string(data))
+}
+
 type dummyFlowProcessor struct {
 	stopper
 	receivedMessages chan interface{}
+	options          UDPOptions
 }
 
 func (d *dummyFlowProcessor) FlowRoutine(host string, port int) error {
 	_ = d.start()
 	d.receivedMessages = make(chan interface{})
-	return UDPStoppableRoutine(d.stopCh, "test_udp", func(msg interface{}) error {
-		d.receivedMessages <- msg
+	return UDPStoppableRoutineOptions(d.stopCh, "test_udp", func(msg interface{}) error {
+		// the payload is not kept by the routine once decoded
+		baseMessage := msg.(BaseMessage)
+		baseMessage.Payload = append([]byte{}, baseMessage.Payload...)
+		d.receivedMessages <- baseMessage
 		return nil
-	}, 3, host, port, false, logrus.StandardLogger())
+	}, 3, host, port, false, logrus.StandardLogger(), d.options)
 }
 
 func getFreeUDPPort() (int, error) {